@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/internal/transport/dto"
+	"github.com/nabil/book-store-system/pkg/database"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const usage = `Usage: admin <command> [flags]
+
+Commands:
+  create    Create a super_admin account, or promote an existing user to super_admin with -promote
+
+Flags for create (default to the ADMIN_EMAIL, ADMIN_PASSWORD and ADMIN_NAME environment variables):
+`
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "create" {
+		fmt.Fprint(os.Stderr, usage)
+		createFlags().PrintDefaults()
+		os.Exit(2)
+	}
+
+	fs := createFlags()
+	email := fs.String("email", os.Getenv("ADMIN_EMAIL"), "admin email")
+	password := fs.String("password", os.Getenv("ADMIN_PASSWORD"), "admin password, only used when the account does not exist yet")
+	name := fs.String("name", getEnv("ADMIN_NAME", "Administrator"), "admin display name")
+	promote := fs.Bool("promote", false, "promote the account to super_admin if it already exists, even when its email is not verified")
+	fs.Parse(os.Args[2:])
+
+	// Load configuration (.env) before connecting to the database
+	cfg := config.LoadConfig()
+	logger.Init()
+
+	if err := helpers.InitPasswords(cfg); err != nil {
+		logger.Errorf("Failed to initialize password policy: %v", err)
+		fmt.Fprintf(os.Stderr, "Failed to initialize password policy: %v\n", err)
+		os.Exit(1)
+	}
+
+	database.Connect()
+	database.Migrate()
+
+	userRepo := repository.NewUserRepository(database.DB)
+	if err := createAdmin(userRepo, *name, *email, *password, *promote); err != nil {
+		logger.Errorf("Failed to create admin: %v", err)
+		fmt.Fprintf(os.Stderr, "Failed to create admin: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func createFlags() *flag.FlagSet {
+	return flag.NewFlagSet("create", flag.ExitOnError)
+}
+
+// createAdmin makes sure a super_admin account with the given email exists. It
+// is idempotent: an existing super_admin is left untouched. Any other user with
+// the email is only promoted, without changing their password, when promote is
+// set; otherwise whoever registered the address first would become admin.
+func createAdmin(userRepo repository.UserRepository, name, email, password string, promote bool) error {
+	user, err := userRepo.GetByEmail(email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if user != nil {
+		if user.Role == entity.RoleSuperAdmin {
+			logger.Infof("Admin %s already exists, nothing to do", email)
+			fmt.Printf("Admin %s already exists\n", email)
+			return nil
+		}
+
+		if !promote {
+			if user.EmailVerifiedAt == nil {
+				return fmt.Errorf("user %s already exists and has not verified their email; pass -promote to make them super_admin anyway", email)
+			}
+			return fmt.Errorf("user %s already exists with role %s; pass -promote to make them super_admin", email, user.Role)
+		}
+
+		user.Role = entity.RoleSuperAdmin
+		if err := userRepo.Update(user); err != nil {
+			return err
+		}
+
+		logger.Infof("Promoted user %s (ID %d) to super_admin", email, user.ID)
+		fmt.Printf("Promoted %s to super_admin\n", email)
+		return nil
+	}
+
+	// Reuse the registration rules for new accounts
+	registerDTO := &dto.RegisterRequestDTO{
+		Name:     name,
+		Email:    email,
+		Password: password,
+	}
+	if err := registerDTO.ValidateRegisterRequest(); err != nil {
+		return err
+	}
+	if err := helpers.ValidatePasswordPolicy(password); err != nil {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// The operator vouches for the address, so no verification email is needed
+	now := time.Now()
+	user = &entity.User{
+		Name:            name,
+		Email:           email,
+		Password:        hashedPassword,
+		Role:            entity.RoleSuperAdmin,
+		EmailVerifiedAt: &now,
+	}
+	if err := userRepo.Create(user); err != nil {
+		return err
+	}
+
+	logger.Infof("Created admin %s (ID %d)", email, user.ID)
+	fmt.Printf("Created admin %s\n", email)
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -1,21 +1,29 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/nabil/book-store-system/config"
 	"github.com/nabil/book-store-system/internal/repository"
 	"github.com/nabil/book-store-system/internal/service"
 	"github.com/nabil/book-store-system/internal/transport/grpc"
+	httptransport "github.com/nabil/book-store-system/internal/transport/http"
 	"github.com/nabil/book-store-system/pkg/database"
 	"github.com/nabil/book-store-system/pkg/helpers"
 	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/mailer"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"github.com/nabil/book-store-system/pkg/oidc"
+	"github.com/nabil/book-store-system/pkg/storage"
 	"github.com/nabil/book-store-system/proto"
 	grpcServer "google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -39,44 +47,140 @@ func main() {
 	database.Migrate()
 	logger.Info("Database migration completed")
 
+	// Load JWT signing and verification keys
+	if err := helpers.InitTokens(cfg); err != nil {
+		logger.Errorf("Failed to initialize JWT keys: %v", err)
+		log.Fatal(err)
+	}
+	logger.Info("JWT keys loaded")
+
+	// Load password hashing parameters and the password blocklist
+	if err := helpers.InitPasswords(cfg); err != nil {
+		logger.Errorf("Failed to initialize password policy: %v", err)
+		log.Fatal(err)
+	}
+
 	// Initialize Midtrans
-	helpers.InitMidtrans()
+	helpers.InitMidtrans(cfg)
 	logger.Info("Midtrans payment gateway initialized")
 
+	// Initialize mailer
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		logger.Errorf("Failed to initialize mailer: %v", err)
+		log.Fatal(err)
+	}
+	logger.Infof("Mailer initialized with driver %q", cfg.Mail.Driver)
+
+	// Initialize blob store for book covers
+	blobs, err := storage.New(cfg.Storage)
+	if err != nil {
+		logger.Errorf("Failed to initialize blob store: %v", err)
+		log.Fatal(err)
+	}
+	logger.Infof("Blob store initialized with driver %q", cfg.Storage.Driver)
+
 	// Initialize repositories
-	userRepo := repository.NewUserRepository(db)
+	userCache := middleware.NewUserCache(cfg.UserCache.Size, cfg.UserCache.TTL)
+	userRepo := middleware.CachingUserRepository(repository.NewUserRepository(db), userCache)
 	categoryRepo := repository.NewCategoryRepository(db)
 	bookRepo := repository.NewBookRepository(db)
 	orderRepo := repository.NewOrderRepository(db)
 	reportRepo := repository.NewReportRepository(db)
 	txRepo := repository.NewTransactionRepository(db)
+	tokenRepo := repository.NewTokenRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+	attemptRepo := repository.NewLoginAttemptRepository(db)
+	mfaRepo := repository.NewMFARepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	resetRepo := repository.NewPasswordResetRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	addressRepo := repository.NewAddressRepository(db)
+	oidcRepo := repository.NewOIDCRepository(db)
 	logger.Info("Repositories initialized")
 
+	// Initialize auth middleware
+	auth := middleware.NewAuthMiddleware(userRepo, tokenRepo, roleRepo, apiKeyRepo, userCache)
+
 	// Initialize services
-	userService := service.NewUserService(userRepo)
-	categoryService := service.NewCategoryService(categoryRepo, userRepo)
-	bookService := service.NewBookService(bookRepo, categoryRepo, userRepo)
-	orderService := service.NewOrderService(orderRepo, bookRepo, userRepo, txRepo)
-	reportService := service.NewReportService(reportRepo, userRepo)
+	userService := service.NewUserService(userRepo, tokenRepo, attemptRepo, mfaRepo, resetRepo, oidcRepo, mail, oidc.NewProviders(cfg.OIDC), cfg.LoginLockout, cfg.MFA, cfg.PasswordReset, cfg.EmailVerification, cfg.OIDC)
+	adminUserService := service.NewAdminUserService(userRepo, tokenRepo, roleRepo, attemptRepo, txRepo, auditRepo, cfg.Impersonation)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, roleRepo, txRepo, auditRepo, cfg.APIKey)
+	categoryService := service.NewCategoryService(categoryRepo, txRepo, auditRepo)
+	bookService := service.NewBookService(bookRepo, categoryRepo, txRepo, auditRepo, blobs, cfg.Cover, cfg.Search)
+	orderService := service.NewOrderService(orderRepo, bookRepo, addressRepo, txRepo, auditRepo, cfg.EmailVerification)
+	reportService := service.NewReportService(reportRepo)
+	auditService := service.NewAuditService(auditRepo)
+	addressService := service.NewAddressService(addressRepo)
+	privacyService := service.NewPrivacyService(userRepo, roleRepo, tokenRepo, mfaRepo, resetRepo, orderRepo, addressRepo, oidcRepo, txRepo, auditRepo)
 	logger.Info("Services initialized")
 
+	// Periodically purge expired refresh tokens, revocation entries, login attempt counters and OIDC login states
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			if err := tokenRepo.DeleteExpired(); err != nil {
+				logger.Errorf("Failed to purge expired tokens: %v", err)
+			}
+			if err := attemptRepo.DeleteExpired(time.Now().Add(-cfg.LoginLockout.Window)); err != nil {
+				logger.Errorf("Failed to purge expired login attempts: %v", err)
+			}
+			if err := resetRepo.DeleteExpired(); err != nil {
+				logger.Errorf("Failed to purge expired password reset tokens: %v", err)
+			}
+			if err := oidcRepo.DeleteExpiredStates(); err != nil {
+				logger.Errorf("Failed to purge expired OIDC login states: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+
+	// Move covers still stored as base64 in the books table into the blob store
+	go func() {
+		if err := bookService.MigrateLegacyCovers(context.Background()); err != nil {
+			logger.Errorf("Failed to migrate legacy book covers: %v", err)
+		}
+	}()
+
 	// Initialize gRPC handlers
-	userHandler := grpc.NewUserHandler(userService)
+	userHandler := grpc.NewUserHandler(userService, privacyService)
+	adminUserHandler := grpc.NewAdminUserHandler(adminUserService, privacyService)
+	apiKeyHandler := grpc.NewAPIKeyHandler(apiKeyService)
 	categoryHandler := grpc.NewCategoryHandler(categoryService)
-	bookHandler := grpc.NewBookHandler(bookService)
-	orderHandler := grpc.NewOrderHandler(orderService)
-	reportHandler := grpc.NewReportHandler(reportService)
+	bookHandler := grpc.NewBookHandler(bookService, blobs, cfg.Cover)
+	orderHandler := grpc.NewOrderHandler(orderService, blobs)
+	reportHandler := grpc.NewReportHandler(reportService, blobs)
+	auditHandler := grpc.NewAuditHandler(auditService)
+	addressHandler := grpc.NewAddressHandler(addressService)
 	logger.Info("gRPC handlers initialized")
 
-	// Create gRPC server
-	grpcSrv := grpcServer.NewServer()
+	// Create gRPC server with authentication interceptors
+	policy := grpc.AccessPolicy()
+	impersonationDenied := grpc.ImpersonationDeniedMethods()
+	grpcSrv := grpcServer.NewServer(
+		grpcServer.ChainUnaryInterceptor(
+			middleware.UnaryRequestIDInterceptor(),
+			auth.UnaryServerInterceptor(policy),
+			middleware.UnaryImpersonationInterceptor(auditRepo, impersonationDenied),
+		),
+		grpcServer.ChainStreamInterceptor(
+			middleware.StreamRequestIDInterceptor(),
+			auth.StreamServerInterceptor(policy),
+			middleware.StreamImpersonationInterceptor(auditRepo, impersonationDenied),
+		),
+	)
 
 	// Register services
 	proto.RegisterUserServiceServer(grpcSrv, userHandler)
+	proto.RegisterAdminUserServiceServer(grpcSrv, adminUserHandler)
+	proto.RegisterAPIKeyServiceServer(grpcSrv, apiKeyHandler)
 	proto.RegisterCategoryServiceServer(grpcSrv, categoryHandler)
 	proto.RegisterBookServiceServer(grpcSrv, bookHandler)
 	proto.RegisterOrderServiceServer(grpcSrv, orderHandler)
 	proto.RegisterReportServiceServer(grpcSrv, reportHandler)
+	proto.RegisterAuditServiceServer(grpcSrv, auditHandler)
+	proto.RegisterAddressServiceServer(grpcSrv, addressHandler)
 
 	reflection.Register(grpcSrv)
 	logger.Info("gRPC services registered")
@@ -95,6 +199,25 @@ func main() {
 		}
 	}()
 
+	// HTTP server for the JWKS document, metrics and locally stored covers
+	var mediaDir string
+	if local, ok := blobs.(*storage.LocalStore); ok {
+		mediaDir = local.Dir()
+	}
+	httpSrv := &http.Server{
+		Addr:              ":" + strconv.Itoa(cfg.AppPort),
+		Handler:           httptransport.NewRouter(userCache, mediaDir),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logger.Infof("HTTP server starting on port %d", cfg.AppPort)
+		if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("Failed to serve HTTP server: %v", err)
+			log.Fatal(err)
+		}
+	}()
+
 	logger.Info("Book Store gRPC Server started successfully")
 	fmt.Printf("gRPC Server is running on port %d\n", cfg.GRPCPort)
 
@@ -107,6 +230,13 @@ func main() {
 	grpcSrv.GracefulStop()
 	logger.Info("gRPC server stopped")
 
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("Failed to shut down HTTP server: %v", err)
+	}
+	logger.Info("HTTP server stopped")
+
 	sqlDB, err := db.DB()
 	if err == nil {
 		sqlDB.Close()
@@ -1,20 +1,45 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	AppPort     int
-	GRPCPort    int
-	JWTSecret   string
-	JWTExpiry   int
-	MidtransKey string
-	DBConfig    DBConfig
+	AppPort   int
+	GRPCPort  int
+	JWTIssuer string
+	// JWTSigningKeyFile is the PEM private key (RSA or Ed25519) used to sign new tokens
+	JWTSigningKeyFile string
+	// JWTAllowEphemeralKey lets the server start without JWTSigningKeyFile by
+	// signing with a key generated at startup; for local development only
+	JWTAllowEphemeralKey bool
+	// JWTVerificationKeyFiles are PEM keys that are still accepted for verification,
+	// e.g. the previous signing key during a rotation
+	JWTVerificationKeyFiles []string
+	AccessTokenExpiry       int // minutes
+	RefreshTokenExpiry      int // hours
+	MidtransKey             string
+	DBConfig                DBConfig
+	LoginLockout            LoginLockoutConfig
+	MFA                     MFAConfig
+	APIKey                  APIKeyConfig
+	UserCache               UserCacheConfig
+	Password                PasswordConfig
+	Mail                    MailConfig
+	PasswordReset           PasswordResetConfig
+	EmailVerification       EmailVerificationConfig
+	OIDC                    OIDCConfig
+	Impersonation           ImpersonationConfig
+	Storage                 StorageConfig
+	Cover                   CoverConfig
+	Search                  SearchConfig
 }
 
 type DBConfig struct {
@@ -25,6 +50,140 @@ type DBConfig struct {
 	Name     string
 }
 
+// LoginLockoutConfig controls brute-force protection of Login. Failed attempts
+// are counted per email and per client IP within Window; once MaxAttempts is
+// reached the key is locked for BaseLockout, doubling with every further
+// failure up to MaxLockout, or without limit when MaxLockout is zero.
+type LoginLockoutConfig struct {
+	MaxAttemptsPerEmail int
+	MaxAttemptsPerIP    int
+	Window              time.Duration
+	BaseLockout         time.Duration
+	MaxLockout          time.Duration
+}
+
+// MFAConfig controls TOTP two-factor authentication
+type MFAConfig struct {
+	// Issuer is the account issuer shown by authenticator apps
+	Issuer string
+	// RequiredForStaff forces every non-customer role to enroll before using staff RPCs
+	RequiredForStaff bool
+}
+
+// APIKeyConfig controls API keys used by machine clients
+type APIKeyConfig struct {
+	// DefaultRateLimit is the number of requests per minute allowed for keys
+	// created without their own limit
+	DefaultRateLimit int
+}
+
+// UserCacheConfig controls the in-memory cache of users resolved by the auth
+// interceptor. A Size or TTL of zero disables the cache.
+type UserCacheConfig struct {
+	Size int
+	TTL  time.Duration
+}
+
+// PasswordConfig controls password hashing and the password policy
+type PasswordConfig struct {
+	Argon2MemoryKiB   uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	MinLength         int
+	MaxLength         int
+	// BlocklistFile lists breached passwords, one per line; empty disables the check
+	BlocklistFile string
+}
+
+// MailConfig selects and configures the mailer. Driver is "smtp" or "log".
+type MailConfig struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// LogFile receives the emails of the log driver; empty writes to stdout
+	LogFile string
+}
+
+// PasswordResetConfig controls the password reset flow
+type PasswordResetConfig struct {
+	TokenTTL time.Duration
+	// URL is an optional page of the frontend; the reset token is appended as ?token=
+	URL string
+}
+
+// EmailVerificationConfig controls email verification of new accounts
+type EmailVerificationConfig struct {
+	TokenTTL time.Duration
+	// URL is an optional page of the frontend; the verification token is appended as ?token=
+	URL string
+	// RequiredForOrders blocks CreateOrder and ProcessPayment until the email is verified
+	RequiredForOrders bool
+}
+
+// OIDCConfig lists the external OpenID Connect providers users can sign in with
+type OIDCConfig struct {
+	Providers []OIDCProviderConfig
+	// StateTTL is how long a user has to complete a login started with BeginOIDCLogin
+	StateTTL time.Duration
+}
+
+// OIDCProviderConfig configures one OpenID Connect provider. The endpoints and
+// signing keys are discovered from IssuerURL.
+type OIDCProviderConfig struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL must be registered at the provider; it receives the code and state
+	RedirectURL string
+	Scopes      []string
+}
+
+// ImpersonationConfig controls the tokens support staff get from ImpersonateUser
+type ImpersonationConfig struct {
+	TokenTTL time.Duration
+}
+
+// StorageConfig selects and configures the blob store holding book covers.
+// Driver is "local" or "s3".
+type StorageConfig struct {
+	Driver string
+	// PublicURL is the base URL blobs are served from. For the local driver this
+	// is usually http://<host>:<APP_PORT>/media; for S3 it defaults to the
+	// bucket URL.
+	PublicURL string
+	// LocalDir is where the local driver stores blobs
+	LocalDir    string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	// S3PathStyle addresses the bucket as <endpoint>/<bucket> instead of
+	// <bucket>.<endpoint>, as needed by most S3-compatible servers such as MinIO
+	S3PathStyle bool
+}
+
+// CoverConfig controls how uploaded book covers are validated and processed
+type CoverConfig struct {
+	// MaxBytes is the largest cover file accepted
+	MaxBytes int
+	// MaxDimension is the largest width and height in pixels accepted
+	MaxDimension int
+	// ThumbnailWidth is the width in pixels of generated cover thumbnails
+	ThumbnailWidth int
+}
+
+// SearchConfig controls the book catalog search
+type SearchConfig struct {
+	// SuggestTimeout is the time budget of a SuggestBooks query; slower queries
+	// are cancelled and return no suggestions
+	SuggestTimeout time.Duration
+}
+
 func LoadConfig() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -33,14 +192,50 @@ func LoadConfig() *Config {
 
 	appPort, _ := strconv.Atoi(getEnv("APP_PORT", "8080"))
 	grpcPort, _ := strconv.Atoi(getEnv("GRPC_PORT", "50051"))
-	jwtExpiry, _ := strconv.Atoi(getEnv("JWT_EXPIRY", "24"))
+	accessTokenExpiry, _ := strconv.Atoi(getEnv("JWT_ACCESS_EXPIRY_MINUTES", "15"))
+	refreshTokenExpiry, _ := strconv.Atoi(getEnv("JWT_REFRESH_EXPIRY_HOURS", "720"))
+	loginMaxAttemptsPerEmail, _ := strconv.Atoi(getEnv("LOGIN_MAX_ATTEMPTS_PER_EMAIL", "5"))
+	loginMaxAttemptsPerIP, _ := strconv.Atoi(getEnv("LOGIN_MAX_ATTEMPTS_PER_IP", "20"))
+	loginAttemptWindow, _ := strconv.Atoi(getEnv("LOGIN_ATTEMPT_WINDOW_MINUTES", "15"))
+	loginBaseLockout, _ := strconv.Atoi(getEnv("LOGIN_LOCKOUT_BASE_SECONDS", "60"))
+	loginMaxLockout, _ := strconv.Atoi(getEnv("LOGIN_LOCKOUT_MAX_MINUTES", "60"))
+	jwtAllowEphemeralKey, _ := strconv.ParseBool(getEnv("JWT_ALLOW_EPHEMERAL_KEY", "false"))
+	mfaRequiredForStaff, _ := strconv.ParseBool(getEnv("MFA_REQUIRED_FOR_STAFF", "true"))
+	apiKeyDefaultRateLimit, _ := strconv.Atoi(getEnv("API_KEY_DEFAULT_RATE_LIMIT", "60"))
+	userCacheSize, _ := strconv.Atoi(getEnv("USER_CACHE_SIZE", "10000"))
+	userCacheTTL, _ := strconv.Atoi(getEnv("USER_CACHE_TTL_SECONDS", "30"))
+	argon2Memory, _ := strconv.ParseUint(getEnv("PASSWORD_ARGON2_MEMORY_KIB", "65536"), 10, 32)
+	argon2Iterations, _ := strconv.ParseUint(getEnv("PASSWORD_ARGON2_ITERATIONS", "3"), 10, 32)
+	argon2Parallelism, _ := strconv.ParseUint(getEnv("PASSWORD_ARGON2_PARALLELISM", "2"), 10, 8)
+	passwordMinLength, _ := strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	passwordMaxLength, _ := strconv.Atoi(getEnv("PASSWORD_MAX_LENGTH", "128"))
+	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+	passwordResetTTL, _ := strconv.Atoi(getEnv("PASSWORD_RESET_TTL_MINUTES", "30"))
+	emailVerificationTTL, _ := strconv.Atoi(getEnv("EMAIL_VERIFICATION_TTL_HOURS", "48"))
+	emailVerificationRequired, _ := strconv.ParseBool(getEnv("EMAIL_VERIFICATION_REQUIRED_FOR_ORDERS", "true"))
+	oidcStateTTL, _ := strconv.Atoi(getEnv("OIDC_STATE_TTL_MINUTES", "10"))
+	impersonationTTL, _ := strconv.Atoi(getEnv("IMPERSONATION_TTL_MINUTES", "15"))
+	s3PathStyle, _ := strconv.ParseBool(getEnv("S3_FORCE_PATH_STYLE", "true"))
+	coverMaxBytes, _ := strconv.Atoi(getEnv("COVER_MAX_BYTES", "5242880"))
+	coverMaxDimension, _ := strconv.Atoi(getEnv("COVER_MAX_DIMENSION", "4000"))
+	thumbnailWidth, _ := strconv.Atoi(getEnv("COVER_THUMBNAIL_WIDTH", "300"))
+	suggestTimeout, _ := strconv.Atoi(getEnv("SEARCH_SUGGEST_TIMEOUT_MS", "150"))
+	storageDriver := getEnv("STORAGE_DRIVER", "local")
+	storagePublicURL := getEnv("STORAGE_PUBLIC_URL", "")
+	if storagePublicURL == "" && storageDriver == "local" {
+		storagePublicURL = fmt.Sprintf("http://localhost:%d/media", appPort)
+	}
 
 	return &Config{
-		AppPort:     appPort,
-		GRPCPort:    grpcPort,
-		JWTSecret:   getEnv("JWT_SECRET", "hello_123"),
-		JWTExpiry:   jwtExpiry,
-		MidtransKey: getEnv("MIDTRANS_SERVER_KEY", ""),
+		AppPort:                 appPort,
+		GRPCPort:                grpcPort,
+		JWTIssuer:               getEnv("JWT_ISSUER", "book-store-system"),
+		JWTSigningKeyFile:       getEnv("JWT_SIGNING_KEY_FILE", ""),
+		JWTAllowEphemeralKey:    jwtAllowEphemeralKey,
+		JWTVerificationKeyFiles: splitList(getEnv("JWT_VERIFICATION_KEY_FILES", "")),
+		AccessTokenExpiry:       accessTokenExpiry,
+		RefreshTokenExpiry:      refreshTokenExpiry,
+		MidtransKey:             getEnv("MIDTRANS_SERVER_KEY", ""),
 		DBConfig: DBConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "3306"),
@@ -48,9 +243,98 @@ func LoadConfig() *Config {
 			Password: getEnv("DB_PASSWORD", ""),
 			Name:     getEnv("DB_NAME", "bookstore"),
 		},
+		LoginLockout: LoginLockoutConfig{
+			MaxAttemptsPerEmail: loginMaxAttemptsPerEmail,
+			MaxAttemptsPerIP:    loginMaxAttemptsPerIP,
+			Window:              time.Duration(loginAttemptWindow) * time.Minute,
+			BaseLockout:         time.Duration(loginBaseLockout) * time.Second,
+			MaxLockout:          time.Duration(loginMaxLockout) * time.Minute,
+		},
+		MFA: MFAConfig{
+			Issuer:           getEnv("MFA_ISSUER", "Book Store"),
+			RequiredForStaff: mfaRequiredForStaff,
+		},
+		APIKey: APIKeyConfig{
+			DefaultRateLimit: apiKeyDefaultRateLimit,
+		},
+		UserCache: UserCacheConfig{
+			Size: userCacheSize,
+			TTL:  time.Duration(userCacheTTL) * time.Second,
+		},
+		Password: PasswordConfig{
+			Argon2MemoryKiB:   uint32(argon2Memory),
+			Argon2Iterations:  uint32(argon2Iterations),
+			Argon2Parallelism: uint8(argon2Parallelism),
+			MinLength:         passwordMinLength,
+			MaxLength:         passwordMaxLength,
+			BlocklistFile:     getEnv("PASSWORD_BLOCKLIST_FILE", ""),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "Book Store <no-reply@bookstore.local>"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     smtpPort,
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			LogFile:      getEnv("MAIL_LOG_FILE", ""),
+		},
+		PasswordReset: PasswordResetConfig{
+			TokenTTL: time.Duration(passwordResetTTL) * time.Minute,
+			URL:      getEnv("PASSWORD_RESET_URL", ""),
+		},
+		EmailVerification: EmailVerificationConfig{
+			TokenTTL:          time.Duration(emailVerificationTTL) * time.Hour,
+			URL:               getEnv("EMAIL_VERIFICATION_URL", ""),
+			RequiredForOrders: emailVerificationRequired,
+		},
+		OIDC: OIDCConfig{
+			Providers: loadOIDCProviders(),
+			StateTTL:  time.Duration(oidcStateTTL) * time.Minute,
+		},
+		Impersonation: ImpersonationConfig{
+			TokenTTL: time.Duration(impersonationTTL) * time.Minute,
+		},
+		Storage: StorageConfig{
+			Driver:      storageDriver,
+			PublicURL:   strings.TrimSuffix(storagePublicURL, "/"),
+			LocalDir:    getEnv("STORAGE_LOCAL_DIR", "storage"),
+			S3Endpoint:  strings.TrimSuffix(getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"), "/"),
+			S3Region:    getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:    getEnv("S3_BUCKET", ""),
+			S3AccessKey: getEnv("S3_ACCESS_KEY_ID", ""),
+			S3SecretKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
+			S3PathStyle: s3PathStyle,
+		},
+		Cover: CoverConfig{
+			MaxBytes:       coverMaxBytes,
+			MaxDimension:   coverMaxDimension,
+			ThumbnailWidth: thumbnailWidth,
+		},
+		Search: SearchConfig{
+			SuggestTimeout: time.Duration(suggestTimeout) * time.Millisecond,
+		},
 	}
 }
 
+// loadOIDCProviders reads the providers named in OIDC_PROVIDERS. Each provider
+// <NAME> is configured with OIDC_<NAME>_ISSUER_URL, OIDC_<NAME>_CLIENT_ID,
+// OIDC_<NAME>_CLIENT_SECRET, OIDC_<NAME>_REDIRECT_URL and OIDC_<NAME>_SCOPES.
+func loadOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range splitList(getEnv("OIDC_PROVIDERS", "")) {
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OIDCProviderConfig{
+			Name:         strings.ToLower(name),
+			IssuerURL:    getEnv(prefix+"ISSUER_URL", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:       splitList(getEnv(prefix+"SCOPES", "openid,email,profile")),
+		})
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -58,3 +342,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// splitList splits a comma separated value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
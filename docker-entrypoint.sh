@@ -0,0 +1,12 @@
+#!/bin/sh
+set -e
+
+# Create the first admin account on boot when ADMIN_EMAIL is set.
+# The admin command is idempotent, so this is safe on every restart. It never
+# promotes an existing non-admin account without -promote; that failure must not
+# keep the server from starting.
+if [ -n "$ADMIN_EMAIL" ]; then
+  ./admin create || echo "Admin account not created, see the error above" >&2
+fi
+
+exec "$@"
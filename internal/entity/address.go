@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+)
+
+// AddressFields are the parts of a shipping address. They are shared by saved
+// addresses and by the snapshot stored on every order.
+type AddressFields struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone"`
+	Street        string `json:"street"`
+	District      string `json:"district"`
+	City          string `json:"city"`
+	Province      string `json:"province"`
+	PostalCode    string `json:"postal_code"`
+}
+
+// Address is an entry in a user's address book. At most one address per user
+// is the default one.
+type Address struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    uint      `gorm:"not null;index;uniqueIndex:idx_addresses_user_default,where:is_default" json:"user_id"`
+	AddressFields
+	IsDefault bool `gorm:"not null;default:false" json:"is_default"`
+}
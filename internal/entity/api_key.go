@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey lets a machine client call the API without a user login. The key acts
+// on behalf of the admin that created it, limited to its scopes. Only the hash
+// of the key is stored; Prefix identifies it in listings.
+type APIKey struct {
+	ID                 uint       `gorm:"primarykey" json:"id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	Name               string     `gorm:"not null" json:"name"`
+	Prefix             string     `gorm:"uniqueIndex;not null" json:"prefix"`
+	KeyHash            string     `gorm:"uniqueIndex;not null" json:"-"`
+	Scopes             string     `gorm:"not null;default:''" json:"scopes"` // comma separated permission names
+	RateLimitPerMinute int        `gorm:"not null" json:"rate_limit_per_minute"`
+	CreatedByID        uint       `gorm:"not null;index" json:"created_by_id"`
+	CreatedBy          User       `gorm:"foreignKey:CreatedByID" json:"-"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ScopeList returns the permission names granted to the key
+func (k *APIKey) ScopeList() []string {
+	var scopes []string
+	for _, scope := range strings.Split(k.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// IsExpired reports whether the key has an expiry that has passed
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !k.ExpiresAt.After(now)
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
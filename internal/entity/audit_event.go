@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// AuditEvent records an administrative change. Before and After hold the JSON
+// of the fields that changed; Before is empty for creations and After for deletions.
+type AuditEvent struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+	ActorID    uint      `gorm:"index" json:"actor_id"`
+	ActorEmail string    `json:"actor_email"`
+	// APIKeyID is set when the actor acted through one of their API keys
+	APIKeyID   *uint   `json:"api_key_id,omitempty"`
+	Action     string  `gorm:"not null;index" json:"action"`
+	EntityType string  `gorm:"not null;index:idx_audit_events_entity" json:"entity_type"`
+	EntityID   uint    `gorm:"index:idx_audit_events_entity" json:"entity_id"`
+	Before     *string `gorm:"type:jsonb" json:"before,omitempty"`
+	After      *string `gorm:"type:jsonb" json:"after,omitempty"`
+	RequestID  string  `gorm:"index" json:"request_id"`
+}
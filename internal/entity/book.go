@@ -12,10 +12,19 @@ type Book struct {
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 	Title       string         `gorm:"not null" json:"title"`
 	Author      string         `gorm:"not null" json:"author"`
-	Price       float64        `gorm:"not null" json:"price"`
-	Stock       int            `gorm:"not null;default:0" json:"stock"`
-	Year        int            `gorm:"not null" json:"year"`
-	CategoryID  uint           `gorm:"not null" json:"category_id"`
-	Category    Category       `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
-	ImageBase64 string         `gorm:"type:text" json:"image_base64,omitempty"`
-}
\ No newline at end of file
+	Description string         `gorm:"type:text" json:"description,omitempty"`
+	// ISBN is the normalized ISBN-13, unique among books that are not deleted
+	ISBN       *string  `gorm:"size:13;uniqueIndex:idx_books_isbn,where:deleted_at IS NULL" json:"isbn,omitempty"`
+	Price      float64  `gorm:"not null" json:"price"`
+	Stock      int      `gorm:"not null;default:0" json:"stock"`
+	Year       int      `gorm:"not null" json:"year"`
+	CategoryID uint     `gorm:"not null" json:"category_id"`
+	Category   Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	// CoverKey and ThumbnailKey locate the cover image and its thumbnail in the
+	// blob store, and are empty for books without a cover
+	CoverKey     string `gorm:"size:255" json:"cover_key,omitempty"`
+	ThumbnailKey string `gorm:"size:255" json:"thumbnail_key,omitempty"`
+	// SearchHighlight holds the matching parts of the book, with matches wrapped
+	// in <mark> tags, when it was found by a full-text search
+	SearchHighlight string `gorm:"->;-:migration" json:"-"`
+}
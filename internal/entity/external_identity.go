@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+)
+
+// ExternalIdentity links a user to their account at an external OpenID Connect
+// provider. The subject is the stable identifier issued by the provider.
+type ExternalIdentity struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Provider    string     `gorm:"not null;uniqueIndex:idx_external_identities_subject" json:"provider"`
+	Subject     string     `gorm:"not null;uniqueIndex:idx_external_identities_subject" json:"subject"`
+	Email       string     `json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// OIDCLoginState holds what is needed to complete a login started with
+// BeginOIDCLogin: the PKCE verifier and the nonce expected in the ID token.
+// Only the hash of the state parameter is stored and each state is used once.
+type OIDCLoginState struct {
+	ID           uint      `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	StateHash    string    `gorm:"uniqueIndex;not null" json:"-"`
+	Provider     string    `gorm:"not null" json:"provider"`
+	Nonce        string    `gorm:"not null" json:"-"`
+	CodeVerifier string    `gorm:"not null" json:"-"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+}
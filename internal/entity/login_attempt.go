@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+)
+
+// LoginAttempt counts consecutive failed logins for a key, which is either
+// "email:<address>" or "ip:<client ip>"
+type LoginAttempt struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	Key           string     `gorm:"uniqueIndex;not null" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `gorm:"not null;index" json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+}
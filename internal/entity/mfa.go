@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+// MFARecoveryCode is a single-use code that replaces a TOTP code when the
+// authenticator device is lost. Only the hash of the code is stored.
+type MFARecoveryCode struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
@@ -16,6 +16,10 @@ type Order struct {
 	Status     string         `gorm:"not null;default:pending" json:"status"`
 	PaymentURL string         `json:"payment_url,omitempty"`
 	OrderItems []OrderItem    `gorm:"foreignKey:OrderID" json:"order_items,omitempty"`
+	// AddressID is the saved address the order was placed with; ShippingAddress is
+	// a copy of it so later edits to the address book do not change the order
+	AddressID       *uint         `json:"address_id,omitempty"`
+	ShippingAddress AddressFields `gorm:"embedded;embeddedPrefix:shipping_" json:"shipping_address"`
 }
 
 type OrderItem struct {
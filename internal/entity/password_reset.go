@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+)
+
+// PasswordResetToken is a single-use token sent by email to reset a forgotten
+// password. Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+)
+
+// Built-in roles. User.Role holds the name of one of the rows in the roles table.
+const (
+	RoleSuperAdmin       = "super_admin"
+	RoleInventoryManager = "inventory_manager"
+	RoleOrderOperator    = "order_operator"
+	RoleFinance          = "finance"
+	RoleUser             = "user"
+)
+
+// Permissions checked by the services
+const (
+	PermissionBooksWrite         = "books:write"
+	PermissionCategoriesWrite    = "categories:write"
+	PermissionOrdersRead         = "orders:read"
+	PermissionOrdersUpdateStatus = "orders:update_status"
+	PermissionReportsRead        = "reports:read"
+	PermissionUsersRead          = "users:read"
+	PermissionUsersWrite         = "users:write"
+	PermissionRolesManage        = "roles:manage"
+	PermissionAPIKeysManage      = "api_keys:manage"
+	PermissionAuditRead          = "audit:read"
+	PermissionUsersImpersonate   = "users:impersonate"
+)
+
+type Permission struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Name        string    `gorm:"uniqueIndex;not null" json:"name"`
+	Description string    `json:"description"`
+}
+
+type Role struct {
+	ID          uint         `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	Name        string       `gorm:"uniqueIndex;not null" json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `gorm:"many2many:role_permissions;" json:"permissions,omitempty"`
+}
@@ -5,15 +5,61 @@ import (
 	"time"
 )
 
+// NoPassword is stored as the password of accounts without one, such as those
+// created by an OIDC sign in. HashPassword never produces it, so no password
+// can match it.
+const NoPassword = "!"
+
 type User struct {
-	ID        uint           `gorm:"primarykey" json:"id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
-	Name      string         `gorm:"not null" json:"name"`
-	Email     string         `gorm:"uniqueIndex;not null" json:"email"`
-	Password  string         `gorm:"not null" json:"-"`
-	Role      string         `gorm:"default:user" json:"role"`
-	Phone     string         `json:"phone"`
-	Address   string         `json:"address"`
-}
\ No newline at end of file
+	ID           uint           `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+	Name         string         `gorm:"not null" json:"name"`
+	Email        string         `gorm:"uniqueIndex;not null" json:"email"`
+	Password     string         `gorm:"not null" json:"-"`
+	Role         string         `gorm:"default:user" json:"role"`
+	Phone        string         `json:"phone"`
+	Address      string         `json:"address"`
+	TokenVersion uint           `gorm:"not null;default:0" json:"-"`
+	DisabledAt   *time.Time     `json:"disabled_at,omitempty"`
+	// MFASecret is the base32 TOTP secret; set but not enabled while enrollment is pending
+	MFASecret       string     `json:"-"`
+	MFAEnabledAt    *time.Time `json:"mfa_enabled_at,omitempty"`
+	MFALastUsedStep int64      `gorm:"not null;default:0" json:"-"`
+	// EmailVerifiedAt is nil until the user follows the verification link
+	EmailVerifiedAt    *time.Time `json:"email_verified_at,omitempty"`
+	VerificationSentAt *time.Time `json:"-"`
+	// AnonymizedAt is set once the personal data was erased; the row is kept for the orders
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty"`
+}
+
+// MFAEnabled reports whether the user completed TOTP enrollment
+func (u *User) MFAEnabled() bool {
+	return u.MFAEnabledAt != nil
+}
+
+// HasPassword reports whether the user can sign in with a password
+func (u *User) HasPassword() bool {
+	return u.Password != NoPassword
+}
+
+// IsStaff reports whether the user has a role other than the customer role
+func (u *User) IsStaff() bool {
+	return u.Role != RoleUser
+}
+
+// IsEmailVerified reports whether the user confirmed their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsAnonymized reports whether the account was deleted and its personal data erased
+func (u *User) IsAnonymized() bool {
+	return u.AnonymizedAt != nil
+}
+
+// IsDisabled reports whether the account was disabled by an administrator
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
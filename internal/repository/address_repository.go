@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type AddressRepository interface {
+	Create(address *entity.Address) error
+	GetByID(id uint) (*entity.Address, error)
+	GetByUserID(userID uint) ([]*entity.Address, error)
+	GetDefault(userID uint) (*entity.Address, error)
+	CountByUserID(userID uint) (int64, error)
+	Update(address *entity.Address) error
+	SetDefault(userID, id uint) error
+	Delete(id uint) error
+	DeleteByUserID(userID uint) error
+	DeleteByUserIDTx(tx *gorm.DB, userID uint) error
+}
+
+type addressRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAddressRepository(db *gorm.DB) AddressRepository {
+	return &addressRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new address
+func (r *addressRepositoryImpl) Create(address *entity.Address) error {
+	logger.Infof("Creating address for user ID: %d", address.UserID)
+	err := r.db.Create(address).Error
+	if err != nil {
+		logger.Errorf("Failed to create address: %v", err)
+		return err
+	}
+	logger.Infof("Successfully created address with ID: %d", address.ID)
+	return nil
+}
+
+// GetByID gets an address by ID
+func (r *addressRepositoryImpl) GetByID(id uint) (*entity.Address, error) {
+	var address entity.Address
+	err := r.db.First(&address, id).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch address by ID %d: %v", id, err)
+		return nil, err
+	}
+	return &address, nil
+}
+
+// GetByUserID gets all addresses of a user, the default one first
+func (r *addressRepositoryImpl) GetByUserID(userID uint) ([]*entity.Address, error) {
+	var addresses []*entity.Address
+	err := r.db.Where("user_id = ?", userID).Order("is_default DESC, created_at ASC").Find(&addresses).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch addresses of user ID %d: %v", userID, err)
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// GetDefault gets the default address of a user
+func (r *addressRepositoryImpl) GetDefault(userID uint) (*entity.Address, error) {
+	var address entity.Address
+	err := r.db.Where("user_id = ? AND is_default", userID).First(&address).Error
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// CountByUserID counts the addresses of a user
+func (r *addressRepositoryImpl) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Address{}).Where("user_id = ?", userID).Count(&count).Error
+	if err != nil {
+		logger.Errorf("Failed to count addresses of user ID %d: %v", userID, err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// Update updates the fields of an address. The default flag is changed with SetDefault.
+func (r *addressRepositoryImpl) Update(address *entity.Address) error {
+	logger.Infof("Updating address ID: %d", address.ID)
+	err := r.db.Model(address).Select("recipient_name", "phone", "street", "district", "city", "province", "postal_code").Updates(address).Error
+	if err != nil {
+		logger.Errorf("Failed to update address ID %d: %v", address.ID, err)
+		return err
+	}
+	logger.Infof("Successfully updated address ID: %d", address.ID)
+	return nil
+}
+
+// SetDefault makes an address the default one of its user and clears the flag
+// on the others
+func (r *addressRepositoryImpl) SetDefault(userID, id uint) error {
+	logger.Infof("Setting default address of user ID %d to address ID %d", userID, id)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Cleared first, the partial unique index allows a single default per user
+		if err := tx.Model(&entity.Address{}).Where("user_id = ? AND id <> ? AND is_default", userID, id).Update("is_default", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.Address{}).Where("user_id = ? AND id = ?", userID, id).Update("is_default", true).Error
+	})
+	if err != nil {
+		logger.Errorf("Failed to set default address of user ID %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+// Delete deletes an address. Orders keep their own copy of the address.
+func (r *addressRepositoryImpl) Delete(id uint) error {
+	logger.Infof("Deleting address ID: %d", id)
+	err := r.db.Delete(&entity.Address{}, id).Error
+	if err != nil {
+		logger.Errorf("Failed to delete address ID %d: %v", id, err)
+		return err
+	}
+	logger.Infof("Successfully deleted address ID: %d", id)
+	return nil
+}
+
+// DeleteByUserID deletes every address of a user
+func (r *addressRepositoryImpl) DeleteByUserID(userID uint) error {
+	return r.DeleteByUserIDTx(r.db, userID)
+}
+
+// DeleteByUserIDTx deletes every address of a user using an external transaction
+func (r *addressRepositoryImpl) DeleteByUserIDTx(tx *gorm.DB, userID uint) error {
+	logger.Infof("Deleting addresses of user ID: %d", userID)
+	err := tx.Where("user_id = ?", userID).Delete(&entity.Address{}).Error
+	if err != nil {
+		logger.Errorf("Failed to delete addresses of user ID %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Create(key *entity.APIKey) error
+	CreateTx(tx *gorm.DB, key *entity.APIKey) error
+	GetByID(id uint) (*entity.APIKey, error)
+	GetByHash(keyHash string) (*entity.APIKey, error)
+	GetAll(page, limit int, includeRevoked bool) ([]*entity.APIKey, int64, error)
+	Revoke(id uint) error
+	RevokeTx(tx *gorm.DB, id uint, revokedAt time.Time) error
+	TouchLastUsed(id uint, usedAt time.Time) error
+}
+
+type apiKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new API key
+func (r *apiKeyRepositoryImpl) Create(key *entity.APIKey) error {
+	return r.CreateTx(r.db, key)
+}
+
+// CreateTx stores a new API key using an external transaction
+func (r *apiKeyRepositoryImpl) CreateTx(tx *gorm.DB, key *entity.APIKey) error {
+	logger.Infof("Creating API key: %s", key.Name)
+	err := tx.Create(key).Error
+	if err != nil {
+		logger.Errorf("Failed to create API key: %v", err)
+		return err
+	}
+	logger.Infof("Successfully created API key with ID: %d", key.ID)
+	return nil
+}
+
+// GetByID gets an API key by ID
+func (r *apiKeyRepositoryImpl) GetByID(id uint) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.First(&key, id).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch API key by ID %d: %v", id, err)
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByHash gets an API key by the hash of its secret
+func (r *apiKeyRepositoryImpl) GetByHash(keyHash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetAll gets API keys with pagination, newest first
+func (r *apiKeyRepositoryImpl) GetAll(page, limit int, includeRevoked bool) ([]*entity.APIKey, int64, error) {
+	logger.Infof("Fetching API keys - page: %d, limit: %d, includeRevoked: %t", page, limit, includeRevoked)
+	var keys []*entity.APIKey
+	var total int64
+
+	query := r.db.Model(&entity.APIKey{})
+	if !includeRevoked {
+		query = query.Where("revoked_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("Failed to count API keys: %v", err)
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&keys).Error; err != nil {
+		logger.Errorf("Failed to fetch API keys: %v", err)
+		return nil, 0, err
+	}
+
+	logger.Infof("Successfully fetched %d API keys (total: %d)", len(keys), total)
+	return keys, total, nil
+}
+
+// Revoke marks an API key as revoked
+func (r *apiKeyRepositoryImpl) Revoke(id uint) error {
+	return r.RevokeTx(r.db, id, time.Now())
+}
+
+// RevokeTx marks an API key as revoked at revokedAt using an external transaction
+func (r *apiKeyRepositoryImpl) RevokeTx(tx *gorm.DB, id uint, revokedAt time.Time) error {
+	logger.Infof("Revoking API key ID: %d", id)
+	err := tx.Model(&entity.APIKey{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", revokedAt).Error
+	if err != nil {
+		logger.Errorf("Failed to revoke API key ID %d: %v", id, err)
+		return err
+	}
+	logger.Infof("Successfully revoked API key ID: %d", id)
+	return nil
+}
+
+// TouchLastUsed records when an API key was last used
+func (r *apiKeyRepositoryImpl) TouchLastUsed(id uint, usedAt time.Time) error {
+	err := r.db.Model(&entity.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error
+	if err != nil {
+		logger.Errorf("Failed to update last used time of API key ID %d: %v", id, err)
+		return err
+	}
+	return nil
+}
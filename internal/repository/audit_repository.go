@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// AuditFilter narrows down ListAuditEvents; zero values are ignored
+type AuditFilter struct {
+	ActorID    uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditRepository interface {
+	Create(event *entity.AuditEvent) error
+	CreateTx(tx *gorm.DB, event *entity.AuditEvent) error
+	GetAll(filter AuditFilter, page, limit int) ([]*entity.AuditEvent, int64, error)
+}
+
+type auditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) AuditRepository {
+	return &auditRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores an audit event for an action that does not change any table
+func (r *auditRepositoryImpl) Create(event *entity.AuditEvent) error {
+	return r.CreateTx(r.db, event)
+}
+
+// CreateTx stores an audit event in the transaction of the change it describes
+func (r *auditRepositoryImpl) CreateTx(tx *gorm.DB, event *entity.AuditEvent) error {
+	logger.Infof("Recording audit event %s for %s ID %d by user ID %d", event.Action, event.EntityType, event.EntityID, event.ActorID)
+	err := tx.Create(event).Error
+	if err != nil {
+		logger.Errorf("Failed to record audit event %s: %v", event.Action, err)
+		return err
+	}
+	return nil
+}
+
+// GetAll retrieves audit events matching filter, newest first
+func (r *auditRepositoryImpl) GetAll(filter AuditFilter, page, limit int) ([]*entity.AuditEvent, int64, error) {
+	logger.Infof("Fetching audit events - page: %d, limit: %d", page, limit)
+	var events []*entity.AuditEvent
+	var total int64
+
+	query := r.db.Model(&entity.AuditEvent{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("Failed to count audit events: %v", err)
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&events).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch audit events: %v", err)
+		return nil, 0, err
+	}
+
+	logger.Infof("Successfully fetched %d audit events out of %d total", len(events), total)
+	return events, total, nil
+}
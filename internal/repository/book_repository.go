@@ -1,21 +1,80 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/pkg/logger"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Search modes supported by GetAll
+const (
+	// SearchModeFullText matches words and word prefixes against the title,
+	// author, category name and description, most relevant books first
+	SearchModeFullText = "fulltext"
+	// SearchModeSubstring matches any part of the title or author
+	SearchModeSubstring = "substring"
 )
 
+// maxSearchTerms limits the words of a full-text search
+const maxSearchTerms = 10
+
+// searchHeadlineOptions configures the snippets of full-text search results
+const searchHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" ... "`
+
+// pgQueryCanceled is the Postgres error code of statements cancelled by
+// statement_timeout
+const pgQueryCanceled = "57014"
+
+// ErrQueryTimeout is returned when a query exceeds its time budget
+var ErrQueryTimeout = errors.New("query exceeded its time budget")
+
+// BookSuggestion is a title or author completing a SuggestBooks prefix
+type BookSuggestion struct {
+	Text string
+	// Field is "title" or "author"
+	Field string
+	// BookID is the book with this title, 0 for authors
+	BookID uint
+}
+
 type BookRepository interface {
 	Create(book *entity.Book) error
+	CreateTx(tx *gorm.DB, book *entity.Book) error
 	GetByID(id uint) (*entity.Book, error)
+	GetByISBN(isbn string) (*entity.Book, error)
 	Update(book *entity.Book) error
+	UpdateTx(tx *gorm.DB, book *entity.Book) error
 	Delete(id uint) error
-	GetAll(page, limit int, search string) ([]*entity.Book, int64, error)
+	DeleteTx(tx *gorm.DB, id uint) error
+	GetAll(page, limit int, search, mode string) ([]*entity.Book, int64, error)
+	GetSimilar(page, limit int, search string) ([]*entity.Book, int64, error)
+	DidYouMean(search string) (string, error)
+	Suggest(prefix string, limit int, timeout time.Duration) ([]BookSuggestion, string, error)
 	GetByCategory(categoryID uint, page, limit int) ([]*entity.Book, int64, error)
 	UpdateStock(id uint, stock int) error
 	UpdateStockTx(tx *gorm.DB, id uint, stock int) error
 	CheckStock(id uint, quantity int) (bool, error)
+	UpdateCoverTx(tx *gorm.DB, id uint, coverKey, thumbnailKey string) error
+	HasLegacyCovers() bool
+	GetLegacyCovers(afterID uint, limit int) ([]LegacyCover, error)
+	MigrateLegacyCover(id uint, coverKey, thumbnailKey string) error
+	DropLegacyCoverColumn() error
+}
+
+// LegacyCover is a cover still stored as base64 text in the image_base64
+// column, which predates the blob store
+type LegacyCover struct {
+	ID          uint
+	ImageBase64 string
 }
 
 type bookRepositoryImpl struct {
@@ -30,8 +89,13 @@ func NewBookRepository(db *gorm.DB) BookRepository {
 
 // Create creates a new book
 func (r *bookRepositoryImpl) Create(book *entity.Book) error {
+	return r.CreateTx(r.db, book)
+}
+
+// CreateTx creates a new book using an external transaction
+func (r *bookRepositoryImpl) CreateTx(tx *gorm.DB, book *entity.Book) error {
 	logger.Infof("Creating new book: %s", book.Title)
-	err := r.db.Create(book).Error
+	err := tx.Create(book).Error
 	if err != nil {
 		logger.Errorf("Failed to create book: %v", err)
 		return err
@@ -53,10 +117,28 @@ func (r *bookRepositoryImpl) GetByID(id uint) (*entity.Book, error) {
 	return &book, nil
 }
 
+// GetByISBN retrieves a book by its normalized ISBN-13 with category
+func (r *bookRepositoryImpl) GetByISBN(isbn string) (*entity.Book, error) {
+	logger.Infof("Fetching book by ISBN: %s", isbn)
+	var book entity.Book
+	err := r.db.Preload("Category").Where("isbn = ?", isbn).First(&book).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch book by ISBN %s: %v", isbn, err)
+		return nil, err
+	}
+	logger.Infof("Successfully fetched book: %s", book.Title)
+	return &book, nil
+}
+
 // Update updates an existing book
 func (r *bookRepositoryImpl) Update(book *entity.Book) error {
+	return r.UpdateTx(r.db, book)
+}
+
+// UpdateTx updates an existing book using an external transaction
+func (r *bookRepositoryImpl) UpdateTx(tx *gorm.DB, book *entity.Book) error {
 	logger.Infof("Updating book with ID: %d", book.ID)
-	err := r.db.Save(book).Error
+	err := tx.Save(book).Error
 	if err != nil {
 		logger.Errorf("Failed to update book with ID %d: %v", book.ID, err)
 		return err
@@ -67,8 +149,13 @@ func (r *bookRepositoryImpl) Update(book *entity.Book) error {
 
 // Delete deletes a book by ID
 func (r *bookRepositoryImpl) Delete(id uint) error {
+	return r.DeleteTx(r.db, id)
+}
+
+// DeleteTx deletes a book by ID using an external transaction
+func (r *bookRepositoryImpl) DeleteTx(tx *gorm.DB, id uint) error {
 	logger.Infof("Deleting book with ID: %d", id)
-	err := r.db.Delete(&entity.Book{}, id).Error
+	err := tx.Delete(&entity.Book{}, id).Error
 	if err != nil {
 		logger.Errorf("Failed to delete book with ID %d: %v", id, err)
 		return err
@@ -77,17 +164,31 @@ func (r *bookRepositoryImpl) Delete(id uint) error {
 	return nil
 }
 
-// GetAll retrieves all books with pagination and optional search
-func (r *bookRepositoryImpl) GetAll(page, limit int, search string) ([]*entity.Book, int64, error) {
-	logger.Infof("Fetching all books - page: %d, limit: %d, search: %s", page, limit, search)
+// GetAll retrieves all books with pagination and optional search. Full-text
+// searches return the most relevant books first, each with a highlighted
+// snippet in SearchHighlight.
+func (r *bookRepositoryImpl) GetAll(page, limit int, search, mode string) ([]*entity.Book, int64, error) {
+	logger.Infof("Fetching all books - page: %d, limit: %d, search: %s, mode: %s", page, limit, search, mode)
 	var books []*entity.Book
 	var total int64
 
 	query := r.db.Model(&entity.Book{}).Preload("Category")
 
 	// Apply search filter if provided
+	var tsQuery string
 	if search != "" {
-		query = query.Where("title LIKE ? OR author LIKE ?", "%"+search+"%", "%"+search+"%")
+		switch mode {
+		case SearchModeSubstring:
+			pattern := "%" + escapeLike(search) + "%"
+			query = query.Where("title ILIKE ? OR author ILIKE ?", pattern, pattern)
+		default:
+			tsQuery = prefixTSQuery(search)
+			if tsQuery == "" {
+				logger.Infof("Search %q has no searchable words", search)
+				return []*entity.Book{}, 0, nil
+			}
+			query = query.Where("search_vector @@ to_tsquery('simple', ?)", tsQuery)
+		}
 	}
 
 	// Count total records
@@ -96,6 +197,17 @@ func (r *bookRepositoryImpl) GetAll(page, limit int, search string) ([]*entity.B
 		return nil, 0, err
 	}
 
+	// Rank full-text matches and highlight them
+	if tsQuery != "" {
+		query = query.
+			Select("books.*, ts_headline('simple', concat_ws(' | ', title, author, NULLIF(description, '')), to_tsquery('simple', ?), ?) AS search_highlight", tsQuery, searchHeadlineOptions).
+			Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:                "ts_rank(search_vector, to_tsquery('simple', ?)) DESC, id",
+				Vars:               []interface{}{tsQuery},
+				WithoutParentheses: true,
+			}})
+	}
+
 	// Calculate offset
 	offset := (page - 1) * limit
 
@@ -105,11 +217,110 @@ func (r *bookRepositoryImpl) GetAll(page, limit int, search string) ([]*entity.B
 		logger.Errorf("Failed to fetch books with pagination: %v", err)
 		return nil, 0, err
 	}
+	for _, book := range books {
+		book.SearchHighlight = escapeHighlight(book.SearchHighlight)
+	}
 
 	logger.Infof("Successfully fetched %d books out of %d total", len(books), total)
 	return books, total, nil
 }
 
+// GetSimilar retrieves books whose title or author resembles search despite
+// typos, using trigram word similarity (pg_trgm), most similar first
+func (r *bookRepositoryImpl) GetSimilar(page, limit int, search string) ([]*entity.Book, int64, error) {
+	logger.Infof("Fetching books similar to %q - page: %d, limit: %d", search, page, limit)
+	var books []*entity.Book
+	var total int64
+
+	query := r.db.Model(&entity.Book{}).Preload("Category").
+		Where("? <% title OR ? <% author", search, search)
+
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("Failed to count similar books: %v", err)
+		return nil, 0, err
+	}
+
+	// Calculate offset
+	offset := (page - 1) * limit
+
+	// Retrieve the most similar books first
+	err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "greatest(word_similarity(?, title), word_similarity(?, author)) DESC, id",
+			Vars:               []interface{}{search, search},
+			WithoutParentheses: true,
+		}}).
+		Offset(offset).Limit(limit).Find(&books).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch similar books: %v", err)
+		return nil, 0, err
+	}
+
+	logger.Infof("Successfully fetched %d books similar to %q out of %d total", len(books), search, total)
+	return books, total, nil
+}
+
+// DidYouMean returns the title or author closest to search, or "" when none
+// is similar enough or search already spells it correctly
+func (r *bookRepositoryImpl) DidYouMean(search string) (string, error) {
+	logger.Infof("Looking up spelling correction for %q", search)
+	correction, err := didYouMean(r.db, search)
+	if err != nil {
+		logger.Errorf("Failed to look up spelling correction for %q: %v", search, err)
+		return "", err
+	}
+	return correction, nil
+}
+
+// Suggest returns up to limit titles and authors containing prefix, those
+// starting with it first. When there are none, it also returns the closest
+// title or author as a spelling correction. Every statement is cancelled after
+// timeout, in which case ErrQueryTimeout is returned.
+func (r *bookRepositoryImpl) Suggest(prefix string, limit int, timeout time.Duration) ([]BookSuggestion, string, error) {
+	logger.Infof("Fetching suggestions for %q, limit: %d", prefix, limit)
+	var suggestions []BookSuggestion
+	var correction string
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// SET cannot take bind parameters; the value is an integer
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds())).Error; err != nil {
+			return err
+		}
+
+		pattern := escapeLike(prefix)
+		err := tx.Raw(`SELECT text, field, book_id FROM (
+	SELECT title AS text, 'title' AS field, id AS book_id FROM books
+	WHERE deleted_at IS NULL AND title ILIKE ?
+	UNION ALL
+	SELECT DISTINCT author, 'author', 0 FROM books
+	WHERE deleted_at IS NULL AND author ILIKE ?
+) suggestions
+ORDER BY text ILIKE ? DESC, similarity(text, ?) DESC, text
+LIMIT ?`,
+			"%"+pattern+"%", "%"+pattern+"%", pattern+"%", prefix, limit,
+		).Scan(&suggestions).Error
+		if err != nil || len(suggestions) > 0 {
+			return err
+		}
+
+		correction, err = didYouMean(tx, prefix)
+		return err
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgQueryCanceled {
+			logger.Warnf("Suggestions for %q exceeded %s", prefix, timeout)
+			return nil, "", ErrQueryTimeout
+		}
+		logger.Errorf("Failed to fetch suggestions for %q: %v", prefix, err)
+		return nil, "", err
+	}
+
+	logger.Infof("Successfully fetched %d suggestions for %q", len(suggestions), prefix)
+	return suggestions, correction, nil
+}
+
 // GetByCategory retrieves books by category with pagination
 func (r *bookRepositoryImpl) GetByCategory(categoryID uint, page, limit int) ([]*entity.Book, int64, error) {
 	logger.Infof("Fetching books by category ID: %d - page: %d, limit: %d", categoryID, page, limit)
@@ -176,3 +387,114 @@ func (r *bookRepositoryImpl) CheckStock(id uint, quantity int) (bool, error) {
 	logger.Infof("Stock check for book ID %d: current stock %d, required %d, sufficient: %t", id, book.Stock, quantity, hasStock)
 	return hasStock, nil
 }
+
+// UpdateCoverTx sets the blob keys of a book's cover using an external transaction
+func (r *bookRepositoryImpl) UpdateCoverTx(tx *gorm.DB, id uint, coverKey, thumbnailKey string) error {
+	logger.Infof("Updating cover for book ID %d", id)
+	err := tx.Model(&entity.Book{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"cover_key":     coverKey,
+		"thumbnail_key": thumbnailKey,
+	}).Error
+	if err != nil {
+		logger.Errorf("Failed to update cover for book ID %d: %v", id, err)
+		return err
+	}
+	logger.Infof("Successfully updated cover for book ID %d", id)
+	return nil
+}
+
+// HasLegacyCovers reports whether the image_base64 column still exists
+func (r *bookRepositoryImpl) HasLegacyCovers() bool {
+	return r.db.Migrator().HasColumn(&entity.Book{}, "image_base64")
+}
+
+// GetLegacyCovers retrieves up to limit books with an ID above afterID whose
+// cover is still in the image_base64 column, including deleted books
+func (r *bookRepositoryImpl) GetLegacyCovers(afterID uint, limit int) ([]LegacyCover, error) {
+	logger.Infof("Fetching legacy covers after book ID %d, limit: %d", afterID, limit)
+	var covers []LegacyCover
+	err := r.db.Raw(
+		"SELECT id, image_base64 FROM books WHERE id > ? AND image_base64 IS NOT NULL AND image_base64 <> '' ORDER BY id LIMIT ?",
+		afterID, limit,
+	).Scan(&covers).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch legacy covers: %v", err)
+		return nil, err
+	}
+	logger.Infof("Successfully fetched %d legacy covers", len(covers))
+	return covers, nil
+}
+
+// MigrateLegacyCover stores the blob keys of a migrated cover and clears its
+// base64 copy
+func (r *bookRepositoryImpl) MigrateLegacyCover(id uint, coverKey, thumbnailKey string) error {
+	logger.Infof("Migrating legacy cover for book ID %d", id)
+	err := r.db.Exec(
+		"UPDATE books SET cover_key = ?, thumbnail_key = ?, image_base64 = NULL WHERE id = ?",
+		coverKey, thumbnailKey, id,
+	).Error
+	if err != nil {
+		logger.Errorf("Failed to migrate legacy cover for book ID %d: %v", id, err)
+		return err
+	}
+	logger.Infof("Successfully migrated legacy cover for book ID %d", id)
+	return nil
+}
+
+// DropLegacyCoverColumn drops the image_base64 column once every cover has
+// been migrated
+func (r *bookRepositoryImpl) DropLegacyCoverColumn() error {
+	logger.Info("Dropping legacy image_base64 column")
+	if err := r.db.Migrator().DropColumn(&entity.Book{}, "image_base64"); err != nil {
+		logger.Errorf("Failed to drop legacy image_base64 column: %v", err)
+		return err
+	}
+	logger.Info("Successfully dropped legacy image_base64 column")
+	return nil
+}
+
+// prefixTSQuery turns free text into a tsquery matching books that contain
+// every word, or a word starting with it. Everything but letters and digits
+// separates words, so user input cannot inject tsquery operators.
+func prefixTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// escapeHighlight HTML-escapes a ts_headline snippet except for its <mark>
+// tags, so that snippets can be rendered as HTML safely
+func escapeHighlight(highlight string) string {
+	return strings.NewReplacer("&lt;mark&gt;", "<mark>", "&lt;/mark&gt;", "</mark>").Replace(html.EscapeString(highlight))
+}
+
+// didYouMean finds the title or author with the highest trigram word
+// similarity to search
+func didYouMean(db *gorm.DB, search string) (string, error) {
+	var candidates []string
+	err := db.Raw(`SELECT text FROM (
+	SELECT title AS text FROM books WHERE deleted_at IS NULL AND ? <% title
+	UNION
+	SELECT author FROM books WHERE deleted_at IS NULL AND ? <% author
+) candidates
+ORDER BY word_similarity(?, text) DESC, text
+LIMIT 1`,
+		search, search, search,
+	).Scan(&candidates).Error
+	if err != nil || len(candidates) == 0 {
+		return "", err
+	}
+	if strings.EqualFold(candidates[0], strings.TrimSpace(search)) {
+		return "", nil
+	}
+	return candidates[0], nil
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// hostileSearch contains tsquery operators, LIKE wildcards and SQL quoting
+const hostileSearch = `it's 100%_off: a&b | !c (d) <-> e:* \ '); DROP TABLE books; --`
+
+// recordedStatement is a statement sent to the database with its bind parameters
+type recordedStatement struct {
+	sql  string
+	args []interface{}
+}
+
+// statementRecorder stands in for the database connection. It records every
+// statement and answers with no rows, so that queries using Postgres-only SQL
+// can be checked without a Postgres server.
+type statementRecorder struct {
+	empty      *sql.DB
+	statements []recordedStatement
+}
+
+// noRows is run instead of every recorded statement
+const noRows = "SELECT 1 WHERE 0"
+
+func (r *statementRecorder) record(query string, args []interface{}) {
+	r.statements = append(r.statements, recordedStatement{sql: query, args: args})
+}
+
+func (r *statementRecorder) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	r.record(query, nil)
+	return r.empty.PrepareContext(ctx, noRows)
+}
+
+func (r *statementRecorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.record(query, args)
+	return r.empty.ExecContext(ctx, noRows)
+}
+
+func (r *statementRecorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	r.record(query, args)
+	return r.empty.QueryContext(ctx, noRows)
+}
+
+func (r *statementRecorder) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	r.record(query, args)
+	return r.empty.QueryRowContext(ctx, noRows)
+}
+
+func (r *statementRecorder) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return &recorderTx{r}, nil
+}
+
+// recorderTx is a transaction of a statementRecorder
+type recorderTx struct {
+	*statementRecorder
+}
+
+func (*recorderTx) Commit() error   { return nil }
+func (*recorderTx) Rollback() error { return nil }
+
+// newRecordingDB returns a database whose statements are recorded instead of
+// executed
+func newRecordingDB(t *testing.T) (*gorm.DB, *statementRecorder) {
+	t.Helper()
+
+	db := newTestDB(t)
+	empty, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	recorder := &statementRecorder{empty: empty}
+	db.ConnPool = recorder
+	db.Statement.ConnPool = recorder
+	return db, recorder
+}
+
+// newRecordingBookRepository returns a book repository whose statements are
+// recorded instead of executed
+func newRecordingBookRepository(t *testing.T) (BookRepository, *statementRecorder) {
+	t.Helper()
+
+	db, recorder := newRecordingDB(t)
+	return NewBookRepository(db), recorder
+}
+
+// assertParameterized fails if search was spliced into any statement instead
+// of being bound as a parameter
+func assertParameterized(t *testing.T, statements []recordedStatement, search string) {
+	t.Helper()
+
+	if len(statements) == 0 {
+		t.Fatal("no statements were sent")
+	}
+	for _, statement := range statements {
+		for _, fragment := range []string{search, "DROP TABLE", "100%"} {
+			if strings.Contains(statement.sql, fragment) {
+				t.Errorf("statement contains the search text %q: %s", fragment, statement.sql)
+			}
+		}
+	}
+}
+
+// hasArg reports whether any statement binds want
+func hasArg(statements []recordedStatement, want interface{}) bool {
+	for _, statement := range statements {
+		for _, arg := range statement.args {
+			if arg == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"harry potter", "harry:* & potter:*"},
+		{"  Harry   POTTER ", "harry:* & potter:*"},
+		{"tolkien's hobbit", "tolkien:* & s:* & hobbit:*"},
+		{"c++ & go!", "c:* & go:*"},
+		{"a:b|c&d!e", "a:* & b:* & c:* & d:* & e:*"},
+		{"(war) <-> peace", "war:* & peace:*"},
+		{"'quoted' \"double\"", "quoted:* & double:*"},
+		{`back\slash`, "back:* & slash:*"},
+		{"100%_off", "100:* & off:*"},
+		{"café 東京 1984", "café:* & 東京:* & 1984:*"},
+		{"1 2 3 4 5 6 7 8 9 10 11 12", "1:* & 2:* & 3:* & 4:* & 5:* & 6:* & 7:* & 8:* & 9:* & 10:*"},
+		{"", ""},
+		{"   ", ""},
+		{":*&|!()'", ""},
+	}
+	for _, tt := range tests {
+		if got := prefixTSQuery(tt.search); got != tt.want {
+			t.Errorf("prefixTSQuery(%q) = %q, want %q", tt.search, got, tt.want)
+		}
+	}
+}
+
+func TestPrefixTSQueryOnlyContainsWords(t *testing.T) {
+	query := prefixTSQuery(hostileSearch)
+	for _, term := range strings.Split(query, " & ") {
+		word, ok := strings.CutSuffix(term, ":*")
+		if !ok || word == "" {
+			t.Fatalf("prefixTSQuery(%q) = %q, term %q is not a prefix match", hostileSearch, query, term)
+		}
+		for _, r := range word {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				t.Errorf("prefixTSQuery(%q) = %q, term %q contains %q", hostileSearch, query, term, r)
+			}
+		}
+	}
+}
+
+func TestEscapeHighlight(t *testing.T) {
+	tests := []struct {
+		highlight string
+		want      string
+	}{
+		{"The <mark>Hobbit</mark>", "The <mark>Hobbit</mark>"},
+		{"<script>alert(1)</script> <mark>x</mark>", "&lt;script&gt;alert(1)&lt;/script&gt; <mark>x</mark>"},
+		{`Tom & "Jerry"`, "Tom &amp; &#34;Jerry&#34;"},
+		{"<mark onclick=x>", "&lt;mark onclick=x&gt;"},
+	}
+	for _, tt := range tests {
+		if got := escapeHighlight(tt.highlight); got != tt.want {
+			t.Errorf("escapeHighlight(%q) = %q, want %q", tt.highlight, got, tt.want)
+		}
+	}
+}
+
+func TestGetAllBindsSearch(t *testing.T) {
+	t.Run("full text", func(t *testing.T) {
+		repo, recorder := newRecordingBookRepository(t)
+		if _, _, err := repo.GetAll(1, 10, hostileSearch, SearchModeFullText); err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		assertParameterized(t, recorder.statements, hostileSearch)
+		if want := prefixTSQuery(hostileSearch); !hasArg(recorder.statements, want) {
+			t.Errorf("tsquery %q is not bound as a parameter", want)
+		}
+	})
+
+	t.Run("full text without words", func(t *testing.T) {
+		repo, recorder := newRecordingBookRepository(t)
+		books, total, err := repo.GetAll(1, 10, ":*&|!", SearchModeFullText)
+		if err != nil || len(books) != 0 || total != 0 {
+			t.Fatalf("GetAll() = %d books, %d total, %v, want no books", len(books), total, err)
+		}
+		if len(recorder.statements) != 0 {
+			t.Errorf("GetAll sent %d statements, want none", len(recorder.statements))
+		}
+	})
+
+	t.Run("substring", func(t *testing.T) {
+		repo, recorder := newRecordingBookRepository(t)
+		if _, _, err := repo.GetAll(1, 10, hostileSearch, SearchModeSubstring); err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		assertParameterized(t, recorder.statements, hostileSearch)
+		if want := "%" + escapeLike(hostileSearch) + "%"; !hasArg(recorder.statements, want) {
+			t.Errorf("pattern %q is not bound as a parameter", want)
+		}
+	})
+}
+
+func TestGetSimilarBindsSearch(t *testing.T) {
+	repo, recorder := newRecordingBookRepository(t)
+	if _, _, err := repo.GetSimilar(1, 10, hostileSearch); err != nil {
+		t.Fatalf("GetSimilar: %v", err)
+	}
+	assertParameterized(t, recorder.statements, hostileSearch)
+	if !hasArg(recorder.statements, hostileSearch) {
+		t.Error("search is not bound as a parameter")
+	}
+}
+
+func TestDidYouMeanBindsSearch(t *testing.T) {
+	repo, recorder := newRecordingBookRepository(t)
+	correction, err := repo.DidYouMean(hostileSearch)
+	if err != nil {
+		t.Fatalf("DidYouMean: %v", err)
+	}
+	if correction != "" {
+		t.Errorf("DidYouMean() = %q without candidates, want none", correction)
+	}
+	assertParameterized(t, recorder.statements, hostileSearch)
+	if !hasArg(recorder.statements, hostileSearch) {
+		t.Error("search is not bound as a parameter")
+	}
+}
+
+func TestSuggestBindsPrefix(t *testing.T) {
+	repo, recorder := newRecordingBookRepository(t)
+	suggestions, correction, err := repo.Suggest(hostileSearch, 5, 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Suggest: %v", err)
+	}
+	if len(suggestions) != 0 || correction != "" {
+		t.Errorf("Suggest() = %v, %q without books, want nothing", suggestions, correction)
+	}
+	assertParameterized(t, recorder.statements, hostileSearch)
+
+	// The timeout, the suggestions and, since there are none, the did-you-mean lookup
+	if len(recorder.statements) != 3 {
+		t.Fatalf("Suggest sent %d statements, want 3", len(recorder.statements))
+	}
+	if got := recorder.statements[0].sql; got != "SET LOCAL statement_timeout = 200" {
+		t.Errorf("timeout statement = %q", got)
+	}
+	pattern := escapeLike(hostileSearch)
+	for _, want := range []interface{}{"%" + pattern + "%", pattern + "%", hostileSearch, 5} {
+		if !hasArg(recorder.statements[1:2], want) {
+			t.Errorf("suggestion query does not bind %q", want)
+		}
+	}
+	if !hasArg(recorder.statements[2:], hostileSearch) {
+		t.Error("did-you-mean query does not bind the prefix")
+	}
+}
@@ -8,10 +8,13 @@ import (
 
 type CategoryRepository interface {
 	Create(category *entity.Category) error
+	CreateTx(tx *gorm.DB, category *entity.Category) error
 	GetByID(id uint) (*entity.Category, error)
 	GetByName(name string) (*entity.Category, error)
 	Update(category *entity.Category) error
+	UpdateTx(tx *gorm.DB, category *entity.Category) error
 	Delete(id uint) error
+	DeleteTx(tx *gorm.DB, id uint) error
 	GetAll(page, limit int) ([]*entity.Category, int64, error)
 }
 
@@ -27,8 +30,13 @@ func NewCategoryRepository(db *gorm.DB) CategoryRepository {
 
 // Create creates a new category
 func (r *categoryRepositoryImpl) Create(category *entity.Category) error {
+	return r.CreateTx(r.db, category)
+}
+
+// CreateTx creates a new category using an external transaction
+func (r *categoryRepositoryImpl) CreateTx(tx *gorm.DB, category *entity.Category) error {
 	logger.Infof("Creating new category: %s", category.Name)
-	err := r.db.Create(category).Error
+	err := tx.Create(category).Error
 	if err != nil {
 		logger.Errorf("Failed to create category: %v", err)
 		return err
@@ -65,8 +73,13 @@ func (r *categoryRepositoryImpl) GetByName(name string) (*entity.Category, error
 
 // Update updates a category
 func (r *categoryRepositoryImpl) Update(category *entity.Category) error {
+	return r.UpdateTx(r.db, category)
+}
+
+// UpdateTx updates a category using an external transaction
+func (r *categoryRepositoryImpl) UpdateTx(tx *gorm.DB, category *entity.Category) error {
 	logger.Infof("Updating category with ID: %d", category.ID)
-	err := r.db.Save(category).Error
+	err := tx.Save(category).Error
 	if err != nil {
 		logger.Errorf("Failed to update category with ID %d: %v", category.ID, err)
 		return err
@@ -77,8 +90,13 @@ func (r *categoryRepositoryImpl) Update(category *entity.Category) error {
 
 // Delete deletes a category
 func (r *categoryRepositoryImpl) Delete(id uint) error {
+	return r.DeleteTx(r.db, id)
+}
+
+// DeleteTx deletes a category using an external transaction
+func (r *categoryRepositoryImpl) DeleteTx(tx *gorm.DB, id uint) error {
 	logger.Infof("Deleting category with ID: %d", id)
-	err := r.db.Delete(&entity.Category{}, id).Error
+	err := tx.Delete(&entity.Category{}, id).Error
 	if err != nil {
 		logger.Errorf("Failed to delete category with ID %d: %v", id, err)
 		return err
@@ -0,0 +1,9 @@
+package repository
+
+import "strings"
+
+// escapeLike escapes the LIKE wildcards in s, using the default escape
+// character (backslash)
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
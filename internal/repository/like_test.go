@@ -0,0 +1,52 @@
+package repository
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"tolkien", "tolkien"},
+		{"100%", `100\%`},
+		{"snake_case", `snake\_case`},
+		{`back\slash`, `back\\slash`},
+		{`%_\`, `\%\_\\`},
+		{`\%`, `\\\%`},
+	}
+	for _, tt := range tests {
+		if got := escapeLike(tt.s); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeLikeMatchesLiterally(t *testing.T) {
+	db := newTestDB(t)
+
+	tests := []struct {
+		text   string
+		search string
+		match  bool
+	}{
+		{"100% cotton", "0%", true},
+		{"100 cotton", "0%", false},
+		{"snake_case", "e_c", true},
+		{"snakeXcase", "e_c", false},
+		{`back\slash`, `k\s`, true},
+		{"backslash", `k\s`, false},
+		{"tolkien", "LKI", true},
+	}
+	for _, tt := range tests {
+		// Postgres uses backslash as the LIKE escape character by default;
+		// SQLite needs it spelled out
+		var match bool
+		err := db.Raw(`SELECT ? LIKE ? ESCAPE '\'`, tt.text, "%"+escapeLike(tt.search)+"%").Scan(&match).Error
+		if err != nil {
+			t.Fatalf("LIKE %q: %v", tt.search, err)
+		}
+		if match != tt.match {
+			t.Errorf("%q LIKE %%%s%% = %v, want %v", tt.text, escapeLike(tt.search), match, tt.match)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginAttemptRepository interface {
+	GetByKeys(keys []string) ([]*entity.LoginAttempt, error)
+	RecordFailure(key string, resetBefore time.Time) (*entity.LoginAttempt, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+	ResetTx(tx *gorm.DB, key string) error
+	DeleteExpired(before time.Time) error
+}
+
+type loginAttemptRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetByKeys gets the attempt counters of the given keys that exist
+func (r *loginAttemptRepositoryImpl) GetByKeys(keys []string) ([]*entity.LoginAttempt, error) {
+	var attempts []*entity.LoginAttempt
+	err := r.db.Where("key IN ?", keys).Find(&attempts).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch login attempts: %v", err)
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// RecordFailure increments the failure counter of key and returns the updated counter.
+// Counters whose last failure happened before resetBefore start again from zero.
+func (r *loginAttemptRepositoryImpl) RecordFailure(key string, resetBefore time.Time) (*entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+	now := time.Now()
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&attempt).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			attempt = entity.LoginAttempt{Key: key, Failures: 1, LastFailureAt: now}
+			// A concurrent first failure may have created the row in the meantime
+			return tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "key"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"failures":        gorm.Expr("login_attempts.failures + 1"),
+					"last_failure_at": now,
+				}),
+			}).Create(&attempt).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if attempt.LastFailureAt.Before(resetBefore) {
+			attempt.Failures = 0
+			attempt.LockedUntil = nil
+		}
+		attempt.Failures++
+		attempt.LastFailureAt = now
+		return tx.Save(&attempt).Error
+	})
+	if err != nil {
+		logger.Errorf("Failed to record login failure for %s: %v", key, err)
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// Lock locks key until the given time
+func (r *loginAttemptRepositoryImpl) Lock(key string, until time.Time) error {
+	err := r.db.Model(&entity.LoginAttempt{}).Where("key = ?", key).Update("locked_until", until).Error
+	if err != nil {
+		logger.Errorf("Failed to lock %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+// Reset clears the failure counter and lock of key
+func (r *loginAttemptRepositoryImpl) Reset(key string) error {
+	return r.ResetTx(r.db, key)
+}
+
+// ResetTx clears the failure counter and lock of key using an external transaction
+func (r *loginAttemptRepositoryImpl) ResetTx(tx *gorm.DB, key string) error {
+	logger.Infof("Resetting login attempts for %s", key)
+	err := tx.Where("key = ?", key).Delete(&entity.LoginAttempt{}).Error
+	if err != nil {
+		logger.Errorf("Failed to reset login attempts for %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteExpired removes counters that are no longer locked and whose last failure happened before the given time
+func (r *loginAttemptRepositoryImpl) DeleteExpired(before time.Time) error {
+	result := r.db.Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now()).Delete(&entity.LoginAttempt{})
+	if result.Error != nil {
+		logger.Errorf("Failed to delete expired login attempts: %v", result.Error)
+		return result.Error
+	}
+	logger.Infof("Deleted %d expired login attempts", result.RowsAffected)
+	return nil
+}
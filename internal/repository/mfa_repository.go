@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type MFARepository interface {
+	ReplaceRecoveryCodes(userID uint, codes []*entity.MFARecoveryCode) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+	DeleteRecoveryCodes(userID uint) error
+	DeleteRecoveryCodesTx(tx *gorm.DB, userID uint) error
+}
+
+type mfaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepositoryImpl{
+		db: db,
+	}
+}
+
+// ReplaceRecoveryCodes deletes every recovery code of a user and stores the new ones
+func (r *mfaRepositoryImpl) ReplaceRecoveryCodes(userID uint, codes []*entity.MFARecoveryCode) error {
+	logger.Infof("Replacing recovery codes for user ID: %d", userID)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		logger.Errorf("Failed to replace recovery codes for user ID %d: %v", userID, err)
+		return err
+	}
+	logger.Infof("Successfully stored %d recovery codes for user ID: %d", len(codes), userID)
+	return nil
+}
+
+// DeleteRecoveryCodes deletes every recovery code of a user
+func (r *mfaRepositoryImpl) DeleteRecoveryCodes(userID uint) error {
+	return r.DeleteRecoveryCodesTx(r.db, userID)
+}
+
+// DeleteRecoveryCodesTx deletes every recovery code of a user using an external transaction
+func (r *mfaRepositoryImpl) DeleteRecoveryCodesTx(tx *gorm.DB, userID uint) error {
+	logger.Infof("Deleting recovery codes for user ID: %d", userID)
+	if err := tx.Where("user_id = ?", userID).Delete(&entity.MFARecoveryCode{}).Error; err != nil {
+		logger.Errorf("Failed to delete recovery codes for user ID %d: %v", userID, err)
+		return err
+	}
+	logger.Infof("Successfully deleted recovery codes for user ID: %d", userID)
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code as used. It reports false if
+// the code does not exist or was already used.
+func (r *mfaRepositoryImpl) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&entity.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		logger.Errorf("Failed to use recovery code for user ID %d: %v", userID, result.Error)
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		logger.Infof("Recovery code used for user ID: %d", userID)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountUnusedRecoveryCodes counts the recovery codes a user can still use
+func (r *mfaRepositoryImpl) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.MFARecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error
+	if err != nil {
+		logger.Errorf("Failed to count recovery codes for user ID %d: %v", userID, err)
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/nabil/book-store-system/internal/entity"
+)
+
+func TestUseRecoveryCodeOnlyOnce(t *testing.T) {
+	repo := NewMFARepository(newTestDB(t, &entity.MFARecoveryCode{}))
+
+	codes := []*entity.MFARecoveryCode{
+		{UserID: 1, CodeHash: "hash-a"},
+		{UserID: 1, CodeHash: "hash-b"},
+		{UserID: 2, CodeHash: "hash-c"},
+	}
+	if err := repo.ReplaceRecoveryCodes(1, codes); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes: %v", err)
+	}
+
+	steps := []struct {
+		name   string
+		userID uint
+		hash   string
+		want   bool
+	}{
+		{"first use", 1, "hash-a", true},
+		{"reuse", 1, "hash-a", false},
+		{"other code still valid", 1, "hash-b", true},
+		{"code of another user", 1, "hash-c", false},
+		{"unknown code", 1, "hash-x", false},
+	}
+	for _, step := range steps {
+		used, err := repo.UseRecoveryCode(step.userID, step.hash)
+		if err != nil {
+			t.Fatalf("%s: UseRecoveryCode: %v", step.name, err)
+		}
+		if used != step.want {
+			t.Errorf("%s: UseRecoveryCode(%d, %q) = %v, want %v", step.name, step.userID, step.hash, used, step.want)
+		}
+	}
+
+	unused, err := repo.CountUnusedRecoveryCodes(1)
+	if err != nil {
+		t.Fatalf("CountUnusedRecoveryCodes: %v", err)
+	}
+	if unused != 0 {
+		t.Errorf("CountUnusedRecoveryCodes(1) = %d, want 0", unused)
+	}
+}
+
+func TestReplaceRecoveryCodesDiscardsOldCodes(t *testing.T) {
+	repo := NewMFARepository(newTestDB(t, &entity.MFARecoveryCode{}))
+
+	if err := repo.ReplaceRecoveryCodes(1, []*entity.MFARecoveryCode{{UserID: 1, CodeHash: "old"}}); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes: %v", err)
+	}
+	if err := repo.ReplaceRecoveryCodes(1, []*entity.MFARecoveryCode{{UserID: 1, CodeHash: "new"}}); err != nil {
+		t.Fatalf("ReplaceRecoveryCodes: %v", err)
+	}
+
+	if used, err := repo.UseRecoveryCode(1, "old"); err != nil || used {
+		t.Errorf("UseRecoveryCode(old) = %v, %v, want false, nil", used, err)
+	}
+	if used, err := repo.UseRecoveryCode(1, "new"); err != nil || !used {
+		t.Errorf("UseRecoveryCode(new) = %v, %v, want true, nil", used, err)
+	}
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OIDCRepository interface {
+	CreateState(state *entity.OIDCLoginState) error
+	ConsumeState(stateHash string) (*entity.OIDCLoginState, error)
+	DeleteExpiredStates() error
+	GetIdentity(provider, subject string) (*entity.ExternalIdentity, error)
+	GetIdentitiesByUserID(userID uint) ([]*entity.ExternalIdentity, error)
+	CreateIdentity(identity *entity.ExternalIdentity) error
+	UpdateIdentity(identity *entity.ExternalIdentity) error
+	DeleteIdentitiesByUserID(userID uint) error
+	DeleteIdentitiesByUserIDTx(tx *gorm.DB, userID uint) error
+}
+
+type oidcRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOIDCRepository(db *gorm.DB) OIDCRepository {
+	return &oidcRepositoryImpl{
+		db: db,
+	}
+}
+
+// CreateState stores the state of a login started with BeginOIDCLogin
+func (r *oidcRepositoryImpl) CreateState(state *entity.OIDCLoginState) error {
+	err := r.db.Create(state).Error
+	if err != nil {
+		logger.Errorf("Failed to create OIDC login state: %v", err)
+		return err
+	}
+	return nil
+}
+
+// ConsumeState deletes an unexpired login state and returns it. Concurrent
+// calls with the same state cannot both succeed.
+func (r *oidcRepositoryImpl) ConsumeState(stateHash string) (*entity.OIDCLoginState, error) {
+	var state entity.OIDCLoginState
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("state_hash = ? AND expires_at > ?", stateHash, time.Now()).
+			First(&state).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&state).Error
+	})
+	if err != nil {
+		logger.Errorf("Failed to consume OIDC login state: %v", err)
+		return nil, err
+	}
+	return &state, nil
+}
+
+// DeleteExpiredStates removes login states that were never completed
+func (r *oidcRepositoryImpl) DeleteExpiredStates() error {
+	err := r.db.Where("expires_at < ?", time.Now()).Delete(&entity.OIDCLoginState{}).Error
+	if err != nil {
+		logger.Errorf("Failed to delete expired OIDC login states: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetIdentity gets the identity with the given subject at a provider
+func (r *oidcRepositoryImpl) GetIdentity(provider, subject string) (*entity.ExternalIdentity, error) {
+	var identity entity.ExternalIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// GetIdentitiesByUserID gets the external identities linked to a user
+func (r *oidcRepositoryImpl) GetIdentitiesByUserID(userID uint) ([]*entity.ExternalIdentity, error) {
+	var identities []*entity.ExternalIdentity
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch external identities of user ID %d: %v", userID, err)
+		return nil, err
+	}
+	return identities, nil
+}
+
+// CreateIdentity links a new external identity to a user
+func (r *oidcRepositoryImpl) CreateIdentity(identity *entity.ExternalIdentity) error {
+	logger.Infof("Linking %s identity to user ID: %d", identity.Provider, identity.UserID)
+	err := r.db.Create(identity).Error
+	if err != nil {
+		logger.Errorf("Failed to create external identity: %v", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateIdentity updates an external identity
+func (r *oidcRepositoryImpl) UpdateIdentity(identity *entity.ExternalIdentity) error {
+	err := r.db.Save(identity).Error
+	if err != nil {
+		logger.Errorf("Failed to update external identity ID %d: %v", identity.ID, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteIdentitiesByUserID unlinks every external identity of a user
+func (r *oidcRepositoryImpl) DeleteIdentitiesByUserID(userID uint) error {
+	return r.DeleteIdentitiesByUserIDTx(r.db, userID)
+}
+
+// DeleteIdentitiesByUserIDTx unlinks every external identity of a user using an external transaction
+func (r *oidcRepositoryImpl) DeleteIdentitiesByUserIDTx(tx *gorm.DB, userID uint) error {
+	err := tx.Where("user_id = ?", userID).Delete(&entity.ExternalIdentity{}).Error
+	if err != nil {
+		logger.Errorf("Failed to delete external identities of user ID %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PasswordResetRepository interface {
+	Create(token *entity.PasswordResetToken) error
+	CountSince(userID uint, since time.Time) (int64, error)
+	Consume(tokenHash string) (*entity.PasswordResetToken, error)
+	InvalidateForUser(userID uint) error
+	InvalidateForUserTx(tx *gorm.DB, userID uint) error
+	DeleteExpired() error
+}
+
+type passwordResetRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new password reset token
+func (r *passwordResetRepositoryImpl) Create(token *entity.PasswordResetToken) error {
+	logger.Infof("Creating password reset token for user ID: %d", token.UserID)
+	err := r.db.Create(token).Error
+	if err != nil {
+		logger.Errorf("Failed to create password reset token: %v", err)
+		return err
+	}
+	return nil
+}
+
+// CountSince counts the reset tokens created for a user since the given time
+func (r *passwordResetRepositoryImpl) CountSince(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.PasswordResetToken{}).Where("user_id = ? AND created_at > ?", userID, since).Count(&count).Error
+	if err != nil {
+		logger.Errorf("Failed to count password reset tokens for user ID %d: %v", userID, err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// Consume marks an unused, unexpired token as used and returns it. Concurrent
+// calls with the same token cannot both succeed.
+func (r *passwordResetRepositoryImpl) Consume(tokenHash string) (*entity.PasswordResetToken, error) {
+	var token entity.PasswordResetToken
+	now := time.Now()
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&token).Error
+		if err != nil {
+			return err
+		}
+
+		token.UsedAt = &now
+		return tx.Model(&token).Update("used_at", now).Error
+	})
+	if err != nil {
+		logger.Errorf("Failed to consume password reset token: %v", err)
+		return nil, err
+	}
+
+	logger.Infof("Password reset token consumed for user ID: %d", token.UserID)
+	return &token, nil
+}
+
+// InvalidateForUser marks every unused reset token of a user as used
+func (r *passwordResetRepositoryImpl) InvalidateForUser(userID uint) error {
+	return r.InvalidateForUserTx(r.db, userID)
+}
+
+// InvalidateForUserTx marks every unused reset token of a user as used using an external transaction
+func (r *passwordResetRepositoryImpl) InvalidateForUserTx(tx *gorm.DB, userID uint) error {
+	err := tx.Model(&entity.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		logger.Errorf("Failed to invalidate password reset tokens for user ID %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteExpired removes reset tokens that are past their expiry
+func (r *passwordResetRepositoryImpl) DeleteExpired() error {
+	err := r.db.Where("expires_at < ?", time.Now()).Delete(&entity.PasswordResetToken{}).Error
+	if err != nil {
+		logger.Errorf("Failed to delete expired password reset tokens: %v", err)
+		return err
+	}
+	return nil
+}
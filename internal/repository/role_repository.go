@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	GetAll() ([]*entity.Role, error)
+	GetByName(name string) (*entity.Role, error)
+	GetPermissionNames(roleName string) ([]string, error)
+	GetAllPermissions() ([]*entity.Permission, error)
+	GetPermissionsByNames(names []string) ([]entity.Permission, error)
+	Save(role *entity.Role, permissions []entity.Permission) error
+	SaveTx(tx *gorm.DB, role *entity.Role, permissions []entity.Permission) error
+}
+
+type roleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetAll gets all roles with their permissions
+func (r *roleRepositoryImpl) GetAll() ([]*entity.Role, error) {
+	logger.Infof("Fetching all roles")
+	var roles []*entity.Role
+	err := r.db.Preload("Permissions").Order("name").Find(&roles).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch roles: %v", err)
+		return nil, err
+	}
+	logger.Infof("Successfully fetched %d roles", len(roles))
+	return roles, nil
+}
+
+// GetByName gets a role and its permissions by name
+func (r *roleRepositoryImpl) GetByName(name string) (*entity.Role, error) {
+	logger.Infof("Fetching role by name: %s", name)
+	var role entity.Role
+	err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch role by name %s: %v", name, err)
+		return nil, err
+	}
+	logger.Infof("Successfully fetched role: %s", name)
+	return &role, nil
+}
+
+// GetPermissionNames gets the names of the permissions granted to a role
+func (r *roleRepositoryImpl) GetPermissionNames(roleName string) ([]string, error) {
+	var names []string
+	err := r.db.Model(&entity.Permission{}).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ?", roleName).
+		Pluck("permissions.name", &names).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch permissions of role %s: %v", roleName, err)
+		return nil, err
+	}
+	return names, nil
+}
+
+// GetAllPermissions gets every known permission
+func (r *roleRepositoryImpl) GetAllPermissions() ([]*entity.Permission, error) {
+	logger.Infof("Fetching all permissions")
+	var permissions []*entity.Permission
+	err := r.db.Order("name").Find(&permissions).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch permissions: %v", err)
+		return nil, err
+	}
+	logger.Infof("Successfully fetched %d permissions", len(permissions))
+	return permissions, nil
+}
+
+// GetPermissionsByNames gets the permissions with the given names
+func (r *roleRepositoryImpl) GetPermissionsByNames(names []string) ([]entity.Permission, error) {
+	var permissions []entity.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	err := r.db.Where("name IN ?", names).Find(&permissions).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch permissions by names: %v", err)
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// Save creates or updates a role and replaces its permissions in one transaction
+func (r *roleRepositoryImpl) Save(role *entity.Role, permissions []entity.Permission) error {
+	return r.SaveTx(r.db, role, permissions)
+}
+
+// SaveTx saves a role and its permissions using an external transaction
+func (r *roleRepositoryImpl) SaveTx(tx *gorm.DB, role *entity.Role, permissions []entity.Permission) error {
+	logger.Infof("Saving role: %s", role.Name)
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Permissions").Save(role).Error; err != nil {
+			return err
+		}
+		return tx.Model(role).Association("Permissions").Replace(permissions)
+	})
+	if err != nil {
+		logger.Errorf("Failed to save role %s: %v", role.Name, err)
+		return err
+	}
+	role.Permissions = permissions
+	logger.Infof("Successfully saved role %s with %d permissions", role.Name, len(permissions))
+	return nil
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRepository interface {
+	CreateRefreshToken(token *entity.RefreshToken) error
+	GetRefreshTokenByHash(hash string) (*entity.RefreshToken, error)
+	RotateRefreshToken(old *entity.RefreshToken, replacement *entity.RefreshToken) error
+	RevokeRefreshToken(id uint) error
+	RevokeAllSessions(userID uint) error
+	RevokeAllSessionsTx(tx *gorm.DB, userID uint) error
+	RevokeAccessToken(jti string, userID uint, expiresAt time.Time) error
+	ConsumeAccessToken(jti string, userID uint, expiresAt time.Time) (bool, error)
+	IsAccessTokenRevoked(jti string) (bool, error)
+	CreateSession(session *entity.Session) error
+	GetSession(id uint) (*entity.Session, error)
+	GetActiveSessions(userID uint) ([]*entity.Session, error)
+	TouchSession(id uint, seenAt time.Time) error
+	RevokeSession(id uint) error
+	DeleteExpired() error
+}
+
+type tokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// CreateRefreshToken stores a new refresh token
+func (r *tokenRepositoryImpl) CreateRefreshToken(token *entity.RefreshToken) error {
+	logger.Infof("Creating refresh token for user ID: %d", token.UserID)
+	err := r.db.Create(token).Error
+	if err != nil {
+		logger.Errorf("Failed to create refresh token: %v", err)
+		return err
+	}
+	logger.Infof("Successfully created refresh token with ID: %d", token.ID)
+	return nil
+}
+
+// GetRefreshTokenByHash gets a refresh token by its hash
+func (r *tokenRepositoryImpl) GetRefreshTokenByHash(hash string) (*entity.RefreshToken, error) {
+	logger.Infof("Fetching refresh token by hash")
+	var token entity.RefreshToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch refresh token by hash: %v", err)
+		return nil, err
+	}
+	logger.Infof("Successfully fetched refresh token with ID: %d", token.ID)
+	return &token, nil
+}
+
+// RotateRefreshToken revokes a refresh token and stores its replacement in one transaction.
+// The old token is only revoked if it was still active, so concurrent refreshes cannot both succeed.
+// The session of the replacement is extended to the replacement's expiry.
+func (r *tokenRepositoryImpl) RotateRefreshToken(old *entity.RefreshToken, replacement *entity.RefreshToken) error {
+	logger.Infof("Rotating refresh token ID: %d for user ID: %d", old.ID, old.UserID)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(replacement).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by": replacement.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if replacement.SessionID == nil {
+			return nil
+		}
+		return tx.Model(&entity.Session{}).
+			Where("id = ?", *replacement.SessionID).
+			Updates(map[string]interface{}{"expires_at": replacement.ExpiresAt, "last_seen_at": time.Now()}).Error
+	})
+	if err != nil {
+		logger.Errorf("Failed to rotate refresh token ID %d: %v", old.ID, err)
+		return err
+	}
+	logger.Infof("Successfully rotated refresh token ID %d to ID %d", old.ID, replacement.ID)
+	return nil
+}
+
+// RevokeRefreshToken revokes a single refresh token
+func (r *tokenRepositoryImpl) RevokeRefreshToken(id uint) error {
+	logger.Infof("Revoking refresh token ID: %d", id)
+	err := r.db.Model(&entity.RefreshToken{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now()).Error
+	if err != nil {
+		logger.Errorf("Failed to revoke refresh token ID %d: %v", id, err)
+		return err
+	}
+	logger.Infof("Successfully revoked refresh token ID: %d", id)
+	return nil
+}
+
+// RevokeAllSessions revokes every active session and refresh token of a user
+func (r *tokenRepositoryImpl) RevokeAllSessions(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.RevokeAllSessionsTx(tx, userID)
+	})
+}
+
+// RevokeAllSessionsTx revokes every active session and refresh token of a user
+// using an external transaction
+func (r *tokenRepositoryImpl) RevokeAllSessionsTx(tx *gorm.DB, userID uint) error {
+	logger.Infof("Revoking all sessions for user ID: %d", userID)
+	now := time.Now()
+	err := tx.Model(&entity.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", now).Error
+	if err == nil {
+		err = tx.Model(&entity.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", now).Error
+	}
+	if err != nil {
+		logger.Errorf("Failed to revoke sessions for user ID %d: %v", userID, err)
+		return err
+	}
+	logger.Infof("Successfully revoked all sessions for user ID: %d", userID)
+	return nil
+}
+
+// RevokeAccessToken adds an access token ID (jti) to the revocation list until it expires
+func (r *tokenRepositoryImpl) RevokeAccessToken(jti string, userID uint, expiresAt time.Time) error {
+	logger.Infof("Revoking access token %s for user ID: %d", jti, userID)
+	revoked := &entity.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(revoked).Error
+	if err != nil {
+		logger.Errorf("Failed to revoke access token %s: %v", jti, err)
+		return err
+	}
+	logger.Infof("Successfully revoked access token %s", jti)
+	return nil
+}
+
+// ConsumeAccessToken revokes a single use token such as an MFA challenge. It
+// reports false if the token was already revoked, so concurrent requests
+// cannot both use it.
+func (r *tokenRepositoryImpl) ConsumeAccessToken(jti string, userID uint, expiresAt time.Time) (bool, error) {
+	revoked := &entity.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+	result := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "jti"}}, DoNothing: true}).Create(revoked)
+	if result.Error != nil {
+		logger.Errorf("Failed to consume token %s: %v", jti, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// IsAccessTokenRevoked checks whether an access token ID (jti) has been revoked
+func (r *tokenRepositoryImpl) IsAccessTokenRevoked(jti string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	if err != nil {
+		logger.Errorf("Failed to check revocation of access token %s: %v", jti, err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateSession stores a new session
+func (r *tokenRepositoryImpl) CreateSession(session *entity.Session) error {
+	logger.Infof("Creating session for user ID: %d", session.UserID)
+	err := r.db.Create(session).Error
+	if err != nil {
+		logger.Errorf("Failed to create session: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetSession gets a session by ID
+func (r *tokenRepositoryImpl) GetSession(id uint) (*entity.Session, error) {
+	var session entity.Session
+	err := r.db.First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetActiveSessions gets the unrevoked, unexpired sessions of a user, most recently used first
+func (r *tokenRepositoryImpl) GetActiveSessions(userID uint) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		logger.Errorf("Failed to fetch sessions for user ID %d: %v", userID, err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// TouchSession records when a session was last used
+func (r *tokenRepositoryImpl) TouchSession(id uint, seenAt time.Time) error {
+	err := r.db.Model(&entity.Session{}).Where("id = ?", id).UpdateColumn("last_seen_at", seenAt).Error
+	if err != nil {
+		logger.Errorf("Failed to update last seen time of session ID %d: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// RevokeSession revokes a session together with its refresh tokens
+func (r *tokenRepositoryImpl) RevokeSession(id uint) error {
+	logger.Infof("Revoking session ID: %d", id)
+	now := time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Session{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entity.RefreshToken{}).Where("session_id = ? AND revoked_at IS NULL", id).Update("revoked_at", now).Error
+	})
+	if err != nil {
+		logger.Errorf("Failed to revoke session ID %d: %v", id, err)
+		return err
+	}
+	logger.Infof("Successfully revoked session ID: %d", id)
+	return nil
+}
+
+// DeleteExpired removes refresh tokens, sessions and revocation entries that are past their expiry
+func (r *tokenRepositoryImpl) DeleteExpired() error {
+	logger.Infof("Deleting expired tokens")
+	now := time.Now()
+	if err := r.db.Where("expires_at < ?", now).Delete(&entity.RefreshToken{}).Error; err != nil {
+		logger.Errorf("Failed to delete expired refresh tokens: %v", err)
+		return err
+	}
+	if err := r.db.Where("expires_at < ?", now).Delete(&entity.RevokedToken{}).Error; err != nil {
+		logger.Errorf("Failed to delete expired revoked tokens: %v", err)
+		return err
+	}
+	if err := r.db.Where("expires_at < ?", now).Delete(&entity.Session{}).Error; err != nil {
+		logger.Errorf("Failed to delete expired sessions: %v", err)
+		return err
+	}
+	logger.Infof("Successfully deleted expired tokens")
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"gorm.io/gorm"
+)
+
+func newTestTokenRepository(t *testing.T) (TokenRepository, *gorm.DB) {
+	db := newTestDB(t, &entity.RefreshToken{}, &entity.Session{}, &entity.RevokedToken{})
+	return NewTokenRepository(db), db
+}
+
+// createSessionWithToken stores a session of user 1 and its refresh token
+func createSessionWithToken(t *testing.T, repo TokenRepository, hash string) (*entity.Session, *entity.RefreshToken) {
+	t.Helper()
+
+	session := &entity.Session{UserID: 1, LastSeenAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := repo.CreateSession(session); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	token := &entity.RefreshToken{UserID: 1, SessionID: &session.ID, TokenHash: hash, ExpiresAt: session.ExpiresAt}
+	if err := repo.CreateRefreshToken(token); err != nil {
+		t.Fatalf("CreateRefreshToken: %v", err)
+	}
+	return session, token
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	repo, _ := newTestTokenRepository(t)
+	session, old := createSessionWithToken(t, repo, "old")
+
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	replacement := &entity.RefreshToken{UserID: 1, SessionID: &session.ID, TokenHash: "new", ExpiresAt: expiresAt}
+	if err := repo.RotateRefreshToken(old, replacement); err != nil {
+		t.Fatalf("RotateRefreshToken: %v", err)
+	}
+
+	rotated, err := repo.GetRefreshTokenByHash("old")
+	if err != nil {
+		t.Fatalf("GetRefreshTokenByHash(old): %v", err)
+	}
+	if rotated.RevokedAt == nil || rotated.ReplacedBy == nil || *rotated.ReplacedBy != replacement.ID {
+		t.Errorf("rotated token = revoked %v, replaced by %v, want revoked and replaced by %d", rotated.RevokedAt, rotated.ReplacedBy, replacement.ID)
+	}
+	stored, err := repo.GetRefreshTokenByHash("new")
+	if err != nil || stored.RevokedAt != nil {
+		t.Errorf("replacement = %+v, %v, want an active token", stored, err)
+	}
+	extended, err := repo.GetSession(session.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if !extended.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("session expires at %v, want it extended to %v", extended.ExpiresAt, expiresAt)
+	}
+}
+
+func TestRotateRefreshTokenLosingRace(t *testing.T) {
+	repo, db := newTestTokenRepository(t)
+	session, old := createSessionWithToken(t, repo, "old")
+
+	// Two refreshes read the token while it was active; the first one wins
+	first := &entity.RefreshToken{UserID: 1, SessionID: &session.ID, TokenHash: "first", ExpiresAt: session.ExpiresAt}
+	if err := repo.RotateRefreshToken(old, first); err != nil {
+		t.Fatalf("RotateRefreshToken: %v", err)
+	}
+	second := &entity.RefreshToken{UserID: 1, SessionID: &session.ID, TokenHash: "second", ExpiresAt: session.ExpiresAt}
+	if err := repo.RotateRefreshToken(old, second); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("RotateRefreshToken() of a rotated token = %v, want ErrRecordNotFound", err)
+	}
+
+	var count int64
+	db.Model(&entity.RefreshToken{}).Where("token_hash = ?", "second").Count(&count)
+	if count != 0 {
+		t.Error("the replacement of the losing rotation was stored")
+	}
+	rotated, _ := repo.GetRefreshTokenByHash("old")
+	if rotated.ReplacedBy == nil || *rotated.ReplacedBy != first.ID {
+		t.Errorf("old token replaced by %v, want %d", rotated.ReplacedBy, first.ID)
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	repo, _ := newTestTokenRepository(t)
+	kept, _ := createSessionWithToken(t, repo, "kept")
+	revoked, _ := createSessionWithToken(t, repo, "revoked")
+
+	if err := repo.RevokeSession(revoked.ID); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+	if token, _ := repo.GetRefreshTokenByHash("revoked"); token.RevokedAt == nil || token.ReplacedBy != nil {
+		t.Errorf("refresh token of a revoked session = %+v, want revoked without replacement", token)
+	}
+	active, err := repo.GetActiveSessions(1)
+	if err != nil {
+		t.Fatalf("GetActiveSessions: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != kept.ID {
+		t.Errorf("active sessions = %v, want only session %d", active, kept.ID)
+	}
+
+	if err := repo.RevokeAllSessions(1); err != nil {
+		t.Fatalf("RevokeAllSessions: %v", err)
+	}
+	if active, _ := repo.GetActiveSessions(1); len(active) != 0 {
+		t.Errorf("%d active sessions after RevokeAllSessions, want 0", len(active))
+	}
+	if token, _ := repo.GetRefreshTokenByHash("kept"); token.RevokedAt == nil {
+		t.Error("refresh token still active after RevokeAllSessions")
+	}
+}
+
+func TestConsumeAccessTokenOnlyOnce(t *testing.T) {
+	repo, _ := newTestTokenRepository(t)
+	expiresAt := time.Now().Add(time.Minute)
+
+	if consumed, err := repo.ConsumeAccessToken("challenge", 1, expiresAt); err != nil || !consumed {
+		t.Fatalf("first ConsumeAccessToken() = %v, %v, want true", consumed, err)
+	}
+	if consumed, err := repo.ConsumeAccessToken("challenge", 1, expiresAt); err != nil || consumed {
+		t.Errorf("second ConsumeAccessToken() = %v, %v, want false", consumed, err)
+	}
+	if revoked, _ := repo.IsAccessTokenRevoked("challenge"); !revoked {
+		t.Error("consumed token is not revoked")
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/nabil/book-store-system/pkg/logger"
 	"gorm.io/gorm"
 )
@@ -58,7 +60,24 @@ func (r *transactionRepositoryImpl) RollbackTransaction(tx *gorm.DB) error {
 	return nil
 }
 
-// WithTransaction executes a function within a transaction
+// afterCommitKey is the context key of the functions registered by AfterCommit
+type afterCommitKey struct{}
+
+// AfterCommit runs fn once the transaction of tx, started by WithTransaction,
+// has committed. fn does not run if the transaction rolls back. For any other
+// tx, fn runs immediately.
+func AfterCommit(tx *gorm.DB, fn func()) {
+	if tx != nil && tx.Statement != nil && tx.Statement.Context != nil {
+		if hooks, ok := tx.Statement.Context.Value(afterCommitKey{}).(*[]func()); ok {
+			*hooks = append(*hooks, fn)
+			return
+		}
+	}
+	fn()
+}
+
+// WithTransaction executes a function within a transaction. Functions
+// registered with AfterCommit run after a successful commit.
 func (r *transactionRepositoryImpl) WithTransaction(fn func(tx *gorm.DB) error) error {
 	logger.Infof("Executing function within transaction")
 
@@ -67,6 +86,9 @@ func (r *transactionRepositoryImpl) WithTransaction(fn func(tx *gorm.DB) error)
 		return err
 	}
 
+	var hooks []func()
+	tx = tx.WithContext(context.WithValue(tx.Statement.Context, afterCommitKey{}, &hooks))
+
 	defer func() {
 		if p := recover(); p != nil {
 			logger.Errorf("Panic occurred, rolling back transaction: %v", p)
@@ -84,5 +106,11 @@ func (r *transactionRepositoryImpl) WithTransaction(fn func(tx *gorm.DB) error)
 		return err
 	}
 
-	return r.CommitTransaction(tx)
+	if err := r.CommitTransaction(tx); err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		hook()
+	}
+	return nil
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/pkg/logger"
 	"gorm.io/gorm"
@@ -11,8 +13,13 @@ type UserRepository interface {
 	GetByID(id uint) (*entity.User, error)
 	GetByEmail(email string) (*entity.User, error)
 	Update(user *entity.User) error
+	UpdateTx(tx *gorm.DB, user *entity.User) error
+	IncrementTokenVersion(id uint) error
+	UpdateMFA(user *entity.User) error
+	UseTOTPStep(id uint, step int64) (bool, error)
 	Delete(id uint) error
-	GetAll(page, limit int) ([]*entity.User, int64, error)
+	DeleteTx(tx *gorm.DB, id uint) error
+	GetAll(page, limit int, search, role string) ([]*entity.User, int64, error)
 }
 
 type userRepositoryImpl struct {
@@ -54,7 +61,8 @@ func (r *userRepositoryImpl) GetByID(id uint) (*entity.User, error) {
 func (r *userRepositoryImpl) GetByEmail(email string) (*entity.User, error) {
 	logger.Infof("Fetching user by email: %s", email)
 	var user entity.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	// Emails stored before they were normalized may still contain capitals
+	err := r.db.Where("LOWER(email) = LOWER(?)", strings.TrimSpace(email)).First(&user).Error
 	if err != nil {
 		logger.Errorf("Failed to fetch user by email %s: %v", email, err)
 		return nil, err
@@ -65,8 +73,13 @@ func (r *userRepositoryImpl) GetByEmail(email string) (*entity.User, error) {
 
 // Update updates a user
 func (r *userRepositoryImpl) Update(user *entity.User) error {
+	return r.UpdateTx(r.db, user)
+}
+
+// UpdateTx updates a user using an external transaction
+func (r *userRepositoryImpl) UpdateTx(tx *gorm.DB, user *entity.User) error {
 	logger.Infof("Updating user with ID: %d", user.ID)
-	err := r.db.Save(user).Error
+	err := tx.Save(user).Error
 	if err != nil {
 		logger.Errorf("Failed to update user with ID %d: %v", user.ID, err)
 		return err
@@ -75,10 +88,56 @@ func (r *userRepositoryImpl) Update(user *entity.User) error {
 	return nil
 }
 
+// IncrementTokenVersion invalidates every access token of a user issued so far.
+// Only the column is updated, so a stale copy of the user cannot undo other changes.
+func (r *userRepositoryImpl) IncrementTokenVersion(id uint) error {
+	logger.Infof("Incrementing token version of user ID: %d", id)
+	result := r.db.Model(&entity.User{}).Where("id = ?", id).UpdateColumn("token_version", gorm.Expr("token_version + 1"))
+	if result.Error != nil {
+		logger.Errorf("Failed to increment token version of user ID %d: %v", id, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateMFA stores the MFA secret, enablement and last used TOTP step of a
+// user without touching the rest of the row
+func (r *userRepositoryImpl) UpdateMFA(user *entity.User) error {
+	logger.Infof("Updating MFA settings of user ID: %d", user.ID)
+	err := r.db.Model(user).Select("mfa_secret", "mfa_enabled_at", "mfa_last_used_step").Updates(user).Error
+	if err != nil {
+		logger.Errorf("Failed to update MFA settings of user ID %d: %v", user.ID, err)
+		return err
+	}
+	return nil
+}
+
+// UseTOTPStep records step as the last used TOTP step of a user. It reports
+// false if the same or a later step was already used, so each code is
+// accepted once even when it is presented concurrently.
+func (r *userRepositoryImpl) UseTOTPStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&entity.User{}).
+		Where("id = ? AND mfa_last_used_step < ?", id, step).
+		UpdateColumn("mfa_last_used_step", step)
+	if result.Error != nil {
+		logger.Errorf("Failed to store last used TOTP step of user ID %d: %v", id, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // Delete deletes a user
 func (r *userRepositoryImpl) Delete(id uint) error {
+	return r.DeleteTx(r.db, id)
+}
+
+// DeleteTx deletes a user using an external transaction
+func (r *userRepositoryImpl) DeleteTx(tx *gorm.DB, id uint) error {
 	logger.Infof("Deleting user with ID: %d", id)
-	err := r.db.Delete(&entity.User{}, id).Error
+	err := tx.Delete(&entity.User{}, id).Error
 	if err != nil {
 		logger.Errorf("Failed to delete user with ID %d: %v", id, err)
 		return err
@@ -87,14 +146,27 @@ func (r *userRepositoryImpl) Delete(id uint) error {
 	return nil
 }
 
-// GetAll gets all users with pagination
-func (r *userRepositoryImpl) GetAll(page, limit int) ([]*entity.User, int64, error) {
-	logger.Infof("Fetching all users with pagination - page: %d, limit: %d", page, limit)
+// GetAll gets all users with pagination, optionally filtered by name/email and role
+func (r *userRepositoryImpl) GetAll(page, limit int, search, role string) ([]*entity.User, int64, error) {
+	logger.Infof("Fetching all users with pagination - page: %d, limit: %d, search: %s, role: %s", page, limit, search, role)
 	var users []*entity.User
 	var total int64
 
+	query := r.db.Model(&entity.User{})
+
+	// Apply search filter if provided
+	if search != "" {
+		pattern := "%" + escapeLike(search) + "%"
+		query = query.Where("name ILIKE ? OR email ILIKE ?", pattern, pattern)
+	}
+
+	// Apply role filter if provided
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+
 	// Count total records
-	err := r.db.Model(&entity.User{}).Count(&total).Error
+	err := query.Count(&total).Error
 	if err != nil {
 		logger.Errorf("Failed to count users: %v", err)
 		return nil, 0, err
@@ -102,7 +174,7 @@ func (r *userRepositoryImpl) GetAll(page, limit int) ([]*entity.User, int64, err
 
 	// Get paginated records
 	offset := (page - 1) * limit
-	err = r.db.Offset(offset).Limit(limit).Find(&users).Error
+	err = query.Order("id").Offset(offset).Limit(limit).Find(&users).Error
 	if err != nil {
 		logger.Errorf("Failed to fetch users with pagination: %v", err)
 		return nil, 0, err
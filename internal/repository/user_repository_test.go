@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/nabil/book-store-system/internal/entity"
+)
+
+func TestUseTOTPStepOnlyForward(t *testing.T) {
+	db := newTestDB(t, &entity.User{})
+	repo := NewUserRepository(db)
+	user := &entity.User{Name: "Reader", Email: "reader@example.com", Password: "x", Role: entity.RoleUser}
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	steps := []struct {
+		step int64
+		want bool
+	}{
+		{100, true},
+		{100, false},
+		{99, false},
+		{101, true},
+	}
+	for _, s := range steps {
+		used, err := repo.UseTOTPStep(user.ID, s.step)
+		if err != nil {
+			t.Fatalf("UseTOTPStep(%d): %v", s.step, err)
+		}
+		if used != s.want {
+			t.Errorf("UseTOTPStep(%d) = %v, want %v", s.step, used, s.want)
+		}
+	}
+}
+
+func TestUpdateMFAOnlyTouchesMFAColumns(t *testing.T) {
+	db := newTestDB(t, &entity.User{})
+	repo := NewUserRepository(db)
+	user := &entity.User{Name: "Reader", Email: "reader@example.com", Password: "x", Role: entity.RoleUser}
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := db.Model(&entity.User{}).Where("id = ?", user.ID).Update("name", "Renamed").Error; err != nil {
+		t.Fatalf("rename user: %v", err)
+	}
+
+	user.MFASecret = "SECRET"
+	if err := repo.UpdateMFA(user); err != nil {
+		t.Fatalf("UpdateMFA: %v", err)
+	}
+	stored, err := repo.GetByID(user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if stored.Name != "Renamed" || stored.MFASecret != "SECRET" {
+		t.Errorf("user after UpdateMFA = name %q, secret %q, want Renamed and SECRET", stored.Name, stored.MFASecret)
+	}
+}
+
+func TestUserGetAllEscapesSearch(t *testing.T) {
+	db, recorder := newRecordingDB(t)
+	repo := NewUserRepository(db)
+
+	if _, _, err := repo.GetAll(1, 10, hostileSearch, ""); err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	assertParameterized(t, recorder.statements, hostileSearch)
+	if want := "%" + escapeLike(hostileSearch) + "%"; !hasArg(recorder.statements, want) {
+		t.Errorf("pattern %q is not bound as a parameter", want)
+	}
+}
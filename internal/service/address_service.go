@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ErrAddressNotFound is returned for addresses that do not exist or belong to another user
+var ErrAddressNotFound = errors.New("address not found")
+
+// maxAddressesPerUser limits the size of a user's address book
+const maxAddressesPerUser = 20
+
+// AddressService manages the address book of the authenticated user
+type AddressService interface {
+	CreateAddress(ctx context.Context, fields entity.AddressFields, isDefault bool) (*entity.Address, error)
+	ListAddresses(ctx context.Context) ([]*entity.Address, error)
+	GetAddress(ctx context.Context, id uint) (*entity.Address, error)
+	UpdateAddress(ctx context.Context, id uint, fields entity.AddressFields, isDefault bool) (*entity.Address, error)
+	DeleteAddress(ctx context.Context, id uint) error
+}
+
+type addressServiceImpl struct {
+	addressRepo repository.AddressRepository
+}
+
+func NewAddressService(addressRepo repository.AddressRepository) AddressService {
+	return &addressServiceImpl{
+		addressRepo: addressRepo,
+	}
+}
+
+// CreateAddress adds an address to the caller's address book. The first
+// address always becomes the default one.
+func (s *addressServiceImpl) CreateAddress(ctx context.Context, fields entity.AddressFields, isDefault bool) (*entity.Address, error) {
+	logger.Info("Starting address creation")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Address creation failed - unauthenticated", "error", err)
+		return nil, err
+	}
+
+	count, err := s.addressRepo.CountByUserID(user.ID)
+	if err != nil {
+		logger.Error("Failed to count addresses", "userID", user.ID, "error", err)
+		return nil, err
+	}
+	if count >= maxAddressesPerUser {
+		logger.Error("Address creation failed - address book is full", "userID", user.ID, "count", count)
+		return nil, fmt.Errorf("an address book can hold at most %d addresses", maxAddressesPerUser)
+	}
+
+	address := &entity.Address{
+		UserID:        user.ID,
+		AddressFields: fields,
+	}
+	if err := s.addressRepo.Create(address); err != nil {
+		logger.Error("Failed to create address", "userID", user.ID, "error", err)
+		return nil, err
+	}
+
+	if isDefault || count == 0 {
+		if err := s.addressRepo.SetDefault(user.ID, address.ID); err != nil {
+			logger.Error("Failed to set default address", "userID", user.ID, "addressID", address.ID, "error", err)
+			return nil, err
+		}
+		address.IsDefault = true
+	}
+
+	logger.Info("Address created successfully", "userID", user.ID, "addressID", address.ID, "isDefault", address.IsDefault)
+	return address, nil
+}
+
+// ListAddresses retrieves the caller's addresses, the default one first
+func (s *addressServiceImpl) ListAddresses(ctx context.Context) ([]*entity.Address, error) {
+	logger.Info("Listing addresses")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to list addresses - unauthenticated", "error", err)
+		return nil, err
+	}
+
+	addresses, err := s.addressRepo.GetByUserID(user.ID)
+	if err != nil {
+		logger.Error("Failed to list addresses", "userID", user.ID, "error", err)
+		return nil, err
+	}
+
+	logger.Info("Addresses listed successfully", "userID", user.ID, "count", len(addresses))
+	return addresses, nil
+}
+
+// GetAddress retrieves one of the caller's addresses
+func (s *addressServiceImpl) GetAddress(ctx context.Context, id uint) (*entity.Address, error) {
+	logger.Info("Getting address by ID", "addressID", id)
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to get address - unauthenticated", "addressID", id, "error", err)
+		return nil, err
+	}
+
+	return s.ownAddress(user, id)
+}
+
+// UpdateAddress replaces the fields of one of the caller's addresses. isDefault
+// makes it the default address; the default address stays the default until
+// another address is chosen.
+func (s *addressServiceImpl) UpdateAddress(ctx context.Context, id uint, fields entity.AddressFields, isDefault bool) (*entity.Address, error) {
+	logger.Info("Starting address update", "addressID", id)
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Address update failed - unauthenticated", "addressID", id, "error", err)
+		return nil, err
+	}
+
+	address, err := s.ownAddress(user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	address.AddressFields = fields
+	if err := s.addressRepo.Update(address); err != nil {
+		logger.Error("Failed to update address", "userID", user.ID, "addressID", id, "error", err)
+		return nil, err
+	}
+
+	if isDefault && !address.IsDefault {
+		if err := s.addressRepo.SetDefault(user.ID, address.ID); err != nil {
+			logger.Error("Failed to set default address", "userID", user.ID, "addressID", id, "error", err)
+			return nil, err
+		}
+		address.IsDefault = true
+	}
+
+	logger.Info("Address updated successfully", "userID", user.ID, "addressID", id)
+	return address, nil
+}
+
+// DeleteAddress removes one of the caller's addresses. When the default address
+// is removed, the oldest remaining address becomes the default.
+func (s *addressServiceImpl) DeleteAddress(ctx context.Context, id uint) error {
+	logger.Info("Starting address deletion", "addressID", id)
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Address deletion failed - unauthenticated", "addressID", id, "error", err)
+		return err
+	}
+
+	address, err := s.ownAddress(user, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.addressRepo.Delete(address.ID); err != nil {
+		logger.Error("Failed to delete address", "userID", user.ID, "addressID", id, "error", err)
+		return err
+	}
+
+	if address.IsDefault {
+		remaining, err := s.addressRepo.GetByUserID(user.ID)
+		if err != nil {
+			logger.Error("Failed to get remaining addresses", "userID", user.ID, "error", err)
+			return err
+		}
+		if len(remaining) > 0 {
+			if err := s.addressRepo.SetDefault(user.ID, remaining[0].ID); err != nil {
+				logger.Error("Failed to promote default address", "userID", user.ID, "addressID", remaining[0].ID, "error", err)
+				return err
+			}
+		}
+	}
+
+	logger.Info("Address deleted successfully", "userID", user.ID, "addressID", id)
+	return nil
+}
+
+// ownAddress loads an address of user. Addresses of other users are reported
+// as missing so their IDs cannot be probed.
+func (s *addressServiceImpl) ownAddress(user *entity.User, id uint) (*entity.Address, error) {
+	address, err := s.addressRepo.GetByID(id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("Failed to get address", "userID", user.ID, "addressID", id, "error", err)
+		return nil, err
+	}
+	if err != nil || address.UserID != user.ID {
+		logger.Error("Address not found", "userID", user.ID, "addressID", id)
+		return nil, ErrAddressNotFound
+	}
+	return address, nil
+}
@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// AdminUserService exposes user account and role management to staff
+type AdminUserService interface {
+	ListUsers(ctx context.Context, page, limit int, search, role string) ([]*entity.User, int64, error)
+	GetUser(ctx context.Context, id uint) (*entity.User, error)
+	SetUserRole(ctx context.Context, id uint, role string) (*entity.User, error)
+	DisableUser(ctx context.Context, id uint) (*entity.User, error)
+	EnableUser(ctx context.Context, id uint) (*entity.User, error)
+	DeleteUser(ctx context.Context, id uint) error
+	UnlockAccount(ctx context.Context, id uint) error
+	ListRoles(ctx context.Context) ([]*entity.Role, error)
+	ListPermissions(ctx context.Context) ([]*entity.Permission, error)
+	UpsertRole(ctx context.Context, name, description string, permissions []string) (*entity.Role, error)
+	ImpersonateUser(ctx context.Context, id uint) (*AuthTokens, *entity.User, error)
+}
+
+type adminUserServiceImpl struct {
+	userRepo         repository.UserRepository
+	tokenRepo        repository.TokenRepository
+	roleRepo         repository.RoleRepository
+	attemptRepo      repository.LoginAttemptRepository
+	txRepo           repository.TransactionRepository
+	auditRepo        repository.AuditRepository
+	impersonationCfg config.ImpersonationConfig
+}
+
+func NewAdminUserService(userRepo repository.UserRepository, tokenRepo repository.TokenRepository, roleRepo repository.RoleRepository, attemptRepo repository.LoginAttemptRepository, txRepo repository.TransactionRepository, auditRepo repository.AuditRepository, impersonationCfg config.ImpersonationConfig) AdminUserService {
+	return &adminUserServiceImpl{
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		roleRepo:         roleRepo,
+		attemptRepo:      attemptRepo,
+		txRepo:           txRepo,
+		auditRepo:        auditRepo,
+		impersonationCfg: impersonationCfg,
+	}
+}
+
+// updateUserAudited saves user and its audit event in one transaction
+func (s *adminUserServiceImpl) updateUserAudited(ctx context.Context, action string, before, user *entity.User) error {
+	return s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.userRepo.UpdateTx(tx, user); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, action, auditEntityUser, user.ID, before, user)
+	})
+}
+
+// ListUsers retrieves users with pagination, optionally filtered by name/email and role (requires users:read)
+func (s *adminUserServiceImpl) ListUsers(ctx context.Context, page, limit int, search, role string) ([]*entity.User, int64, error) {
+	logger.Info("Listing users", "page", page, "limit", limit, "search", search, "role", role)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersRead); err != nil {
+		logger.Error("Listing users denied", "error", err)
+		return nil, 0, err
+	}
+
+	users, total, err := s.userRepo.GetAll(page, limit, search, role)
+	if err != nil {
+		logger.Error("Failed to list users", "page", page, "limit", limit, "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Users listed successfully", "count", len(users), "total", total)
+	return users, total, nil
+}
+
+// GetUser retrieves a user by ID (requires users:read)
+func (s *adminUserServiceImpl) GetUser(ctx context.Context, id uint) (*entity.User, error) {
+	logger.Info("Getting user by ID", "userID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersRead); err != nil {
+		logger.Error("Getting user denied", "userID", id, "error", err)
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user", "userID", id, "error", err)
+		return nil, err
+	}
+
+	logger.Info("User retrieved successfully", "userID", id)
+	return user, nil
+}
+
+// SetUserRole assigns one of the roles stored in the database to a user (requires users:write).
+// The caller must hold every permission of both the user's current role and the
+// new one. Tokens issued with the previous role are revoked.
+func (s *adminUserServiceImpl) SetUserRole(ctx context.Context, id uint, role string) (*entity.User, error) {
+	logger.Info("Setting user role", "userID", id, "role", role)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersWrite); err != nil {
+		logger.Error("Setting user role denied", "userID", id, "error", err)
+		return nil, err
+	}
+
+	admin, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to set user role - unauthenticated", "error", err)
+		return nil, err
+	}
+
+	if admin.ID == id {
+		logger.Error("Failed to set user role - admins cannot change their own role", "userID", id)
+		return nil, errors.New("cannot change your own role")
+	}
+
+	newRole, err := s.roleRepo.GetByName(role)
+	if err != nil {
+		logger.Error("Failed to set user role - unknown role", "userID", id, "role", role, "error", err)
+		return nil, errors.New("role not found")
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user for role change", "userID", id, "error", err)
+		return nil, err
+	}
+
+	if user.Role == role {
+		logger.Info("User already has the requested role", "userID", id, "role", role)
+		return user, nil
+	}
+
+	// Staff may only hand out, or take away, permissions they hold themselves
+	if err := requireHeldPermissions(ctx, newRole.Permissions); err != nil {
+		logger.Error("Failed to set user role - role grants more than the caller holds", "userID", id, "role", role, "adminID", admin.ID, "error", err)
+		return nil, err
+	}
+	if err := requireHeldPermissionsOf(ctx, s.roleRepo, user); err != nil {
+		logger.Error("Failed to set user role - user holds more than the caller", "userID", id, "role", user.Role, "adminID", admin.ID, "error", err)
+		return nil, err
+	}
+
+	before := *user
+	user.Role = role
+	user.TokenVersion++
+	if err := s.updateUserAudited(ctx, "user.set_role", &before, user); err != nil {
+		logger.Error("Failed to update user role", "userID", id, "error", err)
+		return nil, err
+	}
+
+	if err := s.tokenRepo.RevokeAllSessions(user.ID); err != nil {
+		logger.Error("Failed to revoke sessions after role change", "userID", id, "error", err)
+		return nil, err
+	}
+
+	logger.Info("User role updated successfully", "userID", id, "role", role, "adminID", admin.ID)
+	return user, nil
+}
+
+// DisableUser blocks a user from logging in and revokes all of their tokens
+// (requires users:write and every permission of the user's role)
+func (s *adminUserServiceImpl) DisableUser(ctx context.Context, id uint) (*entity.User, error) {
+	logger.Info("Disabling user", "userID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersWrite); err != nil {
+		logger.Error("Disabling user denied", "userID", id, "error", err)
+		return nil, err
+	}
+
+	admin, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to disable user - unauthenticated", "error", err)
+		return nil, err
+	}
+
+	if admin.ID == id {
+		logger.Error("Failed to disable user - admins cannot disable themselves", "userID", id)
+		return nil, errors.New("cannot disable your own account")
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user for disabling", "userID", id, "error", err)
+		return nil, err
+	}
+
+	if err := requireHeldPermissionsOf(ctx, s.roleRepo, user); err != nil {
+		logger.Error("Failed to disable user - user holds more than the caller", "userID", id, "role", user.Role, "adminID", admin.ID, "error", err)
+		return nil, err
+	}
+
+	if user.IsDisabled() {
+		logger.Info("User is already disabled", "userID", id)
+		return user, nil
+	}
+
+	before := *user
+	now := time.Now()
+	user.DisabledAt = &now
+	user.TokenVersion++
+	if err := s.updateUserAudited(ctx, "user.disable", &before, user); err != nil {
+		logger.Error("Failed to disable user", "userID", id, "error", err)
+		return nil, err
+	}
+
+	if err := s.tokenRepo.RevokeAllSessions(user.ID); err != nil {
+		logger.Error("Failed to revoke sessions of disabled user", "userID", id, "error", err)
+		return nil, err
+	}
+
+	logger.Info("User disabled successfully", "userID", id, "adminID", admin.ID)
+	return user, nil
+}
+
+// EnableUser lifts a previous DisableUser (requires users:write)
+func (s *adminUserServiceImpl) EnableUser(ctx context.Context, id uint) (*entity.User, error) {
+	logger.Info("Enabling user", "userID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersWrite); err != nil {
+		logger.Error("Enabling user denied", "userID", id, "error", err)
+		return nil, err
+	}
+
+	admin, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to enable user - unauthenticated", "error", err)
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user for enabling", "userID", id, "error", err)
+		return nil, err
+	}
+
+	if !user.IsDisabled() {
+		logger.Info("User is not disabled", "userID", id)
+		return user, nil
+	}
+
+	if user.IsAnonymized() {
+		logger.Error("Failed to enable user - account was deleted", "userID", id)
+		return nil, errors.New("deleted accounts cannot be enabled")
+	}
+
+	before := *user
+	user.DisabledAt = nil
+	if err := s.updateUserAudited(ctx, "user.enable", &before, user); err != nil {
+		logger.Error("Failed to enable user", "userID", id, "error", err)
+		return nil, err
+	}
+
+	logger.Info("User enabled successfully", "userID", id, "adminID", admin.ID)
+	return user, nil
+}
+
+// DeleteUser soft deletes a user and revokes all of their tokens (requires
+// users:write and every permission of the user's role)
+func (s *adminUserServiceImpl) DeleteUser(ctx context.Context, id uint) error {
+	logger.Info("Deleting user", "userID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersWrite); err != nil {
+		logger.Error("Deleting user denied", "userID", id, "error", err)
+		return err
+	}
+
+	admin, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to delete user - unauthenticated", "error", err)
+		return err
+	}
+
+	if admin.ID == id {
+		logger.Error("Failed to delete user - admins cannot delete themselves", "userID", id)
+		return errors.New("cannot delete your own account")
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user for deletion", "userID", id, "error", err)
+		return err
+	}
+
+	if err := requireHeldPermissionsOf(ctx, s.roleRepo, user); err != nil {
+		logger.Error("Failed to delete user - user holds more than the caller", "userID", id, "role", user.Role, "adminID", admin.ID, "error", err)
+		return err
+	}
+
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.userRepo.DeleteTx(tx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "user.delete", auditEntityUser, id, user, nil)
+	})
+	if err != nil {
+		logger.Error("Failed to delete user", "userID", id, "error", err)
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllSessions(id); err != nil {
+		logger.Error("Failed to revoke sessions of deleted user", "userID", id, "error", err)
+		return err
+	}
+
+	logger.Info("User deleted successfully", "userID", id, "adminID", admin.ID)
+	return nil
+}
+
+// UnlockAccount clears the failed login counter and lockout of a user's email
+// (requires users:write and every permission of the user's role)
+func (s *adminUserServiceImpl) UnlockAccount(ctx context.Context, id uint) error {
+	logger.Info("Unlocking account", "userID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersWrite); err != nil {
+		logger.Error("Unlocking account denied", "userID", id, "error", err)
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user for unlocking", "userID", id, "error", err)
+		return err
+	}
+
+	if err := requireHeldPermissionsOf(ctx, s.roleRepo, user); err != nil {
+		logger.Error("Failed to unlock account - user holds more than the caller", "userID", id, "role", user.Role, "error", err)
+		return err
+	}
+
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.attemptRepo.ResetTx(tx, emailAttemptKey(user.Email)); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "user.unlock", auditEntityUser, id, nil, nil)
+	})
+	if err != nil {
+		logger.Error("Failed to unlock account", "userID", id, "error", err)
+		return err
+	}
+
+	logger.Info("Account unlocked successfully", "userID", id, "email", user.Email)
+	return nil
+}
+
+// ListRoles retrieves every role with its permissions (requires users:read)
+func (s *adminUserServiceImpl) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	logger.Info("Listing roles")
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersRead); err != nil {
+		logger.Error("Listing roles denied", "error", err)
+		return nil, err
+	}
+
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		logger.Error("Failed to list roles", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Roles listed successfully", "count", len(roles))
+	return roles, nil
+}
+
+// ListPermissions retrieves every permission that can be granted to a role (requires users:read)
+func (s *adminUserServiceImpl) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	logger.Info("Listing permissions")
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersRead); err != nil {
+		logger.Error("Listing permissions denied", "error", err)
+		return nil, err
+	}
+
+	permissions, err := s.roleRepo.GetAllPermissions()
+	if err != nil {
+		logger.Error("Failed to list permissions", "error", err)
+		return nil, err
+	}
+
+	logger.Info("Permissions listed successfully", "count", len(permissions))
+	return permissions, nil
+}
+
+// UpsertRole creates a role or replaces the description and permissions of an
+// existing one (requires roles:manage). The super_admin and user roles cannot
+// be edited, and the caller can only grant, or take away, permissions they hold
+// themselves.
+func (s *adminUserServiceImpl) UpsertRole(ctx context.Context, name, description string, permissions []string) (*entity.Role, error) {
+	logger.Info("Saving role", "name", name, "permissions", permissions)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionRolesManage); err != nil {
+		logger.Error("Saving role denied", "name", name, "error", err)
+		return nil, err
+	}
+
+	if name == entity.RoleSuperAdmin {
+		logger.Error("Failed to save role - super_admin is read-only", "name", name)
+		return nil, errors.New("the super_admin role cannot be modified")
+	}
+	// Every customer has the user role, so editing it would change them all
+	if name == entity.RoleUser {
+		logger.Error("Failed to save role - user is read-only", "name", name)
+		return nil, errors.New("the user role cannot be modified")
+	}
+
+	permissions = uniqueScopes(permissions)
+	granted, err := s.roleRepo.GetPermissionsByNames(permissions)
+	if err != nil {
+		logger.Error("Failed to get permissions for role", "name", name, "error", err)
+		return nil, err
+	}
+	if len(granted) != len(permissions) {
+		logger.Error("Failed to save role - unknown permission", "name", name, "permissions", permissions)
+		return nil, errors.New("unknown permission")
+	}
+	if err := requireHeldPermissions(ctx, granted); err != nil {
+		logger.Error("Failed to save role - permission not held by caller", "name", name, "error", err)
+		return nil, err
+	}
+
+	role, err := s.roleRepo.GetByName(name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Error("Failed to check existing role", "name", name, "error", err)
+		return nil, err
+	}
+	var before map[string]interface{}
+	if role == nil {
+		role = &entity.Role{Name: name}
+	} else {
+		if err := requireHeldPermissions(ctx, role.Permissions); err != nil {
+			logger.Error("Failed to save role - role holds more than the caller", "name", name, "error", err)
+			return nil, err
+		}
+		before = roleAuditSnapshot(role, role.Permissions)
+	}
+	role.Description = description
+
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.roleRepo.SaveTx(tx, role, granted); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "role.upsert", auditEntityRole, role.ID, before, roleAuditSnapshot(role, granted))
+	})
+	if err != nil {
+		logger.Error("Failed to save role", "name", name, "error", err)
+		return nil, err
+	}
+
+	logger.Info("Role saved successfully", "name", name, "roleID", role.ID)
+	return role, nil
+}
+
+// requireHeldPermissions returns an error wrapping middleware.ErrPermissionDenied
+// unless the caller holds every one of permissions
+func requireHeldPermissions(ctx context.Context, permissions []entity.Permission) error {
+	for _, permission := range permissions {
+		if !middleware.HasPermission(ctx, permission.Name) {
+			return fmt.Errorf("%w: cannot grant a permission you do not have: %s", middleware.ErrPermissionDenied, permission.Name)
+		}
+	}
+	return nil
+}
+
+// requireHeldPermissionsOf returns an error wrapping middleware.ErrPermissionDenied
+// unless the caller holds every permission of user's role, so that staff cannot
+// act on accounts more privileged than their own
+func requireHeldPermissionsOf(ctx context.Context, roleRepo repository.RoleRepository, user *entity.User) error {
+	permissions, err := roleRepo.GetPermissionNames(user.Role)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if !middleware.HasPermission(ctx, permission) {
+			return fmt.Errorf("%w: the user has a permission you do not have: %s", middleware.ErrPermissionDenied, permission)
+		}
+	}
+	return nil
+}
+
+// roleAuditSnapshot describes a role by its permission names for the audit log
+func roleAuditSnapshot(role *entity.Role, permissions []entity.Permission) map[string]interface{} {
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, permission.Name)
+	}
+	sort.Strings(names)
+
+	return map[string]interface{}{
+		"name":        role.Name,
+		"description": role.Description,
+		"permissions": names,
+	}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+func newTestAdminUserService(repos *testRepos) AdminUserService {
+	return NewAdminUserService(repos.users, repos.tokens, repos.roles, repos.attempts, repos.tx, repos.audit, config.ImpersonationConfig{})
+}
+
+func TestAdminCannotManageMorePrivilegedUsers(t *testing.T) {
+	operations := []struct {
+		name string
+		run  func(context.Context, AdminUserService, uint) error
+	}{
+		{"SetUserRole", func(ctx context.Context, s AdminUserService, id uint) error {
+			_, err := s.SetUserRole(ctx, id, "viewer")
+			return err
+		}},
+		{"DisableUser", func(ctx context.Context, s AdminUserService, id uint) error {
+			_, err := s.DisableUser(ctx, id)
+			return err
+		}},
+		{"DeleteUser", func(ctx context.Context, s AdminUserService, id uint) error {
+			return s.DeleteUser(ctx, id)
+		}},
+		{"UnlockAccount", func(ctx context.Context, s AdminUserService, id uint) error {
+			return s.UnlockAccount(ctx, id)
+		}},
+	}
+
+	for _, op := range operations {
+		t.Run(op.name, func(t *testing.T) {
+			repos := newTestRepos(t)
+			service := newTestAdminUserService(repos)
+			// A custom role that may manage accounts but holds nothing else
+			repos.createRole(t, "support", entity.PermissionUsersRead, entity.PermissionUsersWrite)
+			repos.createRole(t, "viewer", entity.PermissionUsersRead)
+			ctx := repos.contextFor(t, repos.createUser(t, "support", "support"))
+
+			superAdmin := repos.createUser(t, "root", entity.RoleSuperAdmin)
+			if err := op.run(ctx, service, superAdmin.ID); !errors.Is(err, middleware.ErrPermissionDenied) {
+				t.Errorf("%s on a super_admin = %v, want ErrPermissionDenied", op.name, err)
+			}
+			after := repos.reload(t, superAdmin)
+			if after.Role != entity.RoleSuperAdmin || after.IsDisabled() || after.DeletedAt.Valid {
+				t.Errorf("super_admin after a denied %s = role %s, disabled %v, deleted %v", op.name, after.Role, after.IsDisabled(), after.DeletedAt.Valid)
+			}
+
+			// The same caller may manage customers
+			customer := repos.createUser(t, "customer", entity.RoleUser)
+			if err := op.run(ctx, service, customer.ID); err != nil {
+				t.Errorf("%s on a customer: %v", op.name, err)
+			}
+		})
+	}
+}
+
+func TestSetUserRolePermissionRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		caller     []string
+		callerRole string
+		from       string
+		to         string
+		allowed    bool
+	}{
+		{
+			name:    "grants a role with a permission the caller lacks",
+			caller:  []string{entity.PermissionUsersWrite},
+			from:    entity.RoleUser,
+			to:      entity.RoleInventoryManager,
+			allowed: false,
+		},
+		{
+			name:    "grants a role the caller fully holds",
+			caller:  []string{entity.PermissionUsersWrite, entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+			from:    entity.RoleUser,
+			to:      entity.RoleInventoryManager,
+			allowed: true,
+		},
+		{
+			name:    "removes permissions the caller holds",
+			caller:  []string{entity.PermissionUsersWrite, entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+			from:    entity.RoleInventoryManager,
+			to:      entity.RoleUser,
+			allowed: true,
+		},
+		{
+			name:    "removes permissions the caller lacks",
+			caller:  []string{entity.PermissionUsersWrite, entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+			from:    entity.RoleOrderOperator,
+			to:      entity.RoleUser,
+			allowed: false,
+		},
+		{
+			name:    "demotes a super_admin",
+			caller:  []string{entity.PermissionUsersWrite, entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+			from:    entity.RoleSuperAdmin,
+			to:      entity.RoleInventoryManager,
+			allowed: false,
+		},
+		{
+			name:       "super_admin promotes to super_admin",
+			callerRole: entity.RoleSuperAdmin,
+			from:       entity.RoleUser,
+			to:         entity.RoleSuperAdmin,
+			allowed:    true,
+		},
+		{
+			name:    "without users:write",
+			caller:  []string{entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+			from:    entity.RoleUser,
+			to:      entity.RoleInventoryManager,
+			allowed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos(t)
+			service := newTestAdminUserService(repos)
+
+			callerRole := tt.callerRole
+			if callerRole == "" {
+				callerRole = "caller"
+				repos.createRole(t, callerRole, tt.caller...)
+			}
+			ctx := repos.contextFor(t, repos.createUser(t, "caller", callerRole))
+			target := repos.createUser(t, "target", tt.from)
+
+			_, err := service.SetUserRole(ctx, target.ID, tt.to)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("SetUserRole: %v", err)
+				}
+				if after := repos.reload(t, target); after.Role != tt.to || after.TokenVersion != target.TokenVersion+1 {
+					t.Errorf("user after SetUserRole = role %s, token version %d, want %s, %d", after.Role, after.TokenVersion, tt.to, target.TokenVersion+1)
+				}
+				return
+			}
+			if !errors.Is(err, middleware.ErrPermissionDenied) {
+				t.Fatalf("SetUserRole() = %v, want ErrPermissionDenied", err)
+			}
+			if after := repos.reload(t, target); after.Role != tt.from {
+				t.Errorf("role after a denied SetUserRole = %s, want %s", after.Role, tt.from)
+			}
+		})
+	}
+}
+
+func TestSetUserRoleRejectsOwnRoleAndUnknownRoles(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestAdminUserService(repos)
+	admin := repos.createUser(t, "root", entity.RoleSuperAdmin)
+	ctx := repos.contextFor(t, admin)
+
+	if _, err := service.SetUserRole(ctx, admin.ID, entity.RoleUser); err == nil {
+		t.Error("SetUserRole changed the caller's own role")
+	}
+	customer := repos.createUser(t, "customer", entity.RoleUser)
+	if _, err := service.SetUserRole(ctx, customer.ID, "no_such_role"); err == nil {
+		t.Error("SetUserRole assigned an unknown role")
+	}
+}
+
+func TestUpsertRolePermissionRules(t *testing.T) {
+	caller := []string{entity.PermissionRolesManage, entity.PermissionBooksWrite, entity.PermissionCategoriesWrite}
+
+	tests := []struct {
+		name        string
+		role        string
+		permissions []string
+		allowed     bool
+		// denied is whether a refusal must wrap ErrPermissionDenied
+		denied bool
+		want   []string
+	}{
+		{
+			name:        "creates a role with held permissions",
+			role:        "catalog",
+			permissions: []string{entity.PermissionBooksWrite},
+			allowed:     true,
+			want:        []string{entity.PermissionBooksWrite},
+		},
+		{
+			name:        "duplicate permissions are stored once",
+			role:        "catalog",
+			permissions: []string{entity.PermissionBooksWrite, entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+			allowed:     true,
+			want:        []string{entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+		},
+		{
+			name:        "grants a permission the caller lacks",
+			role:        "catalog",
+			permissions: []string{entity.PermissionBooksWrite, entity.PermissionUsersWrite},
+			denied:      true,
+		},
+		{
+			name:        "grants a permission the caller lacks twice",
+			role:        "catalog",
+			permissions: []string{entity.PermissionUsersWrite, entity.PermissionUsersWrite},
+			denied:      true,
+		},
+		{
+			name:        "edits a role the caller fully holds",
+			role:        entity.RoleInventoryManager,
+			permissions: []string{entity.PermissionBooksWrite},
+			allowed:     true,
+			want:        []string{entity.PermissionBooksWrite},
+		},
+		{
+			name:        "removes permissions the caller lacks from a role",
+			role:        entity.RoleOrderOperator,
+			permissions: []string{},
+			denied:      true,
+		},
+		{
+			name:        "edits super_admin",
+			role:        entity.RoleSuperAdmin,
+			permissions: []string{entity.PermissionBooksWrite},
+		},
+		{
+			name:        "edits the user role",
+			role:        entity.RoleUser,
+			permissions: []string{entity.PermissionBooksWrite},
+		},
+		{
+			name:        "unknown permission",
+			role:        "catalog",
+			permissions: []string{"books:burn"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos(t)
+			service := newTestAdminUserService(repos)
+			repos.createRole(t, "role_admin", caller...)
+			ctx := repos.contextFor(t, repos.createUser(t, "caller", "role_admin"))
+
+			before, _ := repos.roles.GetPermissionNames(tt.role)
+			_, err := service.UpsertRole(ctx, tt.role, "edited", tt.permissions)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("UpsertRole: %v", err)
+				}
+				got, err := repos.roles.GetPermissionNames(tt.role)
+				if err != nil {
+					t.Fatalf("GetPermissionNames: %v", err)
+				}
+				if !sameStrings(got, tt.want) {
+					t.Errorf("permissions of %s = %v, want %v", tt.role, got, tt.want)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("UpsertRole succeeded, want an error")
+			}
+			if tt.denied && !errors.Is(err, middleware.ErrPermissionDenied) {
+				t.Errorf("UpsertRole() = %v, want ErrPermissionDenied", err)
+			}
+			if after, _ := repos.roles.GetPermissionNames(tt.role); !sameStrings(after, before) {
+				t.Errorf("permissions of %s after a refused UpsertRole = %v, want %v", tt.role, after, before)
+			}
+		})
+	}
+}
+
+func TestUpsertRoleRequiresRolesManage(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestAdminUserService(repos)
+	repos.createRole(t, "catalog", entity.PermissionBooksWrite)
+	ctx := repos.contextFor(t, repos.createUser(t, "caller", "catalog"))
+
+	if _, err := service.UpsertRole(ctx, "catalog", "", nil); !errors.Is(err, middleware.ErrPermissionDenied) {
+		t.Errorf("UpsertRole() without roles:manage = %v, want ErrPermissionDenied", err)
+	}
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// APIKeyService manages API keys for machine clients (requires api_keys:manage)
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, name string, scopes []string, rateLimitPerMinute int, expiresAt *time.Time) (*entity.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, page, limit int, includeRevoked bool) ([]*entity.APIKey, int64, error)
+	RevokeAPIKey(ctx context.Context, id uint) error
+}
+
+type apiKeyServiceImpl struct {
+	apiKeyRepo repository.APIKeyRepository
+	roleRepo   repository.RoleRepository
+	txRepo     repository.TransactionRepository
+	auditRepo  repository.AuditRepository
+	cfg        config.APIKeyConfig
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, roleRepo repository.RoleRepository, txRepo repository.TransactionRepository, auditRepo repository.AuditRepository, cfg config.APIKeyConfig) APIKeyService {
+	return &apiKeyServiceImpl{
+		apiKeyRepo: apiKeyRepo,
+		roleRepo:   roleRepo,
+		txRepo:     txRepo,
+		auditRepo:  auditRepo,
+		cfg:        cfg,
+	}
+}
+
+// CreateAPIKey creates a key acting on behalf of the caller. Scopes must be
+// permissions the caller holds. The plain key is returned only here.
+func (s *apiKeyServiceImpl) CreateAPIKey(ctx context.Context, name string, scopes []string, rateLimitPerMinute int, expiresAt *time.Time) (*entity.APIKey, string, error) {
+	logger.Info("Creating API key", "name", name, "scopes", scopes)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionAPIKeysManage); err != nil {
+		logger.Error("Creating API key denied", "name", name, "error", err)
+		return nil, "", err
+	}
+
+	admin, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to create API key - unauthenticated", "error", err)
+		return nil, "", err
+	}
+
+	scopes = uniqueScopes(scopes)
+	known, err := s.roleRepo.GetPermissionsByNames(scopes)
+	if err != nil {
+		logger.Error("Failed to get permissions for API key", "name", name, "error", err)
+		return nil, "", err
+	}
+	if len(known) != len(scopes) {
+		logger.Error("Failed to create API key - unknown scope", "name", name, "scopes", scopes)
+		return nil, "", errors.New("unknown scope")
+	}
+
+	// A key can never do more than the admin creating it
+	for _, scope := range scopes {
+		if !middleware.HasPermission(ctx, scope) {
+			logger.Error("Failed to create API key - scope not held by caller", "name", name, "scope", scope, "adminID", admin.ID)
+			return nil, "", errors.New("cannot grant a scope you do not have: " + scope)
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		logger.Error("Failed to create API key - expiry in the past", "name", name)
+		return nil, "", errors.New("expiry must be in the future")
+	}
+
+	if rateLimitPerMinute <= 0 {
+		rateLimitPerMinute = s.cfg.DefaultRateLimit
+	}
+
+	plainKey, prefix, err := helpers.GenerateAPIKey()
+	if err != nil {
+		logger.Error("Failed to generate API key", "name", name, "error", err)
+		return nil, "", err
+	}
+
+	apiKey := &entity.APIKey{
+		Name:               name,
+		Prefix:             prefix,
+		KeyHash:            helpers.HashToken(plainKey),
+		Scopes:             strings.Join(scopes, ","),
+		RateLimitPerMinute: rateLimitPerMinute,
+		CreatedByID:        admin.ID,
+		ExpiresAt:          expiresAt,
+	}
+	// Save key together with its audit event
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.apiKeyRepo.CreateTx(tx, apiKey); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "api_key.create", auditEntityAPIKey, apiKey.ID, nil, apiKey)
+	})
+	if err != nil {
+		logger.Error("Failed to create API key", "name", name, "error", err)
+		return nil, "", err
+	}
+
+	logger.Info("API key created successfully", "apiKeyID", apiKey.ID, "prefix", prefix, "adminID", admin.ID)
+	return apiKey, plainKey, nil
+}
+
+// ListAPIKeys retrieves API keys with pagination
+func (s *apiKeyServiceImpl) ListAPIKeys(ctx context.Context, page, limit int, includeRevoked bool) ([]*entity.APIKey, int64, error) {
+	logger.Info("Listing API keys", "page", page, "limit", limit, "includeRevoked", includeRevoked)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionAPIKeysManage); err != nil {
+		logger.Error("Listing API keys denied", "error", err)
+		return nil, 0, err
+	}
+
+	keys, total, err := s.apiKeyRepo.GetAll(page, limit, includeRevoked)
+	if err != nil {
+		logger.Error("Failed to list API keys", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("API keys listed successfully", "count", len(keys), "total", total)
+	return keys, total, nil
+}
+
+// RevokeAPIKey permanently disables an API key
+func (s *apiKeyServiceImpl) RevokeAPIKey(ctx context.Context, id uint) error {
+	logger.Info("Revoking API key", "apiKeyID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionAPIKeysManage); err != nil {
+		logger.Error("Revoking API key denied", "apiKeyID", id, "error", err)
+		return err
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get API key for revocation", "apiKeyID", id, "error", err)
+		return err
+	}
+
+	if apiKey.IsRevoked() {
+		logger.Info("API key already revoked", "apiKeyID", id)
+		return nil
+	}
+
+	before := *apiKey
+	now := time.Now()
+	apiKey.RevokedAt = &now
+
+	// Revoke key together with its audit event
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.apiKeyRepo.RevokeTx(tx, id, now); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "api_key.revoke", auditEntityAPIKey, id, &before, apiKey)
+	})
+	if err != nil {
+		logger.Error("Failed to revoke API key", "apiKeyID", id, "error", err)
+		return err
+	}
+
+	logger.Info("API key revoked successfully", "apiKeyID", id)
+	return nil
+}
+
+// uniqueScopes trims, deduplicates and sorts scope (permission) names
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	var unique []string
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		unique = append(unique, scope)
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// Entity types recorded in the audit log
+const (
+	auditEntityBook     = "book"
+	auditEntityCategory = "category"
+	auditEntityOrder    = "order"
+	auditEntityUser     = "user"
+	auditEntityRole     = "role"
+	auditEntityAPIKey   = "api_key"
+)
+
+// auditMaxValueLength is the longest string stored verbatim in an audit diff.
+// Longer values (e.g. base64 images) are replaced by their length and hash.
+const auditMaxValueLength = 256
+
+// AuditService reads the audit log of administrative changes (requires audit:read)
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, filter repository.AuditFilter, page, limit int) ([]*entity.AuditEvent, int64, error)
+}
+
+type auditServiceImpl struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) AuditService {
+	return &auditServiceImpl{
+		auditRepo: auditRepo,
+	}
+}
+
+// ListAuditEvents retrieves audit events matching filter, newest first (requires audit:read)
+func (s *auditServiceImpl) ListAuditEvents(ctx context.Context, filter repository.AuditFilter, page, limit int) ([]*entity.AuditEvent, int64, error) {
+	logger.Info("Listing audit events", "page", page, "limit", limit, "action", filter.Action, "entityType", filter.EntityType, "entityID", filter.EntityID)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionAuditRead); err != nil {
+		logger.Error("Listing audit events denied", "error", err)
+		return nil, 0, err
+	}
+
+	events, total, err := s.auditRepo.GetAll(filter, page, limit)
+	if err != nil {
+		logger.Error("Failed to list audit events", "error", err)
+		return nil, 0, err
+	}
+
+	logger.Info("Audit events listed successfully", "count", len(events), "total", total)
+	return events, total, nil
+}
+
+// newAuditEvent describes a change made by the caller. before and after are the
+// entity before and after the change, nil for creations and deletions; only
+// the fields that differ are stored.
+func newAuditEvent(ctx context.Context, action, entityType string, entityID uint, before, after interface{}) (*entity.AuditEvent, error) {
+	actor, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeJSON, afterJSON, err := auditDiff(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &entity.AuditEvent{
+		ActorID:    actor.ID,
+		ActorEmail: actor.Email,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		RequestID:  middleware.RequestIDFromContext(ctx),
+	}
+	if apiKey, ok := middleware.APIKeyFromContext(ctx); ok {
+		event.APIKeyID = &apiKey.ID
+	}
+	return event, nil
+}
+
+// recordAudit stores an audit event in tx, the transaction making the change
+func recordAudit(ctx context.Context, tx *gorm.DB, auditRepo repository.AuditRepository, action, entityType string, entityID uint, before, after interface{}) error {
+	event, err := newAuditEvent(ctx, action, entityType, entityID, before, after)
+	if err != nil {
+		return err
+	}
+	return auditRepo.CreateTx(tx, event)
+}
+
+// auditDiff returns the JSON of the fields that differ between before and
+// after. Timestamps maintained by GORM and related objects are left out.
+func auditDiff(before, after interface{}) (*string, *string, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for key, value := range beforeFields {
+			if other, ok := afterFields[key]; ok && reflect.DeepEqual(value, other) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	beforeJSON, err := auditJSON(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterJSON, err := auditJSON(afterFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return beforeJSON, afterJSON, nil
+}
+
+// auditFields returns the top-level JSON fields of v that are not objects, or
+// nil if v is nil. Structs embedded in the row, such as the shipping address of
+// an order, are flattened into "field.subfield" keys instead of being dropped.
+func auditFields(v interface{}) (map[string]interface{}, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for _, key := range embeddedJSONFields(reflect.TypeOf(v)) {
+		if nested, ok := fields[key].(map[string]interface{}); ok {
+			delete(fields, key)
+			for subKey, value := range nested {
+				fields[key+"."+subKey] = value
+			}
+		}
+	}
+
+	for key, value := range fields {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			delete(fields, key)
+		case []interface{}:
+			// Lists of scalars such as permission names are kept
+			for _, element := range typed {
+				if _, ok := element.(map[string]interface{}); ok {
+					delete(fields, key)
+					break
+				}
+			}
+		case string:
+			if len(typed) > auditMaxValueLength {
+				// The hash still shows whether two long values differ
+				fields[key] = fmt.Sprintf("<%d bytes, sha256:%s>", len(typed), helpers.HashToken(typed)[:16])
+			}
+		}
+	}
+	delete(fields, "created_at")
+	delete(fields, "updated_at")
+	return fields, nil
+}
+
+// embeddedJSONFields returns the JSON names of the named struct fields of t that
+// GORM stores in the row itself through the embedded tag
+func embeddedJSONFields(t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous || !strings.Contains(field.Tag.Get("gorm"), "embedded") {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func auditJSON(fields map[string]interface{}) (*string, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(fields); err != nil {
+		return nil, err
+	}
+	s := strings.TrimSuffix(buf.String(), "\n")
+	return &s, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"gorm.io/gorm"
+)
+
+// decodeAudit decodes an audit JSON column, nil staying nil
+func decodeAudit(t *testing.T, column *string) map[string]interface{} {
+	t.Helper()
+
+	if column == nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(*column), &fields); err != nil {
+		t.Fatalf("decode audit JSON %s: %v", *column, err)
+	}
+	return fields
+}
+
+func TestAuditDiffKeepsChangedFieldsOnly(t *testing.T) {
+	before := &entity.Book{ID: 1, Title: "Dune", Author: "Herbert", Price: 10, Stock: 3, CategoryID: 1, Category: entity.Category{ID: 1, Name: "Fiction"}}
+	after := *before
+	after.Price = 12
+	after.Category = entity.Category{ID: 2, Name: "Science Fiction"}
+	after.UpdatedAt = before.UpdatedAt.Add(1)
+
+	beforeJSON, afterJSON, err := auditDiff(before, &after)
+	if err != nil {
+		t.Fatalf("auditDiff: %v", err)
+	}
+	gotBefore, gotAfter := decodeAudit(t, beforeJSON), decodeAudit(t, afterJSON)
+	if len(gotBefore) != 1 || gotBefore["price"] != 10.0 {
+		t.Errorf("before = %s, want only the old price", *beforeJSON)
+	}
+	if len(gotAfter) != 1 || gotAfter["price"] != 12.0 {
+		t.Errorf("after = %s, want only the new price", *afterJSON)
+	}
+}
+
+func TestAuditDiffOfCreationAndDeletion(t *testing.T) {
+	category := &entity.Category{ID: 1, Name: "Fiction"}
+
+	beforeJSON, afterJSON, err := auditDiff(nil, category)
+	if err != nil {
+		t.Fatalf("auditDiff: %v", err)
+	}
+	if beforeJSON != nil {
+		t.Errorf("before of a creation = %s, want nil", *beforeJSON)
+	}
+	if got := decodeAudit(t, afterJSON); got["name"] != "Fiction" || got["id"] != 1.0 {
+		t.Errorf("after of a creation = %v, want every field", got)
+	}
+	if _, ok := decodeAudit(t, afterJSON)["created_at"]; ok {
+		t.Error("timestamps maintained by GORM are audited")
+	}
+
+	// A typed nil pointer is a deletion too
+	var deleted *entity.Category
+	beforeJSON, afterJSON, err = auditDiff(category, deleted)
+	if err != nil {
+		t.Fatalf("auditDiff: %v", err)
+	}
+	if beforeJSON == nil || afterJSON != nil {
+		t.Errorf("diff of a deletion = %v, %v, want only before", beforeJSON, afterJSON)
+	}
+}
+
+func TestAuditDiffFlattensEmbeddedStructs(t *testing.T) {
+	before := &entity.Order{ID: 1, Status: "pending", ShippingAddress: entity.AddressFields{RecipientName: "Reader", City: "Bandung"}}
+	after := *before
+	after.ShippingAddress.City = "Jakarta"
+
+	beforeJSON, afterJSON, err := auditDiff(before, &after)
+	if err != nil {
+		t.Fatalf("auditDiff: %v", err)
+	}
+	gotBefore, gotAfter := decodeAudit(t, beforeJSON), decodeAudit(t, afterJSON)
+	if len(gotBefore) != 1 || gotBefore["shipping_address.city"] != "Bandung" {
+		t.Errorf("before = %s, want only the old shipping city", *beforeJSON)
+	}
+	if len(gotAfter) != 1 || gotAfter["shipping_address.city"] != "Jakarta" {
+		t.Errorf("after = %s, want only the new shipping city", *afterJSON)
+	}
+
+	// Anonymous embedded structs are already flat in JSON
+	_, afterJSON, err = auditDiff(nil, &entity.Address{AddressFields: entity.AddressFields{City: "Bandung"}})
+	if err != nil {
+		t.Fatalf("auditDiff: %v", err)
+	}
+	if got := decodeAudit(t, afterJSON); got["city"] != "Bandung" {
+		t.Errorf("after = %v, want the city of the address", got)
+	}
+}
+
+func TestAuditDiffValues(t *testing.T) {
+	type role struct {
+		Name        string          `json:"name"`
+		Permissions []string        `json:"permissions"`
+		Grants      []entity.APIKey `json:"grants"`
+		Cover       string          `json:"cover"`
+	}
+
+	cover := strings.Repeat("a", auditMaxValueLength+1)
+	_, afterJSON, err := auditDiff(nil, role{Name: "editor", Permissions: []string{"books:write"}, Grants: []entity.APIKey{{Name: "key"}}, Cover: cover})
+	if err != nil {
+		t.Fatalf("auditDiff: %v", err)
+	}
+	got := decodeAudit(t, afterJSON)
+
+	if permissions, ok := got["permissions"].([]interface{}); !ok || len(permissions) != 1 || permissions[0] != "books:write" {
+		t.Errorf("permissions = %v, want lists of scalars kept", got["permissions"])
+	}
+	if _, ok := got["grants"]; ok {
+		t.Error("list of objects is audited")
+	}
+	if value, _ := got["cover"].(string); !strings.HasPrefix(value, "<257 bytes, sha256:") {
+		t.Errorf("cover = %q, want long values replaced by their length and hash", value)
+	}
+}
+
+func TestRecordAuditStoresEventOfActor(t *testing.T) {
+	repos := newTestRepos(t)
+	admin := repos.createUser(t, "admin", entity.RoleSuperAdmin)
+	before := &entity.Category{ID: 7, Name: "Fiction"}
+	after := &entity.Category{ID: 7, Name: "Novels"}
+	ctx := repos.contextFor(t, admin)
+
+	err := repos.tx.WithTransaction(func(tx *gorm.DB) error {
+		return recordAudit(ctx, tx, repos.audit, "category.update", auditEntityCategory, 7, before, after)
+	})
+	if err != nil {
+		t.Fatalf("recordAudit: %v", err)
+	}
+
+	events, total, err := repos.audit.GetAll(repository.AuditFilter{EntityType: auditEntityCategory, EntityID: 7}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("%d audit events, want 1", total)
+	}
+	event := events[0]
+	if event.ActorID != admin.ID || event.ActorEmail != admin.Email || event.Action != "category.update" {
+		t.Errorf("event = actor %d %s, action %s, want the admin updating the category", event.ActorID, event.ActorEmail, event.Action)
+	}
+	if got := decodeAudit(t, event.After); len(got) != 1 || got["name"] != "Novels" {
+		t.Errorf("after = %v, want only the new name", got)
+	}
+
+	// Changes without an authenticated actor are not recorded
+	err = repos.tx.WithTransaction(func(tx *gorm.DB) error {
+		return recordAudit(context.Background(), tx, repos.audit, "category.update", auditEntityCategory, 7, before, after)
+	})
+	if err == nil {
+		t.Error("recordAudit succeeded without an authenticated actor")
+	}
+}
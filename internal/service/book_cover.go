@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/imaging"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// legacyCoverBatchSize is how many base64 covers MigrateLegacyCovers loads at once
+const legacyCoverBatchSize = 50
+
+// ErrInvalidCover is wrapped by errors for covers that are not a JPEG, PNG or
+// WebP image, are malformed or exceed the configured limits
+var ErrInvalidCover = errors.New("invalid cover image")
+
+// storedCover holds the blob keys of a cover and its thumbnail
+type storedCover struct {
+	key          string
+	thumbnailKey string
+}
+
+// UploadBookCover replaces the cover of a book (requires books:write). The old
+// cover is deleted from the blob store once the book points to the new one.
+func (s *bookServiceImpl) UploadBookCover(ctx context.Context, bookID uint, data []byte) (*entity.Book, error) {
+	logger.Info("Starting book cover upload", "bookID", bookID, "size", len(data))
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionBooksWrite); err != nil {
+		logger.Error("Book cover upload denied", "bookID", bookID, "error", err)
+		return nil, err
+	}
+
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		logger.Error("Failed to get book for cover upload", "bookID", bookID, "error", err)
+		return nil, err
+	}
+
+	cover, err := s.storeCover(ctx, data)
+	if err != nil {
+		logger.Error("Failed to store book cover", "bookID", bookID, "error", err)
+		return nil, err
+	}
+
+	before := *book
+	old := storedCover{key: book.CoverKey, thumbnailKey: book.ThumbnailKey}
+	book.CoverKey = cover.key
+	book.ThumbnailKey = cover.thumbnailKey
+
+	// Point the book to the new cover together with its audit event
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.bookRepo.UpdateCoverTx(tx, bookID, cover.key, cover.thumbnailKey); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "book.update_cover", auditEntityBook, bookID, &before, book)
+	})
+	if err != nil {
+		logger.Error("Failed to update book cover", "bookID", bookID, "error", err)
+		s.deleteCover(ctx, cover)
+		return nil, err
+	}
+	s.deleteCover(ctx, old)
+
+	logger.Info("Book cover upload successful", "bookID", bookID, "coverKey", cover.key)
+	return book, nil
+}
+
+// storeCover validates and normalizes data, then stores it in the blob store
+// together with a generated thumbnail
+func (s *bookServiceImpl) storeCover(ctx context.Context, data []byte) (storedCover, error) {
+	limits := imaging.Limits{
+		MaxBytes:     s.coverCfg.MaxBytes,
+		MaxDimension: s.coverCfg.MaxDimension,
+	}
+	img, normalized, err := imaging.Normalize(data, limits)
+	if err != nil {
+		return storedCover{}, fmt.Errorf("%w: %v", ErrInvalidCover, err)
+	}
+	thumbnail, err := imaging.Thumbnail(img, s.coverCfg.ThumbnailWidth)
+	if err != nil {
+		return storedCover{}, err
+	}
+
+	id, err := helpers.GenerateRandomString(16)
+	if err != nil {
+		return storedCover{}, err
+	}
+	cover := storedCover{
+		key:          "covers/" + id + ".jpg",
+		thumbnailKey: "covers/" + id + "-thumb.jpg",
+	}
+
+	if err := s.blobs.Put(ctx, cover.key, normalized, imaging.ContentType); err != nil {
+		return storedCover{}, err
+	}
+	if err := s.blobs.Put(ctx, cover.thumbnailKey, thumbnail, imaging.ContentType); err != nil {
+		s.deleteCover(ctx, storedCover{key: cover.key})
+		return storedCover{}, err
+	}
+	return cover, nil
+}
+
+// deleteCover removes the blobs of a cover that is no longer referenced.
+// Failures only leave an orphaned blob behind, so they are logged and ignored.
+func (s *bookServiceImpl) deleteCover(ctx context.Context, cover storedCover) {
+	for _, key := range []string{cover.key, cover.thumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			logger.Warn("Failed to delete cover blob", "key", key, "error", err)
+		}
+	}
+}
+
+// MigrateLegacyCovers moves covers still stored as base64 text in the books
+// table into the blob store. Books whose cover cannot be migrated keep it; the
+// base64 column is dropped once no covers are left in it.
+func (s *bookServiceImpl) MigrateLegacyCovers(ctx context.Context) error {
+	if !s.bookRepo.HasLegacyCovers() {
+		return nil
+	}
+	logger.Info("Starting legacy cover migration")
+
+	var afterID uint
+	migrated, failed := 0, 0
+	for {
+		covers, err := s.bookRepo.GetLegacyCovers(afterID, legacyCoverBatchSize)
+		if err != nil {
+			logger.Error("Failed to get legacy covers", "error", err)
+			return err
+		}
+		if len(covers) == 0 {
+			break
+		}
+
+		for _, legacy := range covers {
+			afterID = legacy.ID
+			if err := s.migrateLegacyCover(ctx, legacy.ID, legacy.ImageBase64); err != nil {
+				logger.Error("Failed to migrate legacy cover", "bookID", legacy.ID, "error", err)
+				failed++
+				continue
+			}
+			migrated++
+		}
+	}
+
+	if failed > 0 {
+		logger.Warn("Legacy cover migration incomplete", "migrated", migrated, "failed", failed)
+		return fmt.Errorf("%d legacy covers could not be migrated", failed)
+	}
+	if err := s.bookRepo.DropLegacyCoverColumn(); err != nil {
+		return err
+	}
+
+	logger.Info("Legacy cover migration completed", "migrated", migrated)
+	return nil
+}
+
+func (s *bookServiceImpl) migrateLegacyCover(ctx context.Context, bookID uint, imageBase64 string) error {
+	data, err := imaging.DecodeBase64(imageBase64)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCover, err)
+	}
+	cover, err := s.storeCover(ctx, data)
+	if err != nil {
+		return err
+	}
+	if err := s.bookRepo.MigrateLegacyCover(bookID, cover.key, cover.thumbnailKey); err != nil {
+		s.deleteCover(ctx, cover)
+		return err
+	}
+	return nil
+}
@@ -1,20 +1,33 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"strings"
 
+	"github.com/nabil/book-store-system/config"
 	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/helpers"
 	"github.com/nabil/book-store-system/pkg/logger"
 	"github.com/nabil/book-store-system/pkg/middleware"
+	"github.com/nabil/book-store-system/pkg/storage"
+	"gorm.io/gorm"
 )
 
+// ErrDuplicateISBN is returned when another book already has the ISBN
+var ErrDuplicateISBN = errors.New("a book with this ISBN already exists")
+
 type BookService interface {
-	CreateBook(title, author, imageBase64, token string, price float64, stock, year int, categoryID uint) (*entity.Book, error)
-	GetBooks(page, limit int, search string) ([]*entity.Book, int64, error)
+	CreateBook(ctx context.Context, title, author, isbn, description string, cover []byte, price float64, stock, year int, categoryID uint) (*entity.Book, error)
+	GetBooks(page, limit int, search, searchMode string) ([]*entity.Book, int64, string, error)
+	SuggestBooks(prefix string, limit int) ([]repository.BookSuggestion, string, error)
 	GetBook(id uint) (*entity.Book, error)
-	UpdateBook(id uint, title, author, imageBase64, token string, price float64, stock, year int, categoryID uint) (*entity.Book, error)
-	DeleteBook(id uint, token string) error
+	GetBookByISBN(isbn string) (*entity.Book, error)
+	UpdateBook(ctx context.Context, id uint, title, author, isbn, description string, cover []byte, price float64, stock, year int, categoryID uint) (*entity.Book, error)
+	UploadBookCover(ctx context.Context, bookID uint, data []byte) (*entity.Book, error)
+	MigrateLegacyCovers(ctx context.Context) error
+	DeleteBook(ctx context.Context, id uint) error
 	GetBooksByCategory(categoryID uint, page, limit int) ([]*entity.Book, int64, error)
 	CheckBookAvailability(bookID uint, quantity int) (bool, error)
 }
@@ -22,51 +35,79 @@ type BookService interface {
 type bookServiceImpl struct {
 	bookRepo     repository.BookRepository
 	categoryRepo repository.CategoryRepository
-	userRepo     repository.UserRepository
-	auth         *middleware.AuthMiddleware
+	txRepo       repository.TransactionRepository
+	auditRepo    repository.AuditRepository
+	blobs        storage.BlobStore
+	coverCfg     config.CoverConfig
+	searchCfg    config.SearchConfig
 }
 
-func NewBookService(bookRepo repository.BookRepository, categoryRepo repository.CategoryRepository, userRepo repository.UserRepository) BookService {
-	auth := middleware.NewAuthMiddleware(userRepo)
+func NewBookService(bookRepo repository.BookRepository, categoryRepo repository.CategoryRepository, txRepo repository.TransactionRepository, auditRepo repository.AuditRepository, blobs storage.BlobStore, coverCfg config.CoverConfig, searchCfg config.SearchConfig) BookService {
 	return &bookServiceImpl{
 		bookRepo:     bookRepo,
 		categoryRepo: categoryRepo,
-		userRepo:     userRepo,
-		auth:         auth,
+		txRepo:       txRepo,
+		auditRepo:    auditRepo,
+		blobs:        blobs,
+		coverCfg:     coverCfg,
+		searchCfg:    searchCfg,
 	}
 }
 
-// CreateBook creates a new book (admin only)
-func (s *bookServiceImpl) CreateBook(title, author, imageBase64, token string, price float64, stock, year int, categoryID uint) (*entity.Book, error) {
+// CreateBook creates a new book (requires books:write). cover is optional.
+func (s *bookServiceImpl) CreateBook(ctx context.Context, title, author, isbn, description string, cover []byte, price float64, stock, year int, categoryID uint) (*entity.Book, error) {
 	logger.Info("Starting book creation", "title", title, "author", author, "categoryID", categoryID)
 
-	_, err := s.auth.ValidateAdminToken(token)
-	if err != nil {
-		logger.Error("Book creation failed - invalid admin token", "title", title, "error", err)
+	if err := middleware.RequirePermission(ctx, entity.PermissionBooksWrite); err != nil {
+		logger.Error("Book creation denied", "title", title, "error", err)
 		return nil, err
 	}
 
-	_, err = s.categoryRepo.GetByID(categoryID)
+	_, err := s.categoryRepo.GetByID(categoryID)
 	if err != nil {
 		logger.Error("Book creation failed - category not found", "title", title, "categoryID", categoryID, "error", err)
 		return nil, errors.New("category not found")
 	}
 
+	normalizedISBN, err := s.checkISBN(isbn, 0)
+	if err != nil {
+		logger.Error("Book creation failed - invalid ISBN", "title", title, "isbn", isbn, "error", err)
+		return nil, err
+	}
+
 	// Create book
 	book := &entity.Book{
 		Title:       title,
 		Author:      author,
+		Description: description,
+		ISBN:        normalizedISBN,
 		Price:       price,
 		Stock:       stock,
 		Year:        year,
 		CategoryID:  categoryID,
-		ImageBase64: imageBase64,
 	}
 
-	// Save book
-	err = s.bookRepo.Create(book)
+	var stored storedCover
+	if len(cover) > 0 {
+		stored, err = s.storeCover(ctx, cover)
+		if err != nil {
+			logger.Error("Book creation failed - cover not stored", "title", title, "error", err)
+			return nil, err
+		}
+		book.CoverKey = stored.key
+		book.ThumbnailKey = stored.thumbnailKey
+	}
+
+	// Save book together with its audit event
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.bookRepo.CreateTx(tx, book); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "book.create", auditEntityBook, book.ID, nil, book)
+	})
 	if err != nil {
 		logger.Error("Failed to create book", "title", title, "error", err)
+		s.deleteCover(ctx, stored)
 		return nil, err
 	}
 
@@ -74,18 +115,60 @@ func (s *bookServiceImpl) CreateBook(title, author, imageBase64, token string, p
 	return book, nil
 }
 
-// GetBooks retrieves books with pagination and search
-func (s *bookServiceImpl) GetBooks(page, limit int, search string) ([]*entity.Book, int64, error) {
-	logger.Info("Getting books", "page", page, "limit", limit, "search", search)
+// GetBooks retrieves books with pagination and search. searchMode is
+// repository.SearchModeFullText (the default when empty) or
+// repository.SearchModeSubstring. When nothing matches search, books with a
+// similar title or author are returned instead, together with the closest
+// title or author as a "did you mean" correction.
+func (s *bookServiceImpl) GetBooks(page, limit int, search, searchMode string) ([]*entity.Book, int64, string, error) {
+	logger.Info("Getting books", "page", page, "limit", limit, "search", search, "searchMode", searchMode)
 
-	books, total, err := s.bookRepo.GetAll(page, limit, search)
+	books, total, err := s.bookRepo.GetAll(page, limit, search, searchMode)
 	if err != nil {
-		logger.Error("Failed to get books", "page", page, "limit", limit, "search", search, "error", err)
-		return nil, 0, err
+		logger.Error("Failed to get books", "page", page, "limit", limit, "search", search, "searchMode", searchMode, "error", err)
+		return nil, 0, "", err
+	}
+
+	var didYouMean string
+	if total == 0 && strings.TrimSpace(search) != "" {
+		// Fall back to typo-tolerant matching
+		books, total, err = s.bookRepo.GetSimilar(page, limit, search)
+		if err != nil {
+			logger.Error("Failed to get similar books", "search", search, "error", err)
+			return nil, 0, "", err
+		}
+
+		didYouMean, err = s.bookRepo.DidYouMean(search)
+		if err != nil {
+			logger.Error("Failed to get spelling correction", "search", search, "error", err)
+			return nil, 0, "", err
+		}
+		logger.Info("No exact matches, using similar books", "search", search, "total", total, "didYouMean", didYouMean)
 	}
 
 	logger.Info("Books retrieved successfully", "count", len(books), "total", total)
-	return books, total, nil
+	return books, total, didYouMean, nil
+}
+
+// SuggestBooks returns up to limit titles and authors completing prefix for
+// autocomplete, and a "did you mean" correction when there are none. Lookups
+// exceeding the configured time budget return no suggestions rather than an
+// error, so that slow autocomplete never blocks typing.
+func (s *bookServiceImpl) SuggestBooks(prefix string, limit int) ([]repository.BookSuggestion, string, error) {
+	logger.Info("Getting book suggestions", "prefix", prefix, "limit", limit)
+
+	suggestions, didYouMean, err := s.bookRepo.Suggest(prefix, limit, s.searchCfg.SuggestTimeout)
+	if errors.Is(err, repository.ErrQueryTimeout) {
+		logger.Warn("Book suggestions timed out", "prefix", prefix, "timeout", s.searchCfg.SuggestTimeout)
+		return nil, "", nil
+	}
+	if err != nil {
+		logger.Error("Failed to get book suggestions", "prefix", prefix, "error", err)
+		return nil, "", err
+	}
+
+	logger.Info("Book suggestions retrieved successfully", "prefix", prefix, "count", len(suggestions), "didYouMean", didYouMean)
+	return suggestions, didYouMean, nil
 }
 
 // GetBook retrieves a book by ID
@@ -102,14 +185,33 @@ func (s *bookServiceImpl) GetBook(id uint) (*entity.Book, error) {
 	return book, nil
 }
 
-// UpdateBook updates a book (admin only)
-func (s *bookServiceImpl) UpdateBook(id uint, title, author, imageBase64, token string, price float64, stock, year int, categoryID uint) (*entity.Book, error) {
-	logger.Info("Starting book update", "bookID", id, "title", title, "categoryID", categoryID)
+// GetBookByISBN retrieves a book by ISBN-10 or ISBN-13, with or without hyphens
+func (s *bookServiceImpl) GetBookByISBN(isbn string) (*entity.Book, error) {
+	logger.Info("Getting book by ISBN", "isbn", isbn)
 
-	// Validate admin token
-	_, err := s.auth.ValidateAdminToken(token)
+	normalized, err := helpers.NormalizeISBN(isbn)
 	if err != nil {
-		logger.Error("Book update failed - invalid admin token", "bookID", id, "error", err)
+		logger.Error("Failed to get book - invalid ISBN", "isbn", isbn, "error", err)
+		return nil, err
+	}
+
+	book, err := s.bookRepo.GetByISBN(normalized)
+	if err != nil {
+		logger.Error("Failed to get book by ISBN", "isbn", normalized, "error", err)
+		return nil, err
+	}
+
+	logger.Info("Book retrieved successfully", "bookID", book.ID, "isbn", normalized)
+	return book, nil
+}
+
+// UpdateBook updates a book (requires books:write). An empty cover keeps the
+// current one.
+func (s *bookServiceImpl) UpdateBook(ctx context.Context, id uint, title, author, isbn, description string, cover []byte, price float64, stock, year int, categoryID uint) (*entity.Book, error) {
+	logger.Info("Starting book update", "bookID", id, "title", title, "categoryID", categoryID)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionBooksWrite); err != nil {
+		logger.Error("Book update denied", "bookID", id, "error", err)
 		return nil, err
 	}
 
@@ -127,46 +229,101 @@ func (s *bookServiceImpl) UpdateBook(id uint, title, author, imageBase64, token
 		return nil, errors.New("category not found")
 	}
 
+	normalizedISBN, err := s.checkISBN(isbn, id)
+	if err != nil {
+		logger.Error("Book update failed - invalid ISBN", "bookID", id, "isbn", isbn, "error", err)
+		return nil, err
+	}
+
+	before := *existingBook
+
 	// Update book fields
 	existingBook.Title = title
 	existingBook.Author = author
+	existingBook.Description = description
+	existingBook.ISBN = normalizedISBN
 	existingBook.Price = price
 	existingBook.Stock = stock
 	existingBook.Year = year
 	existingBook.CategoryID = categoryID
-	existingBook.ImageBase64 = imageBase64
 
-	// Update existing book
-	err = s.bookRepo.Update(existingBook)
+	var stored storedCover
+	old := storedCover{key: existingBook.CoverKey, thumbnailKey: existingBook.ThumbnailKey}
+	if len(cover) > 0 {
+		stored, err = s.storeCover(ctx, cover)
+		if err != nil {
+			logger.Error("Book update failed - cover not stored", "bookID", id, "error", err)
+			return nil, err
+		}
+		existingBook.CoverKey = stored.key
+		existingBook.ThumbnailKey = stored.thumbnailKey
+	}
+
+	// Update existing book together with its audit event
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.bookRepo.UpdateTx(tx, existingBook); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "book.update", auditEntityBook, id, &before, existingBook)
+	})
 	if err != nil {
 		logger.Error("Failed to update book", "bookID", id, "error", err)
+		s.deleteCover(ctx, stored)
 		return nil, err
 	}
+	if stored.key != "" {
+		s.deleteCover(ctx, old)
+	}
 
 	logger.Info("Book update successful", "bookID", id, "title", title)
 	return existingBook, nil
 }
 
-// DeleteBook deletes a book (admin only)
-func (s *bookServiceImpl) DeleteBook(id uint, token string) error {
-	logger.Info("Starting book deletion", "bookID", id)
+// checkISBN normalizes isbn and makes sure no book other than bookID has it.
+// An empty isbn means the book has none and is stored as NULL.
+func (s *bookServiceImpl) checkISBN(isbn string, bookID uint) (*string, error) {
+	if strings.TrimSpace(isbn) == "" {
+		return nil, nil
+	}
 
-	// Validate admin token
-	_, err := s.auth.ValidateAdminToken(token)
+	normalized, err := helpers.NormalizeISBN(isbn)
 	if err != nil {
-		logger.Error("Book deletion failed - invalid admin token", "bookID", id, "error", err)
+		return nil, err
+	}
+
+	existing, err := s.bookRepo.GetByISBN(normalized)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.ID != bookID {
+		return nil, ErrDuplicateISBN
+	}
+	return &normalized, nil
+}
+
+// DeleteBook deletes a book (requires books:write)
+func (s *bookServiceImpl) DeleteBook(ctx context.Context, id uint) error {
+	logger.Info("Starting book deletion", "bookID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionBooksWrite); err != nil {
+		logger.Error("Book deletion denied", "bookID", id, "error", err)
 		return err
 	}
 
 	// Check if book exists
-	_, err = s.bookRepo.GetByID(id)
+	book, err := s.bookRepo.GetByID(id)
 	if err != nil {
 		logger.Error("Failed to get book for deletion", "bookID", id, "error", err)
 		return err
 	}
 
-	// Delete book
-	err = s.bookRepo.Delete(id)
+	// Delete book together with its audit event
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.bookRepo.DeleteTx(tx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "book.delete", auditEntityBook, id, book, nil)
+	})
 	if err != nil {
 		logger.Error("Failed to delete book", "bookID", id, "error", err)
 		return err
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/nabil/book-store-system/internal/entity"
@@ -11,35 +12,33 @@ import (
 )
 
 type CategoryService interface {
-	CreateCategory(name, token string) (*entity.Category, error)
+	CreateCategory(ctx context.Context, name string) (*entity.Category, error)
 	GetCategories(page, limit int) ([]*entity.Category, int64, error)
 	GetCategory(id uint) (*entity.Category, error)
-	UpdateCategory(id uint, name, token string) (*entity.Category, error)
-	DeleteCategory(id uint, token string) error
+	UpdateCategory(ctx context.Context, id uint, name string) (*entity.Category, error)
+	DeleteCategory(ctx context.Context, id uint) error
 }
 
 type categoryServiceImpl struct {
 	categoryRepo repository.CategoryRepository
-	userRepo     repository.UserRepository
-	auth         *middleware.AuthMiddleware
+	txRepo       repository.TransactionRepository
+	auditRepo    repository.AuditRepository
 }
 
-func NewCategoryService(categoryRepo repository.CategoryRepository, userRepo repository.UserRepository) CategoryService {
-	auth := middleware.NewAuthMiddleware(userRepo)
+func NewCategoryService(categoryRepo repository.CategoryRepository, txRepo repository.TransactionRepository, auditRepo repository.AuditRepository) CategoryService {
 	return &categoryServiceImpl{
 		categoryRepo: categoryRepo,
-		userRepo:     userRepo,
-		auth:         auth,
+		txRepo:       txRepo,
+		auditRepo:    auditRepo,
 	}
 }
 
-// CreateCategory creates a new category (admin only)
-func (s *categoryServiceImpl) CreateCategory(name, token string) (*entity.Category, error) {
+// CreateCategory creates a new category (requires categories:write)
+func (s *categoryServiceImpl) CreateCategory(ctx context.Context, name string) (*entity.Category, error) {
 	logger.Infof("Starting category creation", "name", name)
 
-	_, err := s.auth.ValidateAdminToken(token)
-	if err != nil {
-		logger.Errorf("Category creation failed - invalid admin token", "name", name, "error", err)
+	if err := middleware.RequirePermission(ctx, entity.PermissionCategoriesWrite); err != nil {
+		logger.Error("Category creation denied", "name", name, "error", err)
 		return nil, err
 	}
 
@@ -58,7 +57,12 @@ func (s *categoryServiceImpl) CreateCategory(name, token string) (*entity.Catego
 		Name: name,
 	}
 
-	err = s.categoryRepo.Create(category)
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.categoryRepo.CreateTx(tx, category); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "category.create", auditEntityCategory, category.ID, nil, category)
+	})
 	if err != nil {
 		logger.Errorf("Failed to create category", "name", name, "error", err)
 		return nil, err
@@ -98,13 +102,12 @@ func (s *categoryServiceImpl) GetCategory(id uint) (*entity.Category, error) {
 	return category, nil
 }
 
-// UpdateCategory updates an existing category (admin only)
-func (s *categoryServiceImpl) UpdateCategory(id uint, name, token string) (*entity.Category, error) {
+// UpdateCategory updates an existing category (requires categories:write)
+func (s *categoryServiceImpl) UpdateCategory(ctx context.Context, id uint, name string) (*entity.Category, error) {
 	logger.Infof("Starting category update", "categoryID", id, "name", name)
 
-	_, err := s.auth.ValidateAdminToken(token)
-	if err != nil {
-		logger.Errorf("Category update failed - invalid admin token", "categoryID", id, "error", err)
+	if err := middleware.RequirePermission(ctx, entity.PermissionCategoriesWrite); err != nil {
+		logger.Error("Category update denied", "categoryID", id, "error", err)
 		return nil, err
 	}
 
@@ -126,9 +129,15 @@ func (s *categoryServiceImpl) UpdateCategory(id uint, name, token string) (*enti
 		}
 	}
 
+	before := *category
 	category.Name = name
 
-	err = s.categoryRepo.Update(category)
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.categoryRepo.UpdateTx(tx, category); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "category.update", auditEntityCategory, id, &before, category)
+	})
 	if err != nil {
 		logger.Errorf("Failed to update category", "categoryID", id, "error", err)
 		return nil, err
@@ -138,23 +147,27 @@ func (s *categoryServiceImpl) UpdateCategory(id uint, name, token string) (*enti
 	return category, nil
 }
 
-// DeleteCategory deletes a category (admin only)
-func (s *categoryServiceImpl) DeleteCategory(id uint, token string) error {
+// DeleteCategory deletes a category (requires categories:write)
+func (s *categoryServiceImpl) DeleteCategory(ctx context.Context, id uint) error {
 	logger.Infof("Starting category deletion", "categoryID", id)
 
-	_, err := s.auth.ValidateAdminToken(token)
-	if err != nil {
-		logger.Errorf("Category deletion failed - invalid admin token", "categoryID", id, "error", err)
+	if err := middleware.RequirePermission(ctx, entity.PermissionCategoriesWrite); err != nil {
+		logger.Error("Category deletion denied", "categoryID", id, "error", err)
 		return err
 	}
 
-	_, err = s.categoryRepo.GetByID(id)
+	category, err := s.categoryRepo.GetByID(id)
 	if err != nil {
 		logger.Errorf("Failed to get category for deletion", "categoryID", id, "error", err)
 		return err
 	}
 
-	err = s.categoryRepo.Delete(id)
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.categoryRepo.DeleteTx(tx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "category.delete", auditEntityCategory, id, category, nil)
+	})
 	if err != nil {
 		logger.Errorf("Failed to delete category", "categoryID", id, "error", err)
 		return err
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+// currentUser returns the user authenticated by the auth interceptor
+func currentUser(ctx context.Context) (*entity.User, error) {
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, errors.New("unauthenticated")
+	}
+	return user, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/mailer"
+)
+
+const (
+	// verificationResendCooldown is the minimum time between two verification emails
+	verificationResendCooldown = time.Minute
+	// mailTimeout bounds how long sending a single email may take
+	mailTimeout = 30 * time.Second
+)
+
+// sendMailAsync sends msg in the background so that slow mail servers do not
+// delay the response. Failures are only logged.
+func (s *userServiceImpl) sendMailAsync(userID uint, msg mailer.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			logger.Error("Failed to send email", "userID", userID, "subject", msg.Subject, "error", err)
+			return
+		}
+		logger.Info("Email sent", "userID", userID, "subject", msg.Subject)
+	}()
+}
+
+// sendVerificationEmail emails a signed verification token for the user's
+// current email address
+func (s *userServiceImpl) sendVerificationEmail(user *entity.User) error {
+	token, err := helpers.GenerateScopedToken(user.ID, user.Email, user.Role, user.TokenVersion, helpers.ScopeEmailVerification, s.verificationCfg.TokenTTL)
+	if err != nil {
+		logger.Error("Failed to generate verification token", "userID", user.ID, "error", err)
+		return err
+	}
+
+	now := time.Now()
+	user.VerificationSentAt = &now
+	if err := s.userRepo.Update(user); err != nil {
+		logger.Error("Failed to store verification time", "userID", user.ID, "error", err)
+		return err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nPlease confirm the email address of your Book Store account.\n\n", user.Name)
+	if s.verificationCfg.URL != "" {
+		body += fmt.Sprintf("Open this link to verify your email:\n\n    %s?token=%s\n\n", s.verificationCfg.URL, url.QueryEscape(token))
+	}
+	body += fmt.Sprintf("Or enter this verification code:\n\n    %s\n\nThe code expires in %d hours.\n", token, int(s.verificationCfg.TokenTTL.Hours()))
+
+	s.sendMailAsync(user.ID, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your Book Store email address",
+		Body:    body,
+	})
+
+	logger.Info("Verification email queued", "userID", user.ID)
+	return nil
+}
+
+// VerifyEmail marks the email address of the token's user as verified. Tokens
+// issued for an email address the user no longer has are rejected.
+func (s *userServiceImpl) VerifyEmail(ctx context.Context, token string) (*entity.User, error) {
+	logger.Info("Starting email verification")
+
+	claims, err := helpers.ValidateToken(token)
+	if err != nil || claims.Scope != helpers.ScopeEmailVerification {
+		logger.Error("Email verification failed - invalid token", "error", err)
+		return nil, errors.New("invalid or expired verification token")
+	}
+
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil || user.Email != claims.Email {
+		logger.Error("Email verification failed - token does not match the user's email", "userID", claims.UserID, "error", err)
+		return nil, errors.New("invalid or expired verification token")
+	}
+
+	if user.IsEmailVerified() {
+		logger.Info("Email already verified", "userID", user.ID)
+		return user, nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if err := s.userRepo.Update(user); err != nil {
+		logger.Error("Failed to mark email as verified", "userID", user.ID, "error", err)
+		return nil, err
+	}
+
+	logger.Info("Email verified successfully", "userID", user.ID)
+	return user, nil
+}
+
+// ResendVerification sends a new verification email to the authenticated user
+func (s *userServiceImpl) ResendVerification(ctx context.Context) error {
+	logger.Info("Starting verification email resend")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Verification resend failed - unauthenticated", "error", err)
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		logger.Error("Verification resend failed - already verified", "userID", user.ID)
+		return errors.New("email is already verified")
+	}
+
+	if user.VerificationSentAt != nil && time.Since(*user.VerificationSentAt) < verificationResendCooldown {
+		logger.Error("Verification resend failed - cooldown", "userID", user.ID)
+		return errors.New("a verification email was sent recently, please wait a minute")
+	}
+
+	return s.sendVerificationEmail(user)
+}
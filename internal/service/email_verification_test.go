@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+const verificationIntro = "Or enter this verification code:"
+
+func TestRegisterSendsVerificationEmail(t *testing.T) {
+	repos := newTestRepos(t)
+	service, mails := newTestMailService(t, repos, config.EmailVerificationConfig{})
+
+	user, err := service.Register("Reader", "Reader@Example.com", testPassword)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if user.IsEmailVerified() {
+		t.Error("new account is verified before the email was confirmed")
+	}
+	mail := sentMails(t, mails, 1)[0]
+	if !strings.Contains(mail, "To: reader@example.com\n") {
+		t.Errorf("verification email not sent to the user:\n%s", mail)
+	}
+	token := codeFrom(t, mail, verificationIntro)
+
+	// Tokens of other scopes are not verification tokens
+	access, err := helpers.GenerateToken(user.ID, user.Email, user.Role, user.TokenVersion, 0)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if _, err := service.VerifyEmail(context.Background(), access); err == nil {
+		t.Error("VerifyEmail accepted an access token")
+	}
+
+	verified, err := service.VerifyEmail(context.Background(), token)
+	if err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if !verified.IsEmailVerified() || !repos.reload(t, user).IsEmailVerified() {
+		t.Error("email not verified by VerifyEmail")
+	}
+	if _, err := service.VerifyEmail(context.Background(), token); err != nil {
+		t.Errorf("VerifyEmail() of a verified email: %v", err)
+	}
+}
+
+func TestEmailChangeResetsVerification(t *testing.T) {
+	repos := newTestRepos(t)
+	service, mails := newTestMailService(t, repos, config.EmailVerificationConfig{})
+	user := repos.createUser(t, "reader", entity.RoleUser)
+
+	// A token for the old address, sent before the change
+	stale, err := helpers.GenerateScopedToken(user.ID, user.Email, user.Role, user.TokenVersion, helpers.ScopeEmailVerification, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken: %v", err)
+	}
+
+	updated, err := service.UpdateProfile(user.ID, user.Name, "New@Example.com", "", "")
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if updated.IsEmailVerified() || repos.reload(t, user).IsEmailVerified() {
+		t.Error("new email is verified before it was confirmed")
+	}
+	mail := sentMails(t, mails, 1)[0]
+	if !strings.Contains(mail, "To: new@example.com\n") {
+		t.Errorf("verification email not sent to the new address:\n%s", mail)
+	}
+
+	if _, err := service.VerifyEmail(context.Background(), stale); err == nil {
+		t.Error("VerifyEmail accepted a token for the previous address")
+	}
+	if _, err := service.VerifyEmail(context.Background(), codeFrom(t, mail, verificationIntro)); err != nil {
+		t.Fatalf("VerifyEmail() for the new address: %v", err)
+	}
+	if !repos.reload(t, user).IsEmailVerified() {
+		t.Error("new email not verified by VerifyEmail")
+	}
+}
+
+func TestResendVerificationCooldown(t *testing.T) {
+	repos := newTestRepos(t)
+	service, mails := newTestMailService(t, repos, config.EmailVerificationConfig{})
+	user, err := service.Register("Reader", "reader@example.com", testPassword)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	sentMails(t, mails, 1)
+	resend := func() error {
+		return service.ResendVerification(middleware.ContextWithUser(context.Background(), repos.reload(t, user), nil, nil))
+	}
+
+	if err := resend(); err == nil {
+		t.Error("ResendVerification succeeded within the cooldown")
+	}
+	sentMails(t, mails, 1)
+
+	past := time.Now().Add(-verificationResendCooldown - time.Second)
+	if err := repos.db.Model(user).Update("verification_sent_at", past).Error; err != nil {
+		t.Fatalf("age verification email: %v", err)
+	}
+	if err := resend(); err != nil {
+		t.Fatalf("ResendVerification() after the cooldown: %v", err)
+	}
+	if _, err := service.VerifyEmail(context.Background(), codeFrom(t, sentMails(t, mails, 2)[1], verificationIntro)); err != nil {
+		t.Fatalf("VerifyEmail() with the resent code: %v", err)
+	}
+
+	if err := resend(); err == nil {
+		t.Error("ResendVerification succeeded for a verified email")
+	}
+}
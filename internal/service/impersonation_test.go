@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+func newTestImpersonationService(t *testing.T, repos *testRepos) AdminUserService {
+	t.Helper()
+
+	initTestTokens(t)
+	return NewAdminUserService(repos.users, repos.tokens, repos.roles, repos.attempts, repos.tx, repos.audit, config.ImpersonationConfig{TokenTTL: 15 * time.Minute})
+}
+
+func TestImpersonateUser(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestImpersonationService(t, repos)
+	admin := repos.createUser(t, "admin", entity.RoleSuperAdmin)
+	customer := repos.createUser(t, "customer", entity.RoleUser)
+
+	tokens, user, err := service.ImpersonateUser(repos.contextFor(t, admin), customer.ID)
+	if err != nil {
+		t.Fatalf("ImpersonateUser: %v", err)
+	}
+	if user.ID != customer.ID {
+		t.Errorf("impersonated user %d, want %d", user.ID, customer.ID)
+	}
+	if tokens.RefreshToken != "" {
+		t.Error("impersonation issued a refresh token")
+	}
+
+	claims, err := helpers.ValidateToken(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if claims.UserID != customer.ID || claims.Actor == nil || claims.Actor.UserID != admin.ID {
+		t.Errorf("token of user %d acted by %+v, want user %d acted by %d", claims.UserID, claims.Actor, customer.ID, admin.ID)
+	}
+	if ttl := time.Until(claims.ExpiresAt.Time); ttl > 15*time.Minute || ttl < 14*time.Minute {
+		t.Errorf("token expires in %v, want the configured 15m", ttl)
+	}
+
+	events, _, err := repos.audit.GetAll(repository.AuditFilter{Action: "user.impersonate", EntityID: customer.ID}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(events) != 1 || events[0].ActorID != admin.ID {
+		t.Errorf("impersonation audit events = %v, want one by the admin", events)
+	}
+}
+
+func TestImpersonateUserRestrictions(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestImpersonationService(t, repos)
+	admin := repos.createUser(t, "admin", entity.RoleSuperAdmin)
+	operator := repos.createUser(t, "operator", entity.RoleOrderOperator)
+	customer := repos.createUser(t, "customer", entity.RoleUser)
+	other := repos.createUser(t, "other", entity.RoleUser)
+	disabled := repos.createUser(t, "disabled", entity.RoleUser)
+	if err := repos.db.Model(disabled).Update("disabled_at", time.Now()).Error; err != nil {
+		t.Fatalf("disable user: %v", err)
+	}
+
+	// A context authenticated with an impersonation token of customer, acted by admin
+	impersonating := middleware.ContextWithUser(context.Background(), admin,
+		&helpers.JWTClaims{UserID: customer.ID, Actor: &helpers.ActorClaim{UserID: admin.ID}},
+		[]string{entity.PermissionUsersImpersonate})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		target uint
+	}{
+		{name: "without users:impersonate", ctx: repos.contextFor(t, operator), target: customer.ID},
+		{name: "yourself", ctx: repos.contextFor(t, admin), target: admin.ID},
+		{name: "staff account", ctx: repos.contextFor(t, admin), target: operator.ID},
+		{name: "disabled account", ctx: repos.contextFor(t, admin), target: disabled.ID},
+		{name: "unknown account", ctx: repos.contextFor(t, admin), target: disabled.ID + 100},
+		{name: "while impersonating", ctx: impersonating, target: other.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tokens, _, err := service.ImpersonateUser(tt.ctx, tt.target); err == nil {
+				t.Errorf("ImpersonateUser() = %+v, want an error", tokens)
+			}
+		})
+	}
+
+	if _, total, _ := repos.audit.GetAll(repository.AuditFilter{Action: "user.impersonate"}, 1, 10); total != 0 {
+		t.Errorf("%d impersonations audited, want none", total)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+)
+
+// LoginLockedError is returned by Login while the email or client IP is locked out
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %d seconds", int(e.RetryAfter.Seconds()))
+}
+
+// loginGuard tracks failed logins per email and per client IP and locks them
+// out with exponential backoff
+type loginGuard struct {
+	attemptRepo repository.LoginAttemptRepository
+	cfg         config.LoginLockoutConfig
+}
+
+func emailAttemptKey(email string) string {
+	return "email:" + helpers.NormalizeEmail(email)
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+// keys returns the attempt keys of a login, skipping the IP when it is unknown
+func (g *loginGuard) keys(email, ip string) []string {
+	keys := []string{emailAttemptKey(email)}
+	if ip != "" {
+		keys = append(keys, ipAttemptKey(ip))
+	}
+	return keys
+}
+
+// check returns a LoginLockedError if any of the keys is currently locked
+func (g *loginGuard) check(email, ip string) error {
+	attempts, err := g.attemptRepo.GetByKeys(g.keys(email, ip))
+	if err != nil {
+		return err
+	}
+
+	var retryAfter time.Duration
+	now := time.Now()
+	for _, attempt := range attempts {
+		if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			if remaining := attempt.LockedUntil.Sub(now); remaining > retryAfter {
+				retryAfter = remaining
+			}
+		}
+	}
+
+	if retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter.Round(time.Second)}
+	}
+	return nil
+}
+
+// recordFailure counts a failed login and locks the keys that reached their limit
+func (g *loginGuard) recordFailure(email, ip string) {
+	limits := map[string]int{emailAttemptKey(email): g.cfg.MaxAttemptsPerEmail}
+	if ip != "" {
+		limits[ipAttemptKey(ip)] = g.cfg.MaxAttemptsPerIP
+	}
+
+	for key, maxAttempts := range limits {
+		attempt, err := g.attemptRepo.RecordFailure(key, time.Now().Add(-g.cfg.Window))
+		if err != nil {
+			continue
+		}
+		if maxAttempts <= 0 || attempt.Failures < maxAttempts {
+			continue
+		}
+
+		lockout := g.lockoutDuration(attempt.Failures - maxAttempts)
+		if err := g.attemptRepo.Lock(key, time.Now().Add(lockout)); err != nil {
+			continue
+		}
+		logger.Warn("Login locked out after repeated failures", "key", key, "failures", attempt.Failures, "lockout", lockout.String())
+	}
+}
+
+// reset clears the failure counter of the email after a successful login. The IP
+// counter is left alone so one valid account cannot be used to reset it.
+func (g *loginGuard) reset(email string) {
+	g.attemptRepo.Reset(emailAttemptKey(email))
+}
+
+// lockoutDuration doubles the base lockout for every failure past the limit, up
+// to MaxLockout. A zero MaxLockout leaves the growth uncapped.
+func (g *loginGuard) lockoutDuration(excess int) time.Duration {
+	capped := g.cfg.MaxLockout > 0
+	lockout := g.cfg.BaseLockout
+	for i := 0; i < excess; i++ {
+		if capped && lockout >= g.cfg.MaxLockout {
+			break
+		}
+		// Stop short of overflowing into a negative duration
+		if lockout > math.MaxInt64/2 {
+			break
+		}
+		lockout *= 2
+	}
+	if capped && lockout > g.cfg.MaxLockout {
+		lockout = g.cfg.MaxLockout
+	}
+	return lockout
+}
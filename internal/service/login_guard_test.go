@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"google.golang.org/grpc/peer"
+)
+
+const testPassword = "a long enough passphrase 42"
+
+// newTestLoginService returns a user service locking logins out according to cfg
+func newTestLoginService(t *testing.T, repos *testRepos, cfg config.LoginLockoutConfig) *userServiceImpl {
+	t.Helper()
+
+	initTestTokens(t)
+	return NewUserService(repos.users, repos.tokens, repos.attempts, repos.mfa, repos.resets, repos.oidc, nil, nil,
+		cfg, config.MFAConfig{}, config.PasswordResetConfig{}, config.EmailVerificationConfig{}, config.OIDCConfig{}).(*userServiceImpl)
+}
+
+// createUserWithPassword stores a user who logs in with testPassword
+func (r *testRepos) createUserWithPassword(t *testing.T, name string) *entity.User {
+	t.Helper()
+
+	user := r.createUser(t, name, entity.RoleUser)
+	hash, err := helpers.HashPassword(testPassword)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if err := r.db.Model(user).Update("password", hash).Error; err != nil {
+		t.Fatalf("set password of %s: %v", name, err)
+	}
+	return user
+}
+
+// fromIP returns a context of a gRPC call from ip
+func fromIP(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000}})
+}
+
+// lockedFor returns how long key stays locked, or zero if it is not locked
+func (r *testRepos) lockedFor(t *testing.T, key string) time.Duration {
+	t.Helper()
+
+	attempts, err := r.attempts.GetByKeys([]string{key})
+	if err != nil {
+		t.Fatalf("GetByKeys: %v", err)
+	}
+	if len(attempts) == 0 || attempts[0].LockedUntil == nil {
+		return 0
+	}
+	return time.Until(*attempts[0].LockedUntil).Round(time.Second)
+}
+
+// expireLockout ends the lockout of key without resetting its failures
+func (r *testRepos) expireLockout(t *testing.T, key string) {
+	t.Helper()
+
+	if err := r.attempts.Lock(key, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+}
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		max    time.Duration
+		excess int
+		want   time.Duration
+	}{
+		{name: "first lockout", max: 10 * time.Minute, excess: 0, want: time.Minute},
+		{name: "doubles", max: 10 * time.Minute, excess: 3, want: 8 * time.Minute},
+		{name: "capped", max: 10 * time.Minute, excess: 4, want: 10 * time.Minute},
+		{name: "stays capped", max: 10 * time.Minute, excess: 50, want: 10 * time.Minute},
+		{name: "no cap", max: 0, excess: 10, want: 1024 * time.Minute},
+		{name: "no cap does not overflow", max: 0, excess: 100, want: time.Minute << 27},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := &loginGuard{cfg: config.LoginLockoutConfig{BaseLockout: time.Minute, MaxLockout: tt.max}}
+			if got := guard.lockoutDuration(tt.excess); got != tt.want {
+				t.Errorf("lockoutDuration(%d) = %v, want %v", tt.excess, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginLocksOutEmailWithBackoff(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestLoginService(t, repos, config.LoginLockoutConfig{
+		MaxAttemptsPerEmail: 3,
+		MaxAttemptsPerIP:    100,
+		Window:              time.Hour,
+		BaseLockout:         time.Minute,
+		MaxLockout:          time.Hour,
+	})
+	user := repos.createUserWithPassword(t, "reader")
+	repos.createUserWithPassword(t, "other")
+	key := emailAttemptKey(user.Email)
+
+	for i := 0; i < 3; i++ {
+		if _, err := service.Login(fromIP("192.0.2.1"), user.Email, "wrong password"); err == nil {
+			t.Fatal("Login accepted a wrong password")
+		}
+	}
+
+	// Locked from every IP, even with the right password
+	var locked *LoginLockedError
+	if _, err := service.Login(fromIP("198.51.100.1"), user.Email, testPassword); !errors.As(err, &locked) {
+		t.Fatalf("Login() after 3 failures = %v, want LoginLockedError", err)
+	}
+	if locked.RetryAfter != time.Minute {
+		t.Errorf("retry after %v, want %v", locked.RetryAfter, time.Minute)
+	}
+	if _, err := service.Login(fromIP("192.0.2.1"), "other@example.com", testPassword); err != nil {
+		t.Errorf("Login() of another email from the same IP: %v", err)
+	}
+
+	// Every further failure doubles the lockout
+	for _, want := range []time.Duration{2 * time.Minute, 4 * time.Minute} {
+		repos.expireLockout(t, key)
+		if _, err := service.Login(fromIP("192.0.2.1"), user.Email, "wrong password"); err == nil {
+			t.Fatal("Login accepted a wrong password")
+		}
+		if got := repos.lockedFor(t, key); got != want {
+			t.Errorf("locked for %v, want %v", got, want)
+		}
+	}
+
+	// A successful login clears the failures
+	repos.expireLockout(t, key)
+	if _, err := service.Login(fromIP("192.0.2.1"), user.Email, testPassword); err != nil {
+		t.Fatalf("Login() after the lockout expired: %v", err)
+	}
+	if _, err := service.Login(fromIP("192.0.2.1"), user.Email, "wrong password"); errors.As(err, &locked) {
+		t.Error("Login locked out again after a single failure")
+	}
+	if got := repos.lockedFor(t, key); got != 0 {
+		t.Errorf("locked for %v after a single failure, want no lockout", got)
+	}
+}
+
+func TestLoginLocksOutIP(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestLoginService(t, repos, config.LoginLockoutConfig{
+		MaxAttemptsPerEmail: 100,
+		MaxAttemptsPerIP:    3,
+		Window:              time.Hour,
+		BaseLockout:         time.Minute,
+		MaxLockout:          time.Hour,
+	})
+	user := repos.createUserWithPassword(t, "reader")
+
+	// Spraying different emails from one IP
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		if _, err := service.Login(fromIP("192.0.2.1"), email, "wrong password"); err == nil {
+			t.Fatalf("Login() of unknown %s succeeded", email)
+		}
+	}
+
+	var locked *LoginLockedError
+	if _, err := service.Login(fromIP("192.0.2.1"), user.Email, testPassword); !errors.As(err, &locked) {
+		t.Fatalf("Login() from a locked IP = %v, want LoginLockedError", err)
+	}
+	if _, err := service.Login(fromIP("198.51.100.1"), user.Email, testPassword); err != nil {
+		t.Errorf("Login() from another IP: %v", err)
+	}
+
+	// Logging into a valid account does not reset the IP
+	repos.expireLockout(t, ipAttemptKey("192.0.2.1"))
+	if _, err := service.Login(fromIP("192.0.2.1"), user.Email, testPassword); err != nil {
+		t.Fatalf("Login() after the lockout expired: %v", err)
+	}
+	if _, err := service.Login(fromIP("192.0.2.1"), "d@example.com", "wrong password"); err == nil {
+		t.Fatal("Login() of an unknown email succeeded")
+	}
+	if got := repos.lockedFor(t, ipAttemptKey("192.0.2.1")); got != 2*time.Minute {
+		t.Errorf("IP locked for %v, want %v", got, 2*time.Minute)
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+const (
+	// mfaChallengeTTL is how long a user has to complete VerifyMFA after Login
+	mfaChallengeTTL = 5 * time.Minute
+	// mfaEnrollmentTTL is how long a token restricted to MFA enrollment stays valid
+	mfaEnrollmentTTL = 10 * time.Minute
+	// recoveryCodeCount is the number of recovery codes handed out on enrollment
+	recoveryCodeCount = 10
+)
+
+// mfaEnrollmentRequired reports whether the user must enroll in MFA before getting a full token
+func (s *userServiceImpl) mfaEnrollmentRequired(user *entity.User) bool {
+	return s.mfaCfg.RequiredForStaff && user.IsStaff() && !user.MFAEnabled()
+}
+
+// EnrollMFA generates a new TOTP secret for the caller. The secret is pending
+// until ConfirmMFA proves that the authenticator app was set up correctly.
+func (s *userServiceImpl) EnrollMFA(ctx context.Context) (string, string, error) {
+	logger.Info("Starting MFA enrollment")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("MFA enrollment failed - unauthenticated", "error", err)
+		return "", "", err
+	}
+
+	if user.MFAEnabled() {
+		logger.Error("MFA enrollment failed - already enabled", "userID", user.ID)
+		return "", "", errors.New("multi-factor authentication is already enabled")
+	}
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		logger.Error("Failed to generate TOTP secret", "userID", user.ID, "error", err)
+		return "", "", err
+	}
+
+	user.MFASecret = secret
+	user.MFALastUsedStep = 0
+	if err := s.userRepo.UpdateMFA(user); err != nil {
+		logger.Error("Failed to store pending TOTP secret", "userID", user.ID, "error", err)
+		return "", "", err
+	}
+
+	logger.Info("MFA enrollment started", "userID", user.ID)
+	return secret, helpers.TOTPProvisioningURI(s.mfaCfg.Issuer, user.Email, secret), nil
+}
+
+// ConfirmMFA enables MFA once the caller proves possession of the pending
+// secret. It returns the recovery codes, which are only shown this once, and a
+// fresh unrestricted token pair.
+func (s *userServiceImpl) ConfirmMFA(ctx context.Context, code string) ([]string, *AuthTokens, error) {
+	logger.Info("Starting MFA confirmation")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("MFA confirmation failed - unauthenticated", "error", err)
+		return nil, nil, err
+	}
+
+	if user.MFAEnabled() {
+		logger.Error("MFA confirmation failed - already enabled", "userID", user.ID)
+		return nil, nil, errors.New("multi-factor authentication is already enabled")
+	}
+	if user.MFASecret == "" {
+		logger.Error("MFA confirmation failed - enrollment not started", "userID", user.ID)
+		return nil, nil, errors.New("multi-factor authentication enrollment has not been started")
+	}
+
+	step, ok := helpers.ValidateTOTP(user.MFASecret, code, time.Now(), user.MFALastUsedStep)
+	if !ok {
+		logger.Error("MFA confirmation failed - invalid code", "userID", user.ID)
+		return nil, nil, errors.New("invalid verification code")
+	}
+
+	recoveryCodes, err := helpers.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		logger.Error("Failed to generate recovery codes", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+
+	stored := make([]*entity.MFARecoveryCode, 0, len(recoveryCodes))
+	for _, recoveryCode := range recoveryCodes {
+		stored = append(stored, &entity.MFARecoveryCode{
+			UserID:   user.ID,
+			CodeHash: helpers.HashToken(helpers.NormalizeRecoveryCode(recoveryCode)),
+		})
+	}
+	if err := s.mfaRepo.ReplaceRecoveryCodes(user.ID, stored); err != nil {
+		logger.Error("Failed to store recovery codes", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	user.MFAEnabledAt = &now
+	user.MFALastUsedStep = step
+	if err := s.userRepo.UpdateMFA(user); err != nil {
+		logger.Error("Failed to enable MFA", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+
+	// Tokens restricted to enrollment are no longer needed
+	if claims, ok := middleware.ClaimsFromContext(ctx); ok && claims.Scope == helpers.ScopeMFAEnrollment {
+		if err := s.tokenRepo.RevokeAccessToken(claims.ID, user.ID, claims.ExpiresAt.Time); err != nil {
+			logger.Error("Failed to revoke MFA enrollment token", "userID", user.ID, "error", err)
+		}
+	}
+
+	tokens, err := s.issueTokens(ctx, user)
+	if err != nil {
+		logger.Error("Failed to generate token after MFA confirmation", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+
+	logger.Info("MFA enabled successfully", "userID", user.ID)
+	return recoveryCodes, tokens, nil
+}
+
+// VerifyMFA completes a login that returned an MFA challenge. code is either
+// the current TOTP code or one of the unused recovery codes.
+func (s *userServiceImpl) VerifyMFA(ctx context.Context, mfaToken, code string) (*AuthTokens, *entity.User, error) {
+	clientIP := middleware.ClientIP(ctx)
+	logger.Info("Starting MFA verification", "ip", clientIP)
+
+	claims, err := helpers.ValidateToken(mfaToken)
+	if err != nil || claims.Scope != helpers.ScopeMFAChallenge {
+		logger.Error("MFA verification failed - invalid challenge token", "error", err)
+		return nil, nil, errors.New("invalid or expired MFA challenge")
+	}
+
+	revoked, err := s.tokenRepo.IsAccessTokenRevoked(claims.ID)
+	if err != nil {
+		logger.Error("Failed to check MFA challenge revocation", "userID", claims.UserID, "error", err)
+		return nil, nil, err
+	}
+	if revoked {
+		logger.Error("MFA verification failed - challenge already used", "userID", claims.UserID)
+		return nil, nil, errors.New("invalid or expired MFA challenge")
+	}
+
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil || user.TokenVersion != claims.TokenVersion || user.IsDisabled() || !user.MFAEnabled() {
+		logger.Error("MFA verification failed - user no longer eligible", "userID", claims.UserID, "error", err)
+		return nil, nil, errors.New("invalid or expired MFA challenge")
+	}
+
+	// Wrong codes count towards the same lockout as wrong passwords
+	if err := s.loginGuard.check(user.Email, clientIP); err != nil {
+		logger.Warn("MFA verification rejected - locked out", "userID", user.ID, "ip", clientIP, "error", err)
+		return nil, nil, err
+	}
+
+	if step, ok := helpers.ValidateTOTP(user.MFASecret, code, time.Now(), user.MFALastUsedStep); ok {
+		// Another request may have used the same code since the user was read
+		used, err := s.userRepo.UseTOTPStep(user.ID, step)
+		if err != nil {
+			logger.Error("Failed to store last used TOTP step", "userID", user.ID, "error", err)
+			return nil, nil, err
+		}
+		if !used {
+			logger.Error("MFA verification failed - code already used", "userID", user.ID)
+			return nil, nil, errors.New("invalid verification code")
+		}
+		user.MFALastUsedStep = step
+	} else {
+		used, err := s.mfaRepo.UseRecoveryCode(user.ID, helpers.HashToken(helpers.NormalizeRecoveryCode(code)))
+		if err != nil {
+			return nil, nil, err
+		}
+		if !used {
+			logger.Error("MFA verification failed - invalid code", "userID", user.ID)
+			s.loginGuard.recordFailure(user.Email, clientIP)
+			return nil, nil, errors.New("invalid verification code")
+		}
+		logger.Warn("MFA verification completed with a recovery code", "userID", user.ID)
+	}
+
+	s.loginGuard.reset(user.Email)
+
+	// A challenge can only be completed once
+	consumed, err := s.tokenRepo.ConsumeAccessToken(claims.ID, user.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		logger.Error("Failed to revoke MFA challenge token", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+	if !consumed {
+		logger.Error("MFA verification failed - challenge already used", "userID", user.ID)
+		return nil, nil, errors.New("invalid or expired MFA challenge")
+	}
+
+	tokens, err := s.issueTokens(ctx, user)
+	if err != nil {
+		logger.Error("Failed to generate token after MFA verification", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+
+	logger.Info("MFA verification successful", "userID", user.ID)
+	return tokens, user, nil
+}
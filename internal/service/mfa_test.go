@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+// totpCode returns the TOTP code of secret at now, as an authenticator app shows it
+func totpCode(t *testing.T, secret string, now time.Time) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("decode TOTP secret: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(now.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", (binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff)%1000000)
+}
+
+// enableMFA turns on MFA for user with a new secret and returns the secret
+func (r *testRepos) enableMFA(t *testing.T, user *entity.User) string {
+	t.Helper()
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret: %v", err)
+	}
+	now := time.Now()
+	user.MFASecret = secret
+	user.MFAEnabledAt = &now
+	if err := r.users.UpdateMFA(user); err != nil {
+		t.Fatalf("UpdateMFA: %v", err)
+	}
+	return secret
+}
+
+// mfaChallenge returns the MFA challenge a password login hands out to user
+func mfaChallenge(t *testing.T, user *entity.User) string {
+	t.Helper()
+
+	token, err := helpers.GenerateScopedToken(user.ID, user.Email, user.Role, user.TokenVersion, helpers.ScopeMFAChallenge, mfaChallengeTTL)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken: %v", err)
+	}
+	return token
+}
+
+func TestEnrollMFAKeepsConcurrentChanges(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	ctx := middleware.ContextWithUser(context.Background(), user, nil, nil)
+
+	// The user in the context is a stale copy once the profile changes
+	if err := repos.db.Model(&entity.User{}).Where("id = ?", user.ID).Update("name", "Renamed").Error; err != nil {
+		t.Fatalf("rename user: %v", err)
+	}
+
+	secret, _, err := service.EnrollMFA(ctx)
+	if err != nil {
+		t.Fatalf("EnrollMFA: %v", err)
+	}
+	if after := repos.reload(t, user); after.Name != "Renamed" || after.MFASecret != secret {
+		t.Errorf("user after EnrollMFA = name %q, secret stored %v, want the rename kept and the secret stored", after.Name, after.MFASecret == secret)
+	}
+
+	if _, _, err := service.ConfirmMFA(ctx, totpCode(t, secret, time.Now())); err != nil {
+		t.Fatalf("ConfirmMFA: %v", err)
+	}
+	if after := repos.reload(t, user); after.Name != "Renamed" || !after.MFAEnabled() || after.MFALastUsedStep == 0 {
+		t.Errorf("user after ConfirmMFA = name %q, MFA enabled %v, last step %d, want the rename kept and MFA enabled", after.Name, after.MFAEnabled(), after.MFALastUsedStep)
+	}
+}
+
+func TestVerifyMFARejectsReplayedCode(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	secret := repos.enableMFA(t, user)
+	code := totpCode(t, secret, time.Now())
+
+	if _, _, err := service.VerifyMFA(context.Background(), mfaChallenge(t, user), code); err != nil {
+		t.Fatalf("VerifyMFA: %v", err)
+	}
+	if _, _, err := service.VerifyMFA(context.Background(), mfaChallenge(t, user), code); err == nil {
+		t.Error("VerifyMFA accepted the same code for a second challenge")
+	}
+}
+
+func TestVerifyMFAConcurrentReplay(t *testing.T) {
+	const attempts = 8
+
+	tests := []struct {
+		name string
+		// sameChallenge is whether every attempt presents the same challenge
+		sameChallenge bool
+		// recoveryCodes is whether every attempt uses a different recovery code
+		// instead of the same TOTP code
+		recoveryCodes bool
+	}{
+		{name: "same code, same challenge", sameChallenge: true},
+		{name: "same code, different challenges"},
+		{name: "different recovery codes, same challenge", sameChallenge: true, recoveryCodes: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos(t)
+			service := newTestUserService(t, repos)
+			user := repos.createUser(t, "reader", entity.RoleUser)
+			secret := repos.enableMFA(t, user)
+
+			codes := make([]string, attempts)
+			challenges := make([]string, attempts)
+			stored := make([]*entity.MFARecoveryCode, 0, attempts)
+			for i := range codes {
+				codes[i] = totpCode(t, secret, time.Now())
+				if tt.recoveryCodes {
+					codes[i] = fmt.Sprintf("code%d-recovery", i)
+					stored = append(stored, &entity.MFARecoveryCode{UserID: user.ID, CodeHash: helpers.HashToken(helpers.NormalizeRecoveryCode(codes[i]))})
+				}
+				challenges[i] = mfaChallenge(t, user)
+				if tt.sameChallenge {
+					challenges[i] = challenges[0]
+				}
+			}
+			if tt.recoveryCodes {
+				if err := repos.mfa.ReplaceRecoveryCodes(user.ID, stored); err != nil {
+					t.Fatalf("ReplaceRecoveryCodes: %v", err)
+				}
+			}
+
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			succeeded := 0
+			start := make(chan struct{})
+			for i := 0; i < attempts; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					<-start
+					if _, _, err := service.VerifyMFA(context.Background(), challenges[i], codes[i]); err == nil {
+						mu.Lock()
+						succeeded++
+						mu.Unlock()
+					}
+				}(i)
+			}
+			close(start)
+			wg.Wait()
+
+			if succeeded != 1 {
+				t.Errorf("%d of %d concurrent verifications succeeded, want exactly 1", succeeded, attempts)
+			}
+			if n := repos.activeSessions(t, user); n != 1 {
+				t.Errorf("%d sessions started, want 1", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/oidc"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// ErrUnknownOIDCProvider is returned for provider names that are not configured
+var ErrUnknownOIDCProvider = errors.New("unknown identity provider")
+
+// errOIDCLoginFailed hides why an OIDC login failed from the client; the reason is logged
+var errOIDCLoginFailed = errors.New("sign in with the identity provider failed")
+
+// BeginOIDCLogin starts a sign in with an external OpenID Connect provider. The
+// client sends the user to authURL and passes the code and state the provider
+// redirects back with to CompleteOIDCLogin.
+func (s *userServiceImpl) BeginOIDCLogin(ctx context.Context, providerName string) (string, string, error) {
+	logger.Info("Starting OIDC login", "provider", providerName)
+
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		logger.Error("OIDC login failed - unknown provider", "provider", providerName)
+		return "", "", ErrUnknownOIDCProvider
+	}
+
+	state, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	authURL, err := provider.AuthCodeURL(state, nonce, verifier)
+	if err != nil {
+		logger.Error("Failed to build OIDC authorization URL", "provider", providerName, "error", err)
+		return "", "", err
+	}
+
+	err = s.oidcRepo.CreateState(&entity.OIDCLoginState{
+		StateHash:    helpers.HashToken(state),
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(s.oidcCfg.StateTTL),
+	})
+	if err != nil {
+		logger.Error("Failed to store OIDC login state", "provider", providerName, "error", err)
+		return "", "", err
+	}
+
+	logger.Info("OIDC login started", "provider", providerName)
+	return authURL, state, nil
+}
+
+// CompleteOIDCLogin exchanges the authorization code for a verified ID token
+// and logs in the user linked to it. Unknown identities are linked to the user
+// with the same verified email, or get a new account without a password.
+func (s *userServiceImpl) CompleteOIDCLogin(ctx context.Context, state, code string) (*LoginResult, error) {
+	logger.Info("Completing OIDC login")
+
+	loginState, err := s.oidcRepo.ConsumeState(helpers.HashToken(state))
+	if err != nil {
+		logger.Error("OIDC login failed - invalid or expired state", "error", err)
+		return nil, errors.New("invalid or expired login state")
+	}
+
+	provider, ok := s.oidcProviders[loginState.Provider]
+	if !ok {
+		logger.Error("OIDC login failed - provider no longer configured", "provider", loginState.Provider)
+		return nil, ErrUnknownOIDCProvider
+	}
+
+	identity, err := provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		logger.Error("OIDC login failed - code exchange", "provider", loginState.Provider, "error", err)
+		return nil, errOIDCLoginFailed
+	}
+
+	user, err := s.oidcUser(loginState.Provider, identity)
+	if err != nil {
+		logger.Error("OIDC login failed", "provider", loginState.Provider, "subject", identity.Subject, "error", err)
+		return nil, err
+	}
+
+	if user.IsDisabled() {
+		logger.Error("OIDC login failed - account is disabled", "userID", user.ID)
+		return nil, errors.New("account is disabled")
+	}
+
+	logger.Info("OIDC identity verified", "provider", loginState.Provider, "userID", user.ID)
+	return s.completeLogin(ctx, user)
+}
+
+// oidcUser returns the user linked to identity, linking or creating one on the
+// first sign in with it
+func (s *userServiceImpl) oidcUser(providerName string, identity *oidc.Identity) (*entity.User, error) {
+	now := time.Now()
+	identity.Email = helpers.NormalizeEmail(identity.Email)
+
+	linked, err := s.oidcRepo.GetIdentity(providerName, identity.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if linked != nil {
+		linked.Email = identity.Email
+		linked.LastLoginAt = &now
+		if err := s.oidcRepo.UpdateIdentity(linked); err != nil {
+			return nil, err
+		}
+		return s.userRepo.GetByID(linked.UserID)
+	}
+
+	// Linking by email is only safe when the provider vouches for the address
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errors.New("the identity provider did not return a verified email address")
+	}
+
+	user, err := s.userRepo.GetByEmail(identity.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if user == nil {
+		user, err = s.createOIDCUser(identity)
+		if err != nil {
+			return nil, err
+		}
+	} else if !user.IsEmailVerified() {
+		// Whoever registered this address never proved owning it. Their
+		// credentials are dropped so they cannot share the account with its
+		// real owner.
+		if err := s.takeOverUnverifiedUser(user); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.oidcRepo.CreateIdentity(&entity.ExternalIdentity{
+		UserID:      user.ID,
+		Provider:    providerName,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("External identity linked", "provider", providerName, "userID", user.ID)
+	return user, nil
+}
+
+// createOIDCUser creates an account for a new identity. The account has no
+// password; one can be set through RequestPasswordReset.
+func (s *userServiceImpl) createOIDCUser(identity *oidc.Identity) (*entity.User, error) {
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+
+	now := time.Now()
+	user := &entity.User{
+		Name:            name,
+		Email:           identity.Email,
+		Password:        entity.NoPassword,
+		Role:            entity.RoleUser,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.userRepo.Create(user); err != nil {
+		return nil, err
+	}
+
+	logger.Info("User created from external identity", "userID", user.ID, "email", user.Email)
+	return user, nil
+}
+
+// takeOverUnverifiedUser hands an account whose email was never verified to the
+// owner of the address as proven by the identity provider
+func (s *userServiceImpl) takeOverUnverifiedUser(user *entity.User) error {
+	now := time.Now()
+	user.Password = entity.NoPassword
+	user.MFASecret = ""
+	user.MFAEnabledAt = nil
+	user.MFALastUsedStep = 0
+	user.EmailVerifiedAt = &now
+	user.TokenVersion++
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllSessions(user.ID); err != nil {
+		return err
+	}
+	if err := s.mfaRepo.DeleteRecoveryCodes(user.ID); err != nil {
+		return err
+	}
+	if err := s.resetRepo.InvalidateForUser(user.ID); err != nil {
+		return err
+	}
+
+	logger.Warn("Unverified account taken over by the verified owner of its email", "userID", user.ID)
+	return nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"github.com/nabil/book-store-system/pkg/oidc"
+	"github.com/nabil/book-store-system/pkg/oidc/oidctest"
+	"gorm.io/gorm"
+)
+
+// oidcTestEnv is a user and privacy service signing in through a mock
+// identity provider
+type oidcTestEnv struct {
+	db      *gorm.DB
+	idp     *oidctest.Server
+	users   UserService
+	privacy PrivacyService
+}
+
+func newOIDCTestEnv(t *testing.T) *oidcTestEnv {
+	t.Helper()
+
+	initTestTokens(t)
+	repos := newTestRepos(t)
+
+	idp, err := oidctest.NewServer()
+	if err != nil {
+		t.Fatalf("start mock identity provider: %v", err)
+	}
+	t.Cleanup(idp.Close)
+
+	oidcCfg := config.OIDCConfig{
+		Providers: []config.OIDCProviderConfig{{
+			Name:         "mock",
+			IssuerURL:    idp.URL,
+			ClientID:     oidctest.ClientID,
+			ClientSecret: oidctest.ClientSecret,
+			RedirectURL:  "http://localhost:3000/callback",
+			Scopes:       []string{"openid", "email", "profile"},
+		}},
+		StateTTL: 10 * time.Minute,
+	}
+
+	return &oidcTestEnv{
+		db:  repos.db,
+		idp: idp,
+		users: NewUserService(repos.users, repos.tokens, repos.attempts, repos.mfa, repos.resets, repos.oidc, nil,
+			oidc.NewProviders(oidcCfg), config.LoginLockoutConfig{}, config.MFAConfig{}, config.PasswordResetConfig{}, config.EmailVerificationConfig{}, oidcCfg),
+		privacy: NewPrivacyService(repos.users, repos.roles, repos.tokens, repos.mfa, repos.resets, repos.orders, repos.addresses, repos.oidc, repos.tx, repos.audit),
+	}
+}
+
+// signIn runs a complete OIDC login of user
+func (env *oidcTestEnv) signIn(t *testing.T, user oidctest.User) *LoginResult {
+	t.Helper()
+
+	authURL, state, err := env.users.BeginOIDCLogin(context.Background(), "mock")
+	if err != nil {
+		t.Fatalf("BeginOIDCLogin: %v", err)
+	}
+	code, returnedState, err := env.idp.Login(authURL, user)
+	if err != nil {
+		t.Fatalf("sign in at the identity provider: %v", err)
+	}
+	if returnedState != state {
+		t.Fatalf("provider returned state %q, want %q", returnedState, state)
+	}
+
+	result, err := env.users.CompleteOIDCLogin(context.Background(), state, code)
+	if err != nil {
+		t.Fatalf("CompleteOIDCLogin: %v", err)
+	}
+	return result
+}
+
+// authenticated returns a context carrying the user and claims of an access token
+func authenticated(t *testing.T, user *entity.User, accessToken string) context.Context {
+	t.Helper()
+
+	claims, err := helpers.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	return middleware.ContextWithUser(context.Background(), user, claims, nil)
+}
+
+func TestOIDCLoginCreatesAndReusesAccount(t *testing.T) {
+	env := newOIDCTestEnv(t)
+	idpUser := oidctest.User{Subject: "subject-1", Email: "reader@example.com", EmailVerified: true, Name: "Reader"}
+
+	first := env.signIn(t, idpUser)
+	if first.Tokens == nil || first.Tokens.AccessToken == "" || first.Tokens.RefreshToken == "" {
+		t.Fatalf("CompleteOIDCLogin() tokens = %+v, want access and refresh token", first.Tokens)
+	}
+	if first.User.Email != "reader@example.com" || first.User.Name != "Reader" || !first.User.IsEmailVerified() {
+		t.Errorf("created user = %+v, want verified reader@example.com named Reader", first.User)
+	}
+	if first.User.HasPassword() {
+		t.Error("user created from an external identity has a password")
+	}
+
+	second := env.signIn(t, idpUser)
+	if second.User.ID != first.User.ID {
+		t.Errorf("second sign in returned user %d, want %d", second.User.ID, first.User.ID)
+	}
+}
+
+func TestOIDCLoginRequiresVerifiedEmail(t *testing.T) {
+	env := newOIDCTestEnv(t)
+
+	authURL, state, err := env.users.BeginOIDCLogin(context.Background(), "mock")
+	if err != nil {
+		t.Fatalf("BeginOIDCLogin: %v", err)
+	}
+	code, _, err := env.idp.Login(authURL, oidctest.User{Subject: "subject-1", Email: "reader@example.com"})
+	if err != nil {
+		t.Fatalf("sign in at the identity provider: %v", err)
+	}
+
+	if _, err := env.users.CompleteOIDCLogin(context.Background(), state, code); err == nil {
+		t.Error("CompleteOIDCLogin linked an identity without a verified email")
+	}
+
+	// The state is single use
+	if _, err := env.users.CompleteOIDCLogin(context.Background(), state, code); err == nil {
+		t.Error("CompleteOIDCLogin accepted a state twice")
+	}
+}
+
+func TestDeleteMyAccountWithoutPassword(t *testing.T) {
+	env := newOIDCTestEnv(t)
+	result := env.signIn(t, oidctest.User{Subject: "subject-1", Email: "reader@example.com", EmailVerified: true})
+	ctx := authenticated(t, result.User, result.Tokens.AccessToken)
+
+	// A session started longer ago than the window must sign in again
+	claims, _ := middleware.ClaimsFromContext(ctx)
+	started := time.Now().Add(-reauthenticationWindow - time.Minute)
+	if err := env.db.Model(&entity.Session{}).Where("id = ?", claims.SessionID).Update("created_at", started).Error; err != nil {
+		t.Fatalf("age session: %v", err)
+	}
+	if err := env.privacy.DeleteMyAccount(ctx, ""); !errors.Is(err, ErrReauthenticationRequired) {
+		t.Fatalf("DeleteMyAccount() with an old session = %v, want ErrReauthenticationRequired", err)
+	}
+
+	// Signing in again through the identity provider confirms the deletion
+	result = env.signIn(t, oidctest.User{Subject: "subject-1", Email: "reader@example.com", EmailVerified: true})
+	ctx = authenticated(t, result.User, result.Tokens.AccessToken)
+	if err := env.privacy.DeleteMyAccount(ctx, ""); err != nil {
+		t.Fatalf("DeleteMyAccount() after signing in again: %v", err)
+	}
+
+	var deleted entity.User
+	if err := env.db.First(&deleted, result.User.ID).Error; err != nil {
+		t.Fatalf("load deleted user: %v", err)
+	}
+	if !deleted.IsAnonymized() || deleted.Email == "reader@example.com" {
+		t.Errorf("user after deletion = %+v, want anonymized", deleted)
+	}
+	var identities int64
+	env.db.Model(&entity.ExternalIdentity{}).Where("user_id = ?", deleted.ID).Count(&identities)
+	if identities != 0 {
+		t.Errorf("%d external identities still linked after deletion", identities)
+	}
+}
+
+func TestOIDCLoginLinksEmailsIgnoringCase(t *testing.T) {
+	env := newOIDCTestEnv(t)
+
+	// An account registered before emails were normalized
+	now := time.Now()
+	existing := &entity.User{Name: "Reader", Email: "Reader@Example.com", Password: entity.NoPassword, Role: entity.RoleUser, EmailVerifiedAt: &now}
+	if err := env.db.Create(existing).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	result := env.signIn(t, oidctest.User{Subject: "subject-1", Email: " READER@example.COM", EmailVerified: true})
+	if result.User.ID != existing.ID {
+		t.Errorf("sign in with the email in another case returned user %d, want %d", result.User.ID, existing.ID)
+	}
+
+	created := env.signIn(t, oidctest.User{Subject: "subject-2", Email: "New.Reader@Example.com", EmailVerified: true})
+	if created.User.Email != "new.reader@example.com" {
+		t.Errorf("created user has email %q, want it lowercased", created.User.Email)
+	}
+	var users int64
+	env.db.Model(&entity.User{}).Count(&users)
+	if users != 2 {
+		t.Errorf("%d users after signing in, want 2", users)
+	}
+}
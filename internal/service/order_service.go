@@ -1,10 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
 
+	"github.com/nabil/book-store-system/config"
 	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/internal/repository"
 	"github.com/nabil/book-store-system/pkg/helpers"
@@ -13,6 +15,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrEmailNotVerified is returned when an unverified user tries to order or pay
+var ErrEmailNotVerified = errors.New("email address must be verified before ordering")
+
 // OrderItem represents an item in an order request
 type OrderItem struct {
 	BookID   uint `json:"book_id"`
@@ -20,43 +25,79 @@ type OrderItem struct {
 }
 
 type OrderService interface {
-	CreateOrder(items []OrderItem, token string) (*entity.Order, error)
-	GetOrders(token string, page, limit int) ([]*entity.Order, int64, error)
-	GetOrder(id uint, token string) (*entity.Order, error)
-	UpdateOrderStatus(id uint, status, token string) (*entity.Order, error)
-	ProcessPayment(orderID uint, token string) (string, error)
-	GetAllOrders(token string, page, limit int) ([]*entity.Order, int64, error)
+	CreateOrder(ctx context.Context, items []OrderItem, addressID uint) (*entity.Order, error)
+	GetOrders(ctx context.Context, page, limit int) ([]*entity.Order, int64, error)
+	GetOrder(ctx context.Context, id uint) (*entity.Order, error)
+	UpdateOrderStatus(ctx context.Context, id uint, status string) (*entity.Order, error)
+	ProcessPayment(ctx context.Context, orderID uint) (string, error)
+	GetAllOrders(ctx context.Context, page, limit int) ([]*entity.Order, int64, error)
 }
 
 // orderServiceImpl implements the OrderService interface
 type orderServiceImpl struct {
-	orderRepo  repository.OrderRepository
-	bookRepo   repository.BookRepository
-	userRepo   repository.UserRepository
-	txRepo     repository.TransactionRepository
-	auth       *middleware.AuthMiddleware
-	stockMutex sync.RWMutex
+	orderRepo       repository.OrderRepository
+	bookRepo        repository.BookRepository
+	addressRepo     repository.AddressRepository
+	txRepo          repository.TransactionRepository
+	auditRepo       repository.AuditRepository
+	verificationCfg config.EmailVerificationConfig
+	stockMutex      sync.RWMutex
 }
 
-func NewOrderService(orderRepo repository.OrderRepository, bookRepo repository.BookRepository, userRepo repository.UserRepository, txRepo repository.TransactionRepository) OrderService {
-	auth := middleware.NewAuthMiddleware(userRepo)
+func NewOrderService(orderRepo repository.OrderRepository, bookRepo repository.BookRepository, addressRepo repository.AddressRepository, txRepo repository.TransactionRepository, auditRepo repository.AuditRepository, verificationCfg config.EmailVerificationConfig) OrderService {
 	return &orderServiceImpl{
-		orderRepo: orderRepo,
-		bookRepo:  bookRepo,
-		userRepo:  userRepo,
-		auth:      auth,
-		txRepo:    txRepo,
+		orderRepo:       orderRepo,
+		bookRepo:        bookRepo,
+		addressRepo:     addressRepo,
+		txRepo:          txRepo,
+		auditRepo:       auditRepo,
+		verificationCfg: verificationCfg,
+	}
+}
+
+// requireVerifiedEmail rejects users whose email address is not verified yet
+func (s *orderServiceImpl) requireVerifiedEmail(user *entity.User) error {
+	if s.verificationCfg.RequiredForOrders && !user.IsEmailVerified() {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// shippingAddress resolves the address an order is shipped to: the given
+// address of the user, or their default address when addressID is 0. Users
+// without any saved address get nil.
+func (s *orderServiceImpl) shippingAddress(user *entity.User, addressID uint) (*entity.Address, error) {
+	if addressID == 0 {
+		address, err := s.addressRepo.GetDefault(user.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return address, err
+	}
+
+	address, err := s.addressRepo.GetByID(addressID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err != nil || address.UserID != user.ID {
+		return nil, ErrAddressNotFound
 	}
+	return address, nil
 }
 
-// CreateOrder creates a new order with items
-func (s *orderServiceImpl) CreateOrder(items []OrderItem, token string) (*entity.Order, error) {
-	logger.Info("Starting order creation", "itemCount", len(items))
+// CreateOrder creates a new order with items. The shipping address is copied
+// onto the order so later edits to the address book do not change it.
+func (s *orderServiceImpl) CreateOrder(ctx context.Context, items []OrderItem, addressID uint) (*entity.Order, error) {
+	logger.Info("Starting order creation", "itemCount", len(items), "addressID", addressID)
 
-	// Validate user token
-	user, err := s.auth.ValidateUserToken(token)
+	user, err := currentUser(ctx)
 	if err != nil {
-		logger.Error("Order creation failed - invalid user token", "error", err)
+		logger.Error("Order creation failed - unauthenticated", "error", err)
+		return nil, err
+	}
+
+	if err := s.requireVerifiedEmail(user); err != nil {
+		logger.Error("Order creation failed - email not verified", "userID", user.ID)
 		return nil, err
 	}
 
@@ -65,6 +106,12 @@ func (s *orderServiceImpl) CreateOrder(items []OrderItem, token string) (*entity
 		return nil, errors.New("order must contain at least one item")
 	}
 
+	address, err := s.shippingAddress(user, addressID)
+	if err != nil {
+		logger.Error("Order creation failed - invalid shipping address", "userID", user.ID, "addressID", addressID, "error", err)
+		return nil, err
+	}
+
 	var totalAmount float64
 	var orderItems []*entity.OrderItem
 
@@ -112,6 +159,10 @@ func (s *orderServiceImpl) CreateOrder(items []OrderItem, token string) (*entity
 		TotalPrice: totalAmount,
 		Status:     "pending",
 	}
+	if address != nil {
+		order.AddressID = &address.ID
+		order.ShippingAddress = address.AddressFields
+	}
 
 	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
 		// Create order with transaction
@@ -124,7 +175,7 @@ func (s *orderServiceImpl) CreateOrder(items []OrderItem, token string) (*entity
 		for _, item := range items {
 			// Lock untuk melindungi operasi read-modify-write pada stock
 			s.stockMutex.Lock()
-			
+
 			book, err := s.bookRepo.GetByID(item.BookID)
 			if err != nil {
 				s.stockMutex.Unlock()
@@ -145,7 +196,7 @@ func (s *orderServiceImpl) CreateOrder(items []OrderItem, token string) (*entity
 				logger.Error("Failed to update book stock in transaction", "orderID", order.ID, "bookID", item.BookID, "error", err)
 				return err
 			}
-			
+
 			s.stockMutex.Unlock()
 			logger.Info("Stock updated successfully", "bookID", item.BookID, "oldStock", book.Stock, "newStock", newStock)
 		}
@@ -170,13 +221,12 @@ func (s *orderServiceImpl) CreateOrder(items []OrderItem, token string) (*entity
 }
 
 // GetOrders retrieves orders for a user with pagination
-func (s *orderServiceImpl) GetOrders(token string, page, limit int) ([]*entity.Order, int64, error) {
+func (s *orderServiceImpl) GetOrders(ctx context.Context, page, limit int) ([]*entity.Order, int64, error) {
 	logger.Info("Getting user orders", "page", page, "limit", limit)
 
-	// Validate user token
-	user, err := s.auth.ValidateUserToken(token)
+	user, err := currentUser(ctx)
 	if err != nil {
-		logger.Error("Failed to get orders - invalid user token", "error", err)
+		logger.Error("Failed to get orders - unauthenticated", "error", err)
 		return nil, 0, err
 	}
 
@@ -191,13 +241,12 @@ func (s *orderServiceImpl) GetOrders(token string, page, limit int) ([]*entity.O
 }
 
 // GetOrder retrieves a specific order by ID
-func (s *orderServiceImpl) GetOrder(id uint, token string) (*entity.Order, error) {
+func (s *orderServiceImpl) GetOrder(ctx context.Context, id uint) (*entity.Order, error) {
 	logger.Info("Getting order by ID", "orderID", id)
 
-	// Validate user token
-	user, err := s.auth.ValidateUserToken(token)
+	user, err := currentUser(ctx)
 	if err != nil {
-		logger.Error("Failed to get order - invalid user token", "orderID", id, "error", err)
+		logger.Error("Failed to get order - unauthenticated", "orderID", id, "error", err)
 		return nil, err
 	}
 
@@ -208,8 +257,8 @@ func (s *orderServiceImpl) GetOrder(id uint, token string) (*entity.Order, error
 		return nil, err
 	}
 
-	// Check if user owns the order or is admin
-	if order.UserID != user.ID && user.Role != "admin" {
+	// Check if user owns the order or may read every order
+	if order.UserID != user.ID && !middleware.HasPermission(ctx, entity.PermissionOrdersRead) {
 		logger.Error("Order access denied", "orderID", id, "userID", user.ID, "orderOwnerID", order.UserID, "userRole", user.Role)
 		return nil, errors.New("access denied")
 	}
@@ -218,14 +267,12 @@ func (s *orderServiceImpl) GetOrder(id uint, token string) (*entity.Order, error
 	return order, nil
 }
 
-// UpdateOrderStatus updates order status (admin only)
-func (s *orderServiceImpl) UpdateOrderStatus(id uint, status, token string) (*entity.Order, error) {
+// UpdateOrderStatus updates order status (requires orders:update_status)
+func (s *orderServiceImpl) UpdateOrderStatus(ctx context.Context, id uint, status string) (*entity.Order, error) {
 	logger.Info("Starting order status update", "orderID", id, "newStatus", status)
 
-	// Validate admin token
-	_, err := s.auth.ValidateAdminToken(token)
-	if err != nil {
-		logger.Error("Order status update failed - invalid admin token", "orderID", id, "error", err)
+	if err := middleware.RequirePermission(ctx, entity.PermissionOrdersUpdateStatus); err != nil {
+		logger.Error("Order status update denied", "orderID", id, "error", err)
 		return nil, err
 	}
 
@@ -243,8 +290,21 @@ func (s *orderServiceImpl) UpdateOrderStatus(id uint, status, token string) (*en
 		return nil, errors.New("invalid status")
 	}
 
-	// Update status
-	err = s.orderRepo.UpdateStatus(id, status)
+	current, err := s.orderRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get order for status update", "orderID", id, "error", err)
+		return nil, err
+	}
+
+	// Update status together with its audit event
+	err = s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.orderRepo.UpdateStatusTx(tx, id, status); err != nil {
+			return err
+		}
+		before := map[string]string{"status": current.Status}
+		after := map[string]string{"status": status}
+		return recordAudit(ctx, tx, s.auditRepo, "order.update_status", auditEntityOrder, id, before, after)
+	})
 	if err != nil {
 		logger.Error("Failed to update order status", "orderID", id, "status", status, "error", err)
 		return nil, err
@@ -262,13 +322,17 @@ func (s *orderServiceImpl) UpdateOrderStatus(id uint, status, token string) (*en
 }
 
 // ProcessPayment processes payment for an order
-func (s *orderServiceImpl) ProcessPayment(orderID uint, token string) (string, error) {
+func (s *orderServiceImpl) ProcessPayment(ctx context.Context, orderID uint) (string, error) {
 	logger.Info("Starting payment processing", "orderID", orderID)
 
-	// Validate user token
-	user, err := s.auth.ValidateUserToken(token)
+	user, err := currentUser(ctx)
 	if err != nil {
-		logger.Error("Payment processing failed - invalid user token", "orderID", orderID, "error", err)
+		logger.Error("Payment processing failed - unauthenticated", "orderID", orderID, "error", err)
+		return "", err
+	}
+
+	if err := s.requireVerifiedEmail(user); err != nil {
+		logger.Error("Payment processing failed - email not verified", "orderID", orderID, "userID", user.ID)
 		return "", err
 	}
 
@@ -320,14 +384,12 @@ func (s *orderServiceImpl) ProcessPayment(orderID uint, token string) (string, e
 	return paymentURL, nil
 }
 
-// GetAllOrders retrieves all orders with pagination (admin only)
-func (s *orderServiceImpl) GetAllOrders(token string, page, limit int) ([]*entity.Order, int64, error) {
+// GetAllOrders retrieves all orders with pagination (requires orders:read)
+func (s *orderServiceImpl) GetAllOrders(ctx context.Context, page, limit int) ([]*entity.Order, int64, error) {
 	logger.Info("Getting all orders (admin)", "page", page, "limit", limit)
 
-	// Validate admin token
-	_, err := s.auth.ValidateAdminToken(token)
-	if err != nil {
-		logger.Error("Failed to get all orders - invalid admin token", "error", err)
+	if err := middleware.RequirePermission(ctx, entity.PermissionOrdersRead); err != nil {
+		logger.Error("Getting all orders denied", "error", err)
 		return nil, 0, err
 	}
 
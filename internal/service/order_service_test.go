@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+)
+
+func newTestOrderService(repos *testRepos, verificationCfg config.EmailVerificationConfig) OrderService {
+	return NewOrderService(repos.orders, repos.books, repos.addresses, repos.tx, repos.audit, verificationCfg)
+}
+
+// createBook stores a book with stock copies in a category of its own
+func (r *testRepos) createBook(t *testing.T, title string, stock int) *entity.Book {
+	t.Helper()
+
+	category := &entity.Category{Name: title}
+	if err := r.categories.Create(category); err != nil {
+		t.Fatalf("create category %s: %v", title, err)
+	}
+	book := &entity.Book{Title: title, Author: "Author", Price: 10, Stock: stock, Year: 2020, CategoryID: category.ID}
+	if err := r.books.Create(book); err != nil {
+		t.Fatalf("create book %s: %v", title, err)
+	}
+	return book
+}
+
+func TestOrdersRequireVerifiedEmail(t *testing.T) {
+	repos := newTestFileRepos(t)
+	book := repos.createBook(t, "Dune", 5)
+	unverified := repos.createUser(t, "unverified", entity.RoleUser)
+	if err := repos.db.Model(unverified).Update("email_verified_at", nil).Error; err != nil {
+		t.Fatalf("unverify user: %v", err)
+	}
+	unverified = repos.reload(t, unverified)
+	verified := repos.createUser(t, "verified", entity.RoleUser)
+	items := []OrderItem{{BookID: book.ID, Quantity: 1}}
+
+	required := newTestOrderService(repos, config.EmailVerificationConfig{RequiredForOrders: true})
+	if _, err := required.CreateOrder(repos.contextFor(t, unverified), items, 0); !errors.Is(err, ErrEmailNotVerified) {
+		t.Errorf("CreateOrder() of an unverified user = %v, want ErrEmailNotVerified", err)
+	}
+	order, err := required.CreateOrder(repos.contextFor(t, verified), items, 0)
+	if err != nil {
+		t.Fatalf("CreateOrder() of a verified user: %v", err)
+	}
+
+	// Payment is gated too, for orders placed before verification was required
+	if err := repos.db.Model(order).Update("user_id", unverified.ID).Error; err != nil {
+		t.Fatalf("move order: %v", err)
+	}
+	if _, err := required.ProcessPayment(repos.contextFor(t, unverified), order.ID); !errors.Is(err, ErrEmailNotVerified) {
+		t.Errorf("ProcessPayment() of an unverified user = %v, want ErrEmailNotVerified", err)
+	}
+
+	optional := newTestOrderService(repos, config.EmailVerificationConfig{})
+	if _, err := optional.CreateOrder(repos.contextFor(t, unverified), items, 0); err != nil {
+		t.Errorf("CreateOrder() of an unverified user without the requirement: %v", err)
+	}
+	if _, err := optional.CreateOrder(context.Background(), items, 0); err == nil {
+		t.Error("CreateOrder succeeded without a user")
+	}
+}
+
+// createAddress stores an address of user in city
+func (r *testRepos) createAddress(t *testing.T, user *entity.User, city string, isDefault bool) *entity.Address {
+	t.Helper()
+
+	address := &entity.Address{
+		UserID:        user.ID,
+		AddressFields: entity.AddressFields{RecipientName: user.Name, Phone: "08123456789", Street: "Jl. Merdeka 1", City: city, Province: "Jawa Barat", PostalCode: "40111"},
+		IsDefault:     isDefault,
+	}
+	if err := r.addresses.Create(address); err != nil {
+		t.Fatalf("create address in %s: %v", city, err)
+	}
+	return address
+}
+
+func TestCreateOrderShippingAddress(t *testing.T) {
+	repos := newTestFileRepos(t)
+	service := newTestOrderService(repos, config.EmailVerificationConfig{})
+	book := repos.createBook(t, "Dune", 10)
+	items := []OrderItem{{BookID: book.ID, Quantity: 1}}
+
+	reader := repos.createUser(t, "reader", entity.RoleUser)
+	home := repos.createAddress(t, reader, "Bandung", true)
+	office := repos.createAddress(t, reader, "Jakarta", false)
+	other := repos.createUser(t, "other", entity.RoleUser)
+	foreign := repos.createAddress(t, other, "Surabaya", true)
+	homeless := repos.createUser(t, "homeless", entity.RoleUser)
+
+	tests := []struct {
+		name      string
+		user      *entity.User
+		addressID uint
+		want      *entity.Address
+		err       error
+	}{
+		{name: "default address", user: reader, addressID: 0, want: home},
+		{name: "own address", user: reader, addressID: office.ID, want: office},
+		{name: "address of another user", user: reader, addressID: foreign.ID, err: ErrAddressNotFound},
+		{name: "unknown address", user: reader, addressID: foreign.ID + 100, err: ErrAddressNotFound},
+		{name: "no saved address", user: homeless, addressID: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := repos.books.GetByID(book.ID)
+			if err != nil {
+				t.Fatalf("get book: %v", err)
+			}
+
+			order, err := service.CreateOrder(repos.contextFor(t, tt.user), items, tt.addressID)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("CreateOrder() = %v, want %v", err, tt.err)
+				}
+				if after, _ := repos.books.GetByID(book.ID); after.Stock != before.Stock {
+					t.Errorf("stock = %d after a rejected order, want %d", after.Stock, before.Stock)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateOrder: %v", err)
+			}
+
+			if tt.want == nil {
+				if order.AddressID != nil || order.ShippingAddress != (entity.AddressFields{}) {
+					t.Errorf("order ships to %v %+v, want no address", order.AddressID, order.ShippingAddress)
+				}
+				return
+			}
+			if order.AddressID == nil || *order.AddressID != tt.want.ID {
+				t.Errorf("order address = %v, want %d", order.AddressID, tt.want.ID)
+			}
+			if order.ShippingAddress != tt.want.AddressFields {
+				t.Errorf("order ships to %+v, want %+v", order.ShippingAddress, tt.want.AddressFields)
+			}
+		})
+	}
+}
+
+func TestOrderKeepsShippingAddressSnapshot(t *testing.T) {
+	repos := newTestFileRepos(t)
+	service := newTestOrderService(repos, config.EmailVerificationConfig{})
+	book := repos.createBook(t, "Dune", 10)
+	reader := repos.createUser(t, "reader", entity.RoleUser)
+	home := repos.createAddress(t, reader, "Bandung", true)
+	ctx := repos.contextFor(t, reader)
+
+	order, err := service.CreateOrder(ctx, []OrderItem{{BookID: book.ID, Quantity: 1}}, home.ID)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+
+	home.City = "Jakarta"
+	if err := repos.addresses.Update(home); err != nil {
+		t.Fatalf("update address: %v", err)
+	}
+	stored, err := service.GetOrder(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if stored.ShippingAddress.City != "Bandung" {
+		t.Errorf("order ships to %s after the address was edited, want the original Bandung", stored.ShippingAddress.City)
+	}
+}
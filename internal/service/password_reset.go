@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/mailer"
+)
+
+// passwordResetCooldown is the minimum time between two reset emails to the same user
+const passwordResetCooldown = time.Minute
+
+// RequestPasswordReset emails a single-use reset token to the user. It always
+// succeeds for unknown or disabled emails so that callers cannot find out which
+// emails are registered.
+func (s *userServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	logger.Info("Starting password reset request", "email", email)
+
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		logger.Info("Password reset requested for unknown email", "email", email)
+		return nil
+	}
+	if user.IsDisabled() {
+		logger.Info("Password reset requested for disabled account", "userID", user.ID)
+		return nil
+	}
+
+	recent, err := s.resetRepo.CountSince(user.ID, time.Now().Add(-passwordResetCooldown))
+	if err != nil {
+		return err
+	}
+	if recent > 0 {
+		logger.Warn("Password reset request ignored - cooldown", "userID", user.ID)
+		return nil
+	}
+
+	token, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		logger.Error("Failed to generate password reset token", "userID", user.ID, "error", err)
+		return err
+	}
+
+	// Only the most recently requested token stays valid
+	if err := s.resetRepo.InvalidateForUser(user.ID); err != nil {
+		return err
+	}
+
+	resetToken := &entity.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: helpers.HashToken(token),
+		ExpiresAt: time.Now().Add(s.resetCfg.TokenTTL),
+	}
+	if err := s.resetRepo.Create(resetToken); err != nil {
+		logger.Error("Failed to store password reset token", "userID", user.ID, "error", err)
+		return err
+	}
+
+	// Sending in the background keeps the response time the same for unknown emails
+	s.sendMailAsync(user.ID, s.passwordResetMail(user, token))
+
+	logger.Info("Password reset token issued", "userID", user.ID)
+	return nil
+}
+
+// passwordResetMail builds the email carrying the reset token
+func (s *userServiceImpl) passwordResetMail(user *entity.User, token string) mailer.Message {
+	body := fmt.Sprintf("Hello %s,\n\nWe received a request to reset the password of your Book Store account.\n\nYour reset code is:\n\n    %s\n\n", user.Name, token)
+	if s.resetCfg.URL != "" {
+		body += fmt.Sprintf("You can also open this link to choose a new password:\n\n    %s?token=%s\n\n", s.resetCfg.URL, url.QueryEscape(token))
+	}
+	body += fmt.Sprintf("The code expires in %d minutes and can only be used once. If you did not request a password reset, you can ignore this email.\n", int(s.resetCfg.TokenTTL.Minutes()))
+
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your Book Store password",
+		Body:    body,
+	}
+}
+
+// ConfirmPasswordReset sets a new password using a reset token. Every existing
+// session of the user is revoked, so the user has to log in again.
+func (s *userServiceImpl) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	logger.Info("Starting password reset confirmation")
+
+	if err := helpers.ValidatePasswordPolicy(newPassword); err != nil {
+		logger.Error("Password reset failed - password policy", "error", err)
+		return err
+	}
+
+	resetToken, err := s.resetRepo.Consume(helpers.HashToken(token))
+	if err != nil {
+		logger.Error("Password reset failed - invalid token", "error", err)
+		return errors.New("invalid or expired reset token")
+	}
+
+	user, err := s.userRepo.GetByID(resetToken.UserID)
+	if err != nil {
+		logger.Error("Failed to get user for password reset", "userID", resetToken.UserID, "error", err)
+		return errors.New("invalid or expired reset token")
+	}
+	if user.IsDisabled() {
+		logger.Error("Password reset failed - account is disabled", "userID", user.ID)
+		return errors.New("account is disabled")
+	}
+
+	hashedPassword, err := helpers.HashPassword(newPassword)
+	if err != nil {
+		logger.Error("Failed to hash new password", "userID", user.ID, "error", err)
+		return err
+	}
+
+	// Update password and invalidate every issued token
+	user.Password = hashedPassword
+	user.TokenVersion++
+	if err := s.userRepo.Update(user); err != nil {
+		logger.Error("Failed to update password", "userID", user.ID, "error", err)
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllSessions(user.ID); err != nil {
+		logger.Error("Failed to revoke sessions after password reset", "userID", user.ID, "error", err)
+		return err
+	}
+
+	if err := s.resetRepo.InvalidateForUser(user.ID); err != nil {
+		logger.Error("Failed to invalidate remaining reset tokens", "userID", user.ID, "error", err)
+	}
+
+	// The owner of the mailbox proved who they are, lift a lockout on the email
+	s.loginGuard.reset(user.Email)
+
+	logger.Info("Password reset successful", "userID", user.ID)
+	return nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/mailer"
+)
+
+// newTestMailService returns a user service writing its emails to a log file,
+// and the path of that file
+func newTestMailService(t *testing.T, repos *testRepos, verificationCfg config.EmailVerificationConfig) (*userServiceImpl, string) {
+	t.Helper()
+
+	initTestTokens(t)
+	path := filepath.Join(t.TempDir(), "mail.log")
+	mail, err := mailer.NewLogMailer("noreply@example.com", path)
+	if err != nil {
+		t.Fatalf("NewLogMailer: %v", err)
+	}
+	if verificationCfg.TokenTTL == 0 {
+		verificationCfg.TokenTTL = 24 * time.Hour
+	}
+	service := NewUserService(repos.users, repos.tokens, repos.attempts, repos.mfa, repos.resets, repos.oidc, mail, nil,
+		config.LoginLockoutConfig{}, config.MFAConfig{}, config.PasswordResetConfig{TokenTTL: time.Hour}, verificationCfg, config.OIDCConfig{})
+	return service.(*userServiceImpl), path
+}
+
+// sentMails waits until n emails were written to path and returns them.
+// Emails are sent in the background, so reading the file right away races
+// with the mailer.
+func sentMails(t *testing.T, path string, n int) []string {
+	t.Helper()
+
+	separator := "\n" + strings.Repeat("-", 72) + "\n"
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("read mail log: %v", err)
+		}
+		mails := strings.Split(string(data), separator)
+		mails = mails[:len(mails)-1]
+		if len(mails) >= n || time.Now().After(deadline) {
+			if len(mails) != n {
+				t.Fatalf("%d emails sent, want %d", len(mails), n)
+			}
+			return mails
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// codeFrom returns the code printed on the line after intro in mail
+func codeFrom(t *testing.T, mail, intro string) string {
+	t.Helper()
+
+	i := strings.Index(mail, intro)
+	if i < 0 {
+		t.Fatalf("email does not contain %q:\n%s", intro, mail)
+	}
+	return strings.Fields(mail[i+len(intro):])[0]
+}
+
+func TestPasswordResetTokenIsSingleUse(t *testing.T) {
+	repos := newTestRepos(t)
+	service, mails := newTestMailService(t, repos, config.EmailVerificationConfig{})
+	user := repos.createUserWithPassword(t, "reader")
+	if _, err := service.issueTokens(context.Background(), user); err != nil {
+		t.Fatalf("issueTokens: %v", err)
+	}
+
+	if err := service.RequestPasswordReset(context.Background(), "Reader@Example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	mail := sentMails(t, mails, 1)[0]
+	if !strings.Contains(mail, "To: reader@example.com\n") {
+		t.Errorf("reset email not sent to the user:\n%s", mail)
+	}
+	token := codeFrom(t, mail, "Your reset code is:")
+
+	if err := service.ConfirmPasswordReset(context.Background(), token, "another long passphrase 42"); err != nil {
+		t.Fatalf("ConfirmPasswordReset: %v", err)
+	}
+	if n := repos.activeSessions(t, user); n != 0 {
+		t.Errorf("%d sessions active after the password reset, want 0", n)
+	}
+	if _, err := service.Login(context.Background(), user.Email, "another long passphrase 42"); err != nil {
+		t.Errorf("Login() with the new password: %v", err)
+	}
+
+	if err := service.ConfirmPasswordReset(context.Background(), token, "yet another passphrase 42"); err == nil {
+		t.Error("ConfirmPasswordReset accepted a used token")
+	}
+	if _, err := service.Login(context.Background(), user.Email, "another long passphrase 42"); err != nil {
+		t.Errorf("password changed by the used token: %v", err)
+	}
+}
+
+func TestPasswordResetTokenConcurrentUse(t *testing.T) {
+	const attempts = 8
+
+	repos := newTestRepos(t)
+	service, mails := newTestMailService(t, repos, config.EmailVerificationConfig{})
+	user := repos.createUserWithPassword(t, "reader")
+	if err := service.RequestPasswordReset(context.Background(), user.Email); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	token := codeFrom(t, sentMails(t, mails, 1)[0], "Your reset code is:")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	start := make(chan struct{})
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := service.ConfirmPasswordReset(context.Background(), token, "another long passphrase 42"); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("%d of %d concurrent resets succeeded, want exactly 1", succeeded, attempts)
+	}
+}
+
+func TestPasswordResetCooldown(t *testing.T) {
+	repos := newTestRepos(t)
+	service, mails := newTestMailService(t, repos, config.EmailVerificationConfig{})
+	user := repos.createUserWithPassword(t, "reader")
+
+	if err := service.RequestPasswordReset(context.Background(), user.Email); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	first := codeFrom(t, sentMails(t, mails, 1)[0], "Your reset code is:")
+
+	// Within the cooldown the request succeeds without another email
+	if err := service.RequestPasswordReset(context.Background(), user.Email); err != nil {
+		t.Fatalf("RequestPasswordReset() within the cooldown: %v", err)
+	}
+	sentMails(t, mails, 1)
+
+	// Unknown emails look the same to the caller
+	if err := service.RequestPasswordReset(context.Background(), "nobody@example.com"); err != nil {
+		t.Errorf("RequestPasswordReset() of an unknown email: %v", err)
+	}
+	sentMails(t, mails, 1)
+
+	past := time.Now().Add(-passwordResetCooldown - time.Second)
+	if err := repos.db.Model(&entity.PasswordResetToken{}).Where("user_id = ?", user.ID).Update("created_at", past).Error; err != nil {
+		t.Fatalf("age reset tokens: %v", err)
+	}
+	if err := service.RequestPasswordReset(context.Background(), user.Email); err != nil {
+		t.Fatalf("RequestPasswordReset() after the cooldown: %v", err)
+	}
+	second := codeFrom(t, sentMails(t, mails, 2)[1], "Your reset code is:")
+
+	// Only the most recent token stays valid
+	if err := service.ConfirmPasswordReset(context.Background(), first, "another long passphrase 42"); err == nil {
+		t.Error("ConfirmPasswordReset accepted a superseded token")
+	}
+	if err := service.ConfirmPasswordReset(context.Background(), second, "another long passphrase 42"); err != nil {
+		t.Errorf("ConfirmPasswordReset() with the latest token: %v", err)
+	}
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// exportOrderPageSize is the number of orders loaded at a time while exporting
+const exportOrderPageSize = 100
+
+// reauthenticationWindow is how recently a user without a password must have
+// signed in to delete their account
+const reauthenticationWindow = 10 * time.Minute
+
+// ErrReauthenticationRequired is returned when an account without a password
+// is deleted from a session that did not sign in recently
+var ErrReauthenticationRequired = errors.New("sign in again to confirm the deletion")
+
+// PrivacyService exports and erases the personal data of an account. Orders
+// are never deleted; they stay for accounting and only lose the link to a person.
+type PrivacyService interface {
+	ExportMyData(ctx context.Context, w io.Writer) error
+	DeleteMyAccount(ctx context.Context, password string) error
+	ExportUserData(ctx context.Context, id uint, w io.Writer) error
+	AnonymizeUser(ctx context.Context, id uint) (*entity.User, error)
+}
+
+type privacyServiceImpl struct {
+	userRepo    repository.UserRepository
+	roleRepo    repository.RoleRepository
+	tokenRepo   repository.TokenRepository
+	mfaRepo     repository.MFARepository
+	resetRepo   repository.PasswordResetRepository
+	orderRepo   repository.OrderRepository
+	addressRepo repository.AddressRepository
+	oidcRepo    repository.OIDCRepository
+	txRepo      repository.TransactionRepository
+	auditRepo   repository.AuditRepository
+}
+
+func NewPrivacyService(userRepo repository.UserRepository, roleRepo repository.RoleRepository, tokenRepo repository.TokenRepository, mfaRepo repository.MFARepository, resetRepo repository.PasswordResetRepository, orderRepo repository.OrderRepository, addressRepo repository.AddressRepository, oidcRepo repository.OIDCRepository, txRepo repository.TransactionRepository, auditRepo repository.AuditRepository) PrivacyService {
+	return &privacyServiceImpl{
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		tokenRepo:   tokenRepo,
+		mfaRepo:     mfaRepo,
+		resetRepo:   resetRepo,
+		orderRepo:   orderRepo,
+		addressRepo: addressRepo,
+		oidcRepo:    oidcRepo,
+		txRepo:      txRepo,
+		auditRepo:   auditRepo,
+	}
+}
+
+// exportProfile is the profile section of a data export
+type exportProfile struct {
+	ID              uint             `json:"id"`
+	Name            string           `json:"name"`
+	Email           string           `json:"email"`
+	Phone           string           `json:"phone"`
+	Address         string           `json:"address"`
+	Role            string           `json:"role"`
+	MFAEnabled      bool             `json:"mfa_enabled"`
+	EmailVerifiedAt *time.Time       `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	LinkedAccounts  []exportIdentity `json:"linked_accounts"`
+}
+
+// exportIdentity is an external identity linked to the profile of a data export
+type exportIdentity struct {
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email"`
+	LinkedAt    time.Time  `json:"linked_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// exportOrder is one order of a data export
+type exportOrder struct {
+	ID              uint                  `json:"id"`
+	Status          string                `json:"status"`
+	TotalPrice      float64               `json:"total_price"`
+	PaymentURL      string                `json:"payment_url,omitempty"`
+	ShippingAddress *entity.AddressFields `json:"shipping_address,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	Items           []exportOrderItem     `json:"items"`
+}
+
+// exportOrderItem is one order item of a data export
+type exportOrderItem struct {
+	BookID   uint    `json:"book_id"`
+	Title    string  `json:"title"`
+	Author   string  `json:"author"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+}
+
+// ExportMyData writes a JSON archive of the caller's personal data to w
+func (s *privacyServiceImpl) ExportMyData(ctx context.Context, w io.Writer) error {
+	logger.Info("Starting personal data export")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Personal data export failed - unauthenticated", "error", err)
+		return err
+	}
+
+	if err := s.writeExport(ctx, user, w); err != nil {
+		logger.Error("Personal data export failed", "userID", user.ID, "error", err)
+		return err
+	}
+
+	logger.Info("Personal data export completed", "userID", user.ID)
+	return nil
+}
+
+// DeleteMyAccount erases the caller's personal data after confirming their
+// password. Accounts without a password, such as those created by an OIDC sign
+// in, confirm by having signed in within reauthenticationWindow instead.
+func (s *privacyServiceImpl) DeleteMyAccount(ctx context.Context, password string) error {
+	logger.Info("Starting account deletion")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Account deletion failed - unauthenticated", "error", err)
+		return err
+	}
+
+	if user.HasPassword() {
+		if !helpers.CheckPassword(password, user.Password) {
+			logger.Error("Account deletion failed - invalid password", "userID", user.ID)
+			return errors.New("invalid password")
+		}
+	} else if err := s.checkRecentSignIn(ctx, user); err != nil {
+		logger.Error("Account deletion failed - no recent sign in", "userID", user.ID, "error", err)
+		return err
+	}
+
+	if err := s.anonymize(ctx, user, false); err != nil {
+		logger.Error("Account deletion failed", "userID", user.ID, "error", err)
+		return err
+	}
+
+	logger.Info("Account deleted successfully", "userID", user.ID)
+	return nil
+}
+
+// checkRecentSignIn makes sure the caller's session was started by a sign in
+// within reauthenticationWindow. Refreshing tokens keeps the session, so only
+// signing in again counts.
+func (s *privacyServiceImpl) checkRecentSignIn(ctx context.Context, user *entity.User) error {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok || claims.SessionID == 0 {
+		return ErrReauthenticationRequired
+	}
+
+	session, err := s.tokenRepo.GetSession(claims.SessionID)
+	if err != nil {
+		return ErrReauthenticationRequired
+	}
+	if session.UserID != user.ID || !session.IsActive(time.Now()) || time.Since(session.CreatedAt) > reauthenticationWindow {
+		return ErrReauthenticationRequired
+	}
+	return nil
+}
+
+// ExportUserData writes a JSON archive of a user's personal data to w (requires users:read)
+func (s *privacyServiceImpl) ExportUserData(ctx context.Context, id uint, w io.Writer) error {
+	logger.Info("Starting personal data export for user", "userID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersRead); err != nil {
+		logger.Error("Personal data export denied", "userID", id, "error", err)
+		return err
+	}
+
+	admin, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Personal data export failed - unauthenticated", "error", err)
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user for personal data export", "userID", id, "error", err)
+		return err
+	}
+
+	// Reading someone else's personal data is audited even though nothing changes
+	event, err := newAuditEvent(ctx, "user.export", auditEntityUser, id, nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.auditRepo.Create(event); err != nil {
+		logger.Error("Failed to record personal data export", "userID", id, "error", err)
+		return err
+	}
+
+	if err := s.writeExport(ctx, user, w); err != nil {
+		logger.Error("Personal data export failed", "userID", id, "adminID", admin.ID, "error", err)
+		return err
+	}
+
+	logger.Info("Personal data of user exported by admin", "userID", id, "adminID", admin.ID)
+	return nil
+}
+
+// AnonymizeUser erases a user's personal data on their behalf (requires users:write
+// and every permission of the user's role)
+func (s *privacyServiceImpl) AnonymizeUser(ctx context.Context, id uint) (*entity.User, error) {
+	logger.Info("Anonymizing user", "userID", id)
+
+	if err := middleware.RequirePermission(ctx, entity.PermissionUsersWrite); err != nil {
+		logger.Error("Anonymizing user denied", "userID", id, "error", err)
+		return nil, err
+	}
+
+	admin, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Failed to anonymize user - unauthenticated", "error", err)
+		return nil, err
+	}
+
+	if admin.ID == id {
+		logger.Error("Failed to anonymize user - admins cannot anonymize themselves", "userID", id)
+		return nil, errors.New("use DeleteMyAccount to delete your own account")
+	}
+
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		logger.Error("Failed to get user for anonymization", "userID", id, "error", err)
+		return nil, err
+	}
+
+	if err := requireHeldPermissionsOf(ctx, s.roleRepo, user); err != nil {
+		logger.Error("Anonymizing user denied - user is more privileged", "userID", id, "error", err)
+		return nil, err
+	}
+
+	if user.IsAnonymized() {
+		logger.Info("User is already anonymized", "userID", id)
+		return user, nil
+	}
+
+	if err := s.anonymize(ctx, user, true); err != nil {
+		logger.Error("Failed to anonymize user", "userID", id, "error", err)
+		return nil, err
+	}
+
+	logger.Info("User anonymized by admin", "userID", id, "adminID", admin.ID)
+	return user, nil
+}
+
+// writeExport streams the export document to w, loading orders page by page so
+// long order histories are never held in memory at once
+func (s *privacyServiceImpl) writeExport(ctx context.Context, user *entity.User, w io.Writer) error {
+	identities, err := s.oidcRepo.GetIdentitiesByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+	linkedAccounts := make([]exportIdentity, 0, len(identities))
+	for _, identity := range identities {
+		linkedAccounts = append(linkedAccounts, exportIdentity{
+			Provider:    identity.Provider,
+			Email:       identity.Email,
+			LinkedAt:    identity.CreatedAt,
+			LastLoginAt: identity.LastLoginAt,
+		})
+	}
+
+	profile, err := json.Marshal(exportProfile{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Phone:           user.Phone,
+		Address:         user.Address,
+		Role:            user.Role,
+		MFAEnabled:      user.MFAEnabled(),
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		LinkedAccounts:  linkedAccounts,
+	})
+	if err != nil {
+		return err
+	}
+
+	storedAddresses, err := s.addressRepo.GetByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+	addresses, err := json.Marshal(storedAddresses)
+	if err != nil {
+		return err
+	}
+	if storedAddresses == nil {
+		addresses = []byte("[]")
+	}
+
+	if _, err := fmt.Fprintf(w, `{"exported_at":%q,"profile":%s,"addresses":%s,"orders":[`, time.Now().UTC().Format(time.RFC3339), profile, addresses); err != nil {
+		return err
+	}
+
+	written := 0
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		orders, total, err := s.orderRepo.GetByUserID(user.ID, page, exportOrderPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			data, err := json.Marshal(toExportOrder(order))
+			if err != nil {
+				return err
+			}
+			if written > 0 {
+				data = append([]byte{','}, data...)
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			written++
+		}
+
+		if len(orders) < exportOrderPageSize || int64(page*exportOrderPageSize) >= total {
+			break
+		}
+	}
+
+	_, err = io.WriteString(w, "]}")
+	return err
+}
+
+func toExportOrder(order *entity.Order) exportOrder {
+	items := make([]exportOrderItem, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		items = append(items, exportOrderItem{
+			BookID:   item.BookID,
+			Title:    item.Book.Title,
+			Author:   item.Book.Author,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		})
+	}
+
+	export := exportOrder{
+		ID:         order.ID,
+		Status:     order.Status,
+		TotalPrice: order.TotalPrice,
+		PaymentURL: order.PaymentURL,
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+		Items:      items,
+	}
+	if order.ShippingAddress != (entity.AddressFields{}) {
+		export.ShippingAddress = &order.ShippingAddress
+	}
+	return export
+}
+
+// anonymize replaces the personal data of a user with placeholders, disables
+// the account, revokes every credential, unlinks external identities and
+// empties the address book, all in one transaction so a failure leaves nothing
+// half erased. The row itself is kept so orders still reference an existing
+// user. Anonymizations done by an admin are audited.
+func (s *privacyServiceImpl) anonymize(ctx context.Context, user *entity.User, audited bool) error {
+	before := *user
+	now := time.Now()
+	user.Name = "Deleted User"
+	// The email column is unique, so every anonymized user gets its own placeholder
+	user.Email = fmt.Sprintf("deleted-user-%d@anonymized.invalid", user.ID)
+	user.Phone = ""
+	user.Address = ""
+	user.Password = entity.NoPassword
+	user.MFASecret = ""
+	user.MFAEnabledAt = nil
+	user.MFALastUsedStep = 0
+	user.EmailVerifiedAt = nil
+	user.VerificationSentAt = nil
+	user.AnonymizedAt = &now
+	if user.DisabledAt == nil {
+		user.DisabledAt = &now
+	}
+	user.TokenVersion++
+	return s.txRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.userRepo.UpdateTx(tx, user); err != nil {
+			return err
+		}
+		if err := s.tokenRepo.RevokeAllSessionsTx(tx, user.ID); err != nil {
+			return err
+		}
+		if err := s.mfaRepo.DeleteRecoveryCodesTx(tx, user.ID); err != nil {
+			return err
+		}
+		if err := s.addressRepo.DeleteByUserIDTx(tx, user.ID); err != nil {
+			return err
+		}
+		if err := s.oidcRepo.DeleteIdentitiesByUserIDTx(tx, user.ID); err != nil {
+			return err
+		}
+		if err := s.resetRepo.InvalidateForUserTx(tx, user.ID); err != nil {
+			return err
+		}
+		if !audited {
+			return nil
+		}
+		return recordAudit(ctx, tx, s.auditRepo, "user.anonymize", auditEntityUser, user.ID, &before, user)
+	})
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+func newTestPrivacyService(repos *testRepos) PrivacyService {
+	return NewPrivacyService(repos.users, repos.roles, repos.tokens, repos.mfa, repos.resets, repos.orders, repos.addresses, repos.oidc, repos.tx, repos.audit)
+}
+
+// giveCredentials stores a session, refresh token, recovery code, address,
+// external identity and reset token of user
+func (r *testRepos) giveCredentials(t *testing.T, user *entity.User) {
+	t.Helper()
+
+	expires := time.Now().Add(time.Hour)
+	session := &entity.Session{UserID: user.ID, LastSeenAt: time.Now(), ExpiresAt: expires}
+	records := []interface{}{
+		session,
+		&entity.MFARecoveryCode{UserID: user.ID, CodeHash: "code"},
+		&entity.Address{UserID: user.ID, AddressFields: entity.AddressFields{RecipientName: user.Name, Street: "Jl. Merdeka 1", City: "Bandung"}},
+		&entity.ExternalIdentity{UserID: user.ID, Provider: "mock", Subject: user.Email, Email: user.Email},
+		&entity.PasswordResetToken{UserID: user.ID, TokenHash: "reset-" + user.Email, ExpiresAt: expires},
+	}
+	for _, record := range records {
+		if err := r.db.Create(record).Error; err != nil {
+			t.Fatalf("create %T: %v", record, err)
+		}
+	}
+	if err := r.db.Create(&entity.RefreshToken{UserID: user.ID, TokenHash: "refresh-" + user.Email, ExpiresAt: expires, SessionID: &session.ID}).Error; err != nil {
+		t.Fatalf("create refresh token: %v", err)
+	}
+}
+
+// usableCredentials counts what giveCredentials stored that still works
+func (r *testRepos) usableCredentials(t *testing.T, user *entity.User) map[string]int64 {
+	t.Helper()
+
+	queries := []struct {
+		name  string
+		model interface{}
+		where string
+	}{
+		{"sessions", &entity.Session{}, "revoked_at IS NULL"},
+		{"refresh tokens", &entity.RefreshToken{}, "revoked_at IS NULL"},
+		{"recovery codes", &entity.MFARecoveryCode{}, "1 = 1"},
+		{"addresses", &entity.Address{}, "1 = 1"},
+		{"identities", &entity.ExternalIdentity{}, "1 = 1"},
+		{"reset tokens", &entity.PasswordResetToken{}, "used_at IS NULL"},
+	}
+	counts := make(map[string]int64, len(queries))
+	for _, query := range queries {
+		var count int64
+		if err := r.db.Model(query.model).Where("user_id = ?", user.ID).Where(query.where).Count(&count).Error; err != nil {
+			t.Fatalf("count %s: %v", query.name, err)
+		}
+		counts[query.name] = count
+	}
+	return counts
+}
+
+func TestAnonymizeUserErasesEverything(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestPrivacyService(repos)
+	ctx := repos.contextFor(t, repos.createUser(t, "root", entity.RoleSuperAdmin))
+	customer := repos.createUser(t, "customer", entity.RoleUser)
+	repos.giveCredentials(t, customer)
+
+	if _, err := service.AnonymizeUser(ctx, customer.ID); err != nil {
+		t.Fatalf("AnonymizeUser: %v", err)
+	}
+
+	after := repos.reload(t, customer)
+	if !after.IsAnonymized() || !after.IsDisabled() || after.Email == customer.Email || after.TokenVersion != customer.TokenVersion+1 {
+		t.Errorf("user after AnonymizeUser = %+v, want anonymized, disabled and signed out", after)
+	}
+	for name, count := range repos.usableCredentials(t, customer) {
+		if count != 0 {
+			t.Errorf("%d %s still usable after AnonymizeUser", count, name)
+		}
+	}
+	var events int64
+	repos.db.Model(&entity.AuditEvent{}).Where("action = ? AND entity_id = ?", "user.anonymize", customer.ID).Count(&events)
+	if events != 1 {
+		t.Errorf("%d user.anonymize audit events, want 1", events)
+	}
+}
+
+func TestAnonymizeUserIsAllOrNothing(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestPrivacyService(repos)
+	ctx := repos.contextFor(t, repos.createUser(t, "root", entity.RoleSuperAdmin))
+	customer := repos.createUser(t, "customer", entity.RoleUser)
+	repos.giveCredentials(t, customer)
+
+	// Unlinking external identities fails after the sessions were revoked
+	if err := repos.db.Exec("ALTER TABLE external_identities RENAME TO external_identities_gone").Error; err != nil {
+		t.Fatalf("break external identities: %v", err)
+	}
+	if _, err := service.AnonymizeUser(ctx, customer.ID); err == nil {
+		t.Fatal("AnonymizeUser succeeded without the external identities table")
+	}
+	if err := repos.db.Exec("ALTER TABLE external_identities_gone RENAME TO external_identities").Error; err != nil {
+		t.Fatalf("restore external identities: %v", err)
+	}
+
+	if after := repos.reload(t, customer); after.IsAnonymized() || after.Email != customer.Email {
+		t.Errorf("user after a failed AnonymizeUser = %+v, want unchanged", after)
+	}
+	for name, count := range repos.usableCredentials(t, customer) {
+		if count != 1 {
+			t.Errorf("%d %s usable after a failed AnonymizeUser, want 1", count, name)
+		}
+	}
+
+	// Retrying finishes the job
+	if _, err := service.AnonymizeUser(ctx, customer.ID); err != nil {
+		t.Fatalf("AnonymizeUser retry: %v", err)
+	}
+	for name, count := range repos.usableCredentials(t, customer) {
+		if count != 0 {
+			t.Errorf("%d %s still usable after retrying AnonymizeUser", count, name)
+		}
+	}
+}
+
+func TestAnonymizeUserRequiresHeldPermissions(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestPrivacyService(repos)
+	repos.createRole(t, "support", entity.PermissionUsersRead, entity.PermissionUsersWrite)
+	ctx := repos.contextFor(t, repos.createUser(t, "support", "support"))
+
+	superAdmin := repos.createUser(t, "root", entity.RoleSuperAdmin)
+	if _, err := service.AnonymizeUser(ctx, superAdmin.ID); !errors.Is(err, middleware.ErrPermissionDenied) {
+		t.Errorf("AnonymizeUser() on a super_admin = %v, want ErrPermissionDenied", err)
+	}
+	if after := repos.reload(t, superAdmin); after.IsAnonymized() || after.IsDisabled() {
+		t.Errorf("super_admin after a denied AnonymizeUser = %+v, want unchanged", after)
+	}
+
+	customer := repos.createUser(t, "customer", entity.RoleUser)
+	if _, err := service.AnonymizeUser(ctx, customer.ID); err != nil {
+		t.Errorf("AnonymizeUser() on a customer: %v", err)
+	}
+}
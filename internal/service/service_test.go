@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/database"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// testRepos are the repositories of an in-memory SQLite database holding every
+// table and the built-in roles. SQLite stands in for Postgres, so it only
+// suits services whose queries are not Postgres specific.
+type testRepos struct {
+	db         *gorm.DB
+	users      repository.UserRepository
+	tokens     repository.TokenRepository
+	roles      repository.RoleRepository
+	attempts   repository.LoginAttemptRepository
+	mfa        repository.MFARepository
+	resets     repository.PasswordResetRepository
+	oidc       repository.OIDCRepository
+	orders     repository.OrderRepository
+	addresses  repository.AddressRepository
+	books      repository.BookRepository
+	categories repository.CategoryRepository
+	apiKeys    repository.APIKeyRepository
+	audit      repository.AuditRepository
+	tx         repository.TransactionRepository
+}
+
+func newTestRepos(t *testing.T) *testRepos {
+	t.Helper()
+
+	// Every connection to :memory: is a separate database
+	return openTestRepos(t, ":memory:", 1)
+}
+
+// newTestFileRepos is newTestRepos backed by a file, for services that query
+// outside of a transaction they hold open, such as CreateOrder. A single
+// connection would deadlock there.
+func newTestFileRepos(t *testing.T) *testRepos {
+	t.Helper()
+
+	return openTestRepos(t, filepath.Join(t.TempDir(), "test.db")+"?_journal_mode=WAL&_busy_timeout=5000", 2)
+}
+
+func openTestRepos(t *testing.T, dsn string, maxOpenConns int) *testRepos {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(maxOpenConns)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	err = db.AutoMigrate(
+		&entity.User{}, &entity.Category{}, &entity.Book{}, &entity.Order{}, &entity.OrderItem{},
+		&entity.RefreshToken{}, &entity.RevokedToken{}, &entity.Permission{}, &entity.Role{},
+		&entity.LoginAttempt{}, &entity.MFARecoveryCode{}, &entity.APIKey{}, &entity.PasswordResetToken{},
+		&entity.AuditEvent{}, &entity.Address{}, &entity.ExternalIdentity{}, &entity.OIDCLoginState{},
+		&entity.Session{},
+	)
+	if err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	if err := database.SeedRoles(db); err != nil {
+		t.Fatalf("seed roles: %v", err)
+	}
+
+	return &testRepos{
+		db:         db,
+		users:      repository.NewUserRepository(db),
+		tokens:     repository.NewTokenRepository(db),
+		roles:      repository.NewRoleRepository(db),
+		attempts:   repository.NewLoginAttemptRepository(db),
+		mfa:        repository.NewMFARepository(db),
+		resets:     repository.NewPasswordResetRepository(db),
+		oidc:       repository.NewOIDCRepository(db),
+		orders:     repository.NewOrderRepository(db),
+		addresses:  repository.NewAddressRepository(db),
+		books:      repository.NewBookRepository(db),
+		categories: repository.NewCategoryRepository(db),
+		apiKeys:    repository.NewAPIKeyRepository(db),
+		audit:      repository.NewAuditRepository(db),
+		tx:         repository.NewTransactionRepository(db),
+	}
+}
+
+// initTestTokens signs tokens with an ephemeral key
+func initTestTokens(t *testing.T) {
+	t.Helper()
+
+	if err := helpers.InitTokens(&config.Config{JWTIssuer: "test", JWTAllowEphemeralKey: true, AccessTokenExpiry: 15, RefreshTokenExpiry: 24}); err != nil {
+		t.Fatalf("InitTokens: %v", err)
+	}
+}
+
+// createUser stores a verified user with role and without a password
+func (r *testRepos) createUser(t *testing.T, name, role string) *entity.User {
+	t.Helper()
+
+	now := time.Now()
+	user := &entity.User{
+		Name:            name,
+		Email:           fmt.Sprintf("%s@example.com", name),
+		Password:        entity.NoPassword,
+		Role:            role,
+		EmailVerifiedAt: &now,
+	}
+	if err := r.users.Create(user); err != nil {
+		t.Fatalf("create user %s: %v", name, err)
+	}
+	return user
+}
+
+// createRole stores a custom role holding permissions
+func (r *testRepos) createRole(t *testing.T, name string, permissions ...string) {
+	t.Helper()
+
+	granted, err := r.roles.GetPermissionsByNames(permissions)
+	if err != nil || len(granted) != len(permissions) {
+		t.Fatalf("get permissions %v: %v", permissions, err)
+	}
+	if err := r.roles.Save(&entity.Role{Name: name}, granted); err != nil {
+		t.Fatalf("create role %s: %v", name, err)
+	}
+}
+
+// contextFor returns a context authenticated as user, with the permissions of
+// their role, like the auth interceptor creates
+func (r *testRepos) contextFor(t *testing.T, user *entity.User) context.Context {
+	t.Helper()
+
+	permissions, err := r.roles.GetPermissionNames(user.Role)
+	if err != nil {
+		t.Fatalf("get permissions of %s: %v", user.Role, err)
+	}
+	return middleware.ContextWithUser(context.Background(), user, nil, permissions)
+}
+
+// reload reads user back from the database
+func (r *testRepos) reload(t *testing.T, user *entity.User) *entity.User {
+	t.Helper()
+
+	var fresh entity.User
+	if err := r.db.Unscoped().First(&fresh, user.ID).Error; err != nil {
+		t.Fatalf("reload user %d: %v", user.ID, err)
+	}
+	return &fresh
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+// ErrSessionNotFound is returned for sessions that do not exist, are no longer
+// active or belong to another user
+var ErrSessionNotFound = errors.New("session not found")
+
+// newSession stores a session for a login from the calling device
+func (s *userServiceImpl) newSession(ctx context.Context, userID uint) (*entity.Session, error) {
+	now := time.Now()
+	session := &entity.Session{
+		UserID:     userID,
+		UserAgent:  middleware.UserAgent(ctx),
+		DeviceName: middleware.DeviceName(ctx),
+		IPAddress:  middleware.ClientIP(ctx),
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(helpers.RefreshTokenTTL()),
+	}
+	if err := s.tokenRepo.CreateSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListMySessions retrieves the active sessions of the caller, most recently
+// used first, along with the ID of the session making the request
+func (s *userServiceImpl) ListMySessions(ctx context.Context) ([]*entity.Session, uint, error) {
+	logger.Info("Listing sessions")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Listing sessions failed - unauthenticated", "error", err)
+		return nil, 0, err
+	}
+
+	sessions, err := s.tokenRepo.GetActiveSessions(user.ID)
+	if err != nil {
+		logger.Error("Failed to list sessions", "userID", user.ID, "error", err)
+		return nil, 0, err
+	}
+
+	var currentSessionID uint
+	if claims, ok := middleware.ClaimsFromContext(ctx); ok {
+		currentSessionID = claims.SessionID
+	}
+
+	logger.Info("Sessions listed successfully", "userID", user.ID, "count", len(sessions))
+	return sessions, currentSessionID, nil
+}
+
+// RevokeSession signs the caller out of one of their sessions. Its access
+// tokens are rejected from then on and its refresh token stops working.
+func (s *userServiceImpl) RevokeSession(ctx context.Context, id uint) error {
+	logger.Info("Starting session revocation", "sessionID", id)
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Session revocation failed - unauthenticated", "error", err)
+		return err
+	}
+
+	session, err := s.tokenRepo.GetSession(id)
+	if err != nil || session.UserID != user.ID || !session.IsActive(time.Now()) {
+		logger.Error("Session revocation failed - session not found", "userID", user.ID, "sessionID", id, "error", err)
+		return ErrSessionNotFound
+	}
+
+	if err := s.tokenRepo.RevokeSession(session.ID); err != nil {
+		logger.Error("Failed to revoke session", "userID", user.ID, "sessionID", id, "error", err)
+		return err
+	}
+
+	logger.Info("Session revoked successfully", "userID", user.ID, "sessionID", id)
+	return nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+)
+
+func TestRefreshTokenOfRevokedSession(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	issued, _ := service.issueTokens(context.Background(), user)
+	claims, _ := helpers.ValidateToken(issued.AccessToken)
+
+	if err := repos.tokens.RevokeSession(claims.SessionID); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+	if _, _, err := service.RefreshToken(context.Background(), issued.RefreshToken); err == nil {
+		t.Error("RefreshToken accepted the refresh token of a revoked session")
+	}
+}
+
+func TestLegacyRefreshTokenGetsSession(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+
+	// Refresh tokens issued before sessions existed have no session
+	legacy, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+	stored := &entity.RefreshToken{UserID: user.ID, TokenHash: helpers.HashToken(legacy), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := repos.tokens.CreateRefreshToken(stored); err != nil {
+		t.Fatalf("CreateRefreshToken: %v", err)
+	}
+
+	refreshed, _, err := service.RefreshToken(context.Background(), legacy)
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	claims, err := helpers.ValidateToken(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if claims.SessionID == 0 {
+		t.Fatal("access token issued for a legacy refresh token has no session")
+	}
+	replacement, err := repos.tokens.GetRefreshTokenByHash(helpers.HashToken(refreshed.RefreshToken))
+	if err != nil {
+		t.Fatalf("GetRefreshTokenByHash: %v", err)
+	}
+	if replacement.SessionID == nil || *replacement.SessionID != claims.SessionID {
+		t.Errorf("replacement refresh token has session %v, want %d", replacement.SessionID, claims.SessionID)
+	}
+	if n := repos.activeSessions(t, user); n != 1 {
+		t.Errorf("%d active sessions, want 1", n)
+	}
+}
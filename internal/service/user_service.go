@@ -1,39 +1,101 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/nabil/book-store-system/config"
 	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/internal/repository"
 	"github.com/nabil/book-store-system/pkg/helpers"
 	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/mailer"
 	"github.com/nabil/book-store-system/pkg/middleware"
+	"github.com/nabil/book-store-system/pkg/oidc"
 	"gorm.io/gorm"
 )
 
+// ErrEmailTaken is returned when an email address already belongs to another account
+var ErrEmailTaken = errors.New("email is already taken")
+
+// AuthTokens is the token pair handed out on login and refresh
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+// LoginResult is the outcome of a successful password check. Either Tokens is
+// set, or MFARequired is set and MFAToken must be completed through VerifyMFA.
+// When MFAEnrollmentRequired is set, Tokens only holds an access token that is
+// restricted to the MFA enrollment RPCs.
+type LoginResult struct {
+	User                  *entity.User
+	Tokens                *AuthTokens
+	MFARequired           bool
+	MFAToken              string
+	MFAEnrollmentRequired bool
+}
+
 type UserService interface {
 	Register(name, email, password string) (*entity.User, error)
-	Login(email, password string) (string, *entity.User, error)
-	GetProfile(token string) (*entity.User, error)
-	UpdateProfile(userID uint, name, email string) (*entity.User, error)
-	ChangePassword(userID uint, oldPassword, newPassword string) error
+	Login(ctx context.Context, email, password string) (*LoginResult, error)
+	GetProfile(ctx context.Context) (*entity.User, error)
+	UpdateProfile(userID uint, name, email, phone, address string) (*entity.User, error)
+	ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) (*AuthTokens, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*AuthTokens, *entity.User, error)
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutAllDevices(ctx context.Context) error
+	EnrollMFA(ctx context.Context) (secret, provisioningURI string, err error)
+	ConfirmMFA(ctx context.Context, code string) ([]string, *AuthTokens, error)
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*AuthTokens, *entity.User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) (*entity.User, error)
+	ResendVerification(ctx context.Context) error
+	BeginOIDCLogin(ctx context.Context, provider string) (authURL, state string, err error)
+	CompleteOIDCLogin(ctx context.Context, state, code string) (*LoginResult, error)
+	ListMySessions(ctx context.Context) (sessions []*entity.Session, currentSessionID uint, err error)
+	RevokeSession(ctx context.Context, id uint) error
 }
 
 type userServiceImpl struct {
-	userRepo repository.UserRepository
-	auth     *middleware.AuthMiddleware
+	userRepo        repository.UserRepository
+	tokenRepo       repository.TokenRepository
+	mfaRepo         repository.MFARepository
+	resetRepo       repository.PasswordResetRepository
+	oidcRepo        repository.OIDCRepository
+	mailer          mailer.Mailer
+	oidcProviders   map[string]*oidc.Provider
+	loginGuard      *loginGuard
+	mfaCfg          config.MFAConfig
+	resetCfg        config.PasswordResetConfig
+	verificationCfg config.EmailVerificationConfig
+	oidcCfg         config.OIDCConfig
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
-	auth := middleware.NewAuthMiddleware(userRepo)
+func NewUserService(userRepo repository.UserRepository, tokenRepo repository.TokenRepository, attemptRepo repository.LoginAttemptRepository, mfaRepo repository.MFARepository, resetRepo repository.PasswordResetRepository, oidcRepo repository.OIDCRepository, mail mailer.Mailer, oidcProviders map[string]*oidc.Provider, lockoutCfg config.LoginLockoutConfig, mfaCfg config.MFAConfig, resetCfg config.PasswordResetConfig, verificationCfg config.EmailVerificationConfig, oidcCfg config.OIDCConfig) UserService {
 	return &userServiceImpl{
-		userRepo: userRepo,
-		auth:     auth,
+		userRepo:        userRepo,
+		tokenRepo:       tokenRepo,
+		mfaRepo:         mfaRepo,
+		resetRepo:       resetRepo,
+		oidcRepo:        oidcRepo,
+		mailer:          mail,
+		oidcProviders:   oidcProviders,
+		loginGuard:      &loginGuard{attemptRepo: attemptRepo, cfg: lockoutCfg},
+		mfaCfg:          mfaCfg,
+		resetCfg:        resetCfg,
+		verificationCfg: verificationCfg,
+		oidcCfg:         oidcCfg,
 	}
 }
 
 // Register creates a new user account
 func (s *userServiceImpl) Register(name, email, password string) (*entity.User, error) {
+	email = helpers.NormalizeEmail(email)
 	logger.Info("Starting user registration", "email", email, "name", name)
 
 	// Check if user already exists
@@ -44,7 +106,12 @@ func (s *userServiceImpl) Register(name, email, password string) (*entity.User,
 	}
 	if existingUser != nil {
 		logger.Error("User registration failed - email already exists", "email", email)
-		return nil, errors.New("user with this email already exists")
+		return nil, ErrEmailTaken
+	}
+
+	if err := helpers.ValidatePasswordPolicy(password); err != nil {
+		logger.Error("User registration failed - password policy", "email", email, "error", err)
+		return nil, err
 	}
 
 	// Hash password
@@ -59,7 +126,7 @@ func (s *userServiceImpl) Register(name, email, password string) (*entity.User,
 		Name:     name,
 		Email:    email,
 		Password: hashedPassword,
-		Role:     "user", // Default role
+		Role:     entity.RoleUser, // Default role
 	}
 
 	err = s.userRepo.Create(user)
@@ -68,43 +135,123 @@ func (s *userServiceImpl) Register(name, email, password string) (*entity.User,
 		return nil, err
 	}
 
+	// New accounts start unverified; a failed email can be retried with ResendVerification
+	if err := s.sendVerificationEmail(user); err != nil {
+		logger.Error("Failed to send verification email after registration", "userID", user.ID, "error", err)
+	}
+
 	logger.Info("User registration successful", "email", email, "userID", user.ID)
 	return user, nil
 }
 
-// Login authenticates a user and returns a token
-func (s *userServiceImpl) Login(email, password string) (string, *entity.User, error) {
-	logger.Info("Starting user login", "email", email)
+// Login authenticates a user with their password. Users with MFA enabled get a
+// challenge to complete through VerifyMFA instead of tokens. Repeated failures
+// for the same email or client IP lock further attempts out with a LoginLockedError.
+func (s *userServiceImpl) Login(ctx context.Context, email, password string) (*LoginResult, error) {
+	clientIP := middleware.ClientIP(ctx)
+	logger.Info("Starting user login", "email", email, "ip", clientIP)
+
+	if err := s.loginGuard.check(email, clientIP); err != nil {
+		logger.Warn("Login rejected - locked out", "email", email, "ip", clientIP, "error", err)
+		return nil, err
+	}
 
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(email)
 	if err != nil {
 		logger.Error("Login failed - user not found", "email", email, "error", err)
-		return "", nil, errors.New("invalid email or password")
+		s.loginGuard.recordFailure(email, clientIP)
+		return nil, errors.New("invalid email or password")
 	}
 
 	// Verify password
 	if !helpers.CheckPassword(password, user.Password) {
 		logger.Error("Login failed - invalid password", "email", email)
-		return "", nil, errors.New("invalid email or password")
+		s.loginGuard.recordFailure(email, clientIP)
+		return nil, errors.New("invalid email or password")
 	}
 
-	// Generate token
-	token, err := helpers.GenerateToken(user.ID, user.Email, user.Role)
+	s.loginGuard.reset(email)
+
+	// Upgrade legacy bcrypt hashes and outdated argon2id parameters while the plain password is known
+	if helpers.PasswordNeedsRehash(user.Password) {
+		s.rehashPassword(user, password)
+	}
+
+	if user.IsDisabled() {
+		logger.Error("Login failed - account is disabled", "email", email, "userID", user.ID)
+		return nil, errors.New("account is disabled")
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// completeLogin finishes the login of an authenticated user: users with MFA
+// enabled get a challenge, staff who must enroll in MFA get a token restricted
+// to enrollment and everyone else gets a token pair
+func (s *userServiceImpl) completeLogin(ctx context.Context, user *entity.User) (*LoginResult, error) {
+	// Second factor required before any token is issued
+	if user.MFAEnabled() {
+		mfaToken, err := helpers.GenerateScopedToken(user.ID, user.Email, user.Role, user.TokenVersion, helpers.ScopeMFAChallenge, mfaChallengeTTL)
+		if err != nil {
+			logger.Error("Failed to generate MFA challenge token", "email", user.Email, "error", err)
+			return nil, err
+		}
+
+		logger.Info("User login requires MFA verification", "email", user.Email, "userID", user.ID)
+		return &LoginResult{User: user, MFARequired: true, MFAToken: mfaToken}, nil
+	}
+
+	// Staff who must use MFA but have not enrolled yet only get a token for enrollment
+	if s.mfaEnrollmentRequired(user) {
+		enrollmentToken, err := helpers.GenerateScopedToken(user.ID, user.Email, user.Role, user.TokenVersion, helpers.ScopeMFAEnrollment, mfaEnrollmentTTL)
+		if err != nil {
+			logger.Error("Failed to generate MFA enrollment token", "email", user.Email, "error", err)
+			return nil, err
+		}
+
+		logger.Info("User login requires MFA enrollment", "email", user.Email, "userID", user.ID)
+		return &LoginResult{
+			User:                  user,
+			Tokens:                &AuthTokens{AccessToken: enrollmentToken, ExpiresIn: mfaEnrollmentTTL},
+			MFAEnrollmentRequired: true,
+		}, nil
+	}
+
+	// Generate tokens
+	tokens, err := s.issueTokens(ctx, user)
 	if err != nil {
-		logger.Error("Failed to generate token", "email", email, "error", err)
-		return "", nil, err
+		logger.Error("Failed to generate token", "email", user.Email, "error", err)
+		return nil, err
+	}
+
+	logger.Info("User login successful", "email", user.Email, "userID", user.ID)
+	return &LoginResult{User: user, Tokens: tokens}, nil
+}
+
+// rehashPassword stores the password hashed with the current algorithm. A
+// failure is only logged since the old hash keeps working.
+func (s *userServiceImpl) rehashPassword(user *entity.User, password string) {
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		logger.Error("Failed to rehash password", "userID", user.ID, "error", err)
+		return
 	}
 
-	logger.Info("User login successful", "email", email, "userID", user.ID)
-	return token, user, nil
+	user.Password = hashedPassword
+	if err := s.userRepo.Update(user); err != nil {
+		logger.Error("Failed to store rehashed password", "userID", user.ID, "error", err)
+		return
+	}
+
+	logger.Info("Password hash upgraded", "userID", user.ID)
 }
 
-// GetProfile retrieves user profile by token
-func (s *userServiceImpl) GetProfile(token string) (*entity.User, error) {
+// GetProfile retrieves the profile of the authenticated user
+func (s *userServiceImpl) GetProfile(ctx context.Context) (*entity.User, error) {
 	logger.Info("Getting user profile")
 
-	user, err := s.auth.ValidateUserToken(token)
+	user, err := currentUser(ctx)
 	if err != nil {
 		logger.Error("Failed to get user profile", "error", err)
 		return nil, err
@@ -115,7 +262,8 @@ func (s *userServiceImpl) GetProfile(token string) (*entity.User, error) {
 }
 
 // UpdateProfile updates user profile
-func (s *userServiceImpl) UpdateProfile(userID uint, name, email string) (*entity.User, error) {
+func (s *userServiceImpl) UpdateProfile(userID uint, name, email, phone, address string) (*entity.User, error) {
+	email = helpers.NormalizeEmail(email)
 	logger.Info("Starting profile update", "userID", userID, "name", name, "email", email)
 
 	// Get existing user
@@ -125,22 +273,31 @@ func (s *userServiceImpl) UpdateProfile(userID uint, name, email string) (*entit
 		return nil, err
 	}
 
-	// Check if email is already taken by another user
-	if email != user.Email {
+	// Check if email is already taken by another user. Emails differing only
+	// in case are the same address.
+	emailChanged := !strings.EqualFold(email, user.Email)
+	if emailChanged {
 		existingUser, err := s.userRepo.GetByEmail(email)
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.Error("Failed to check email availability", "email", email, "error", err)
 			return nil, err
 		}
-		if existingUser != nil {
+		if existingUser != nil && existingUser.ID != user.ID {
 			logger.Error("Profile update failed - email already taken", "email", email, "userID", userID)
-			return nil, errors.New("email is already taken")
+			return nil, ErrEmailTaken
 		}
 	}
 
+	// A new email address has to be verified again
+	if emailChanged {
+		user.EmailVerifiedAt = nil
+	}
+
 	// Update user fields
 	user.Name = name
 	user.Email = email
+	user.Phone = phone
+	user.Address = address
 
 	// Save updated user
 	err = s.userRepo.Update(user)
@@ -149,42 +306,268 @@ func (s *userServiceImpl) UpdateProfile(userID uint, name, email string) (*entit
 		return nil, err
 	}
 
+	if emailChanged {
+		if err := s.sendVerificationEmail(user); err != nil {
+			logger.Error("Failed to send verification email after email change", "userID", userID, "error", err)
+		}
+	}
+
 	logger.Info("Profile update successful", "userID", userID, "email", email)
 	return user, nil
 }
 
-// ChangePassword changes user password
-func (s *userServiceImpl) ChangePassword(userID uint, oldPassword, newPassword string) error {
+// ChangePassword changes user password, revokes every previously issued token
+// and returns a fresh token pair for the caller
+func (s *userServiceImpl) ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) (*AuthTokens, error) {
 	logger.Info("Starting password change", "userID", userID)
 
 	// Get user
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
 		logger.Error("Failed to get user for password change", "userID", userID, "error", err)
-		return err
+		return nil, err
 	}
 
 	// Verify old password
 	if !helpers.CheckPassword(oldPassword, user.Password) {
 		logger.Error("Password change failed - invalid old password", "userID", userID)
-		return errors.New("invalid old password")
+		return nil, errors.New("invalid old password")
+	}
+
+	if err := helpers.ValidatePasswordPolicy(newPassword); err != nil {
+		logger.Error("Password change failed - password policy", "userID", userID, "error", err)
+		return nil, err
 	}
 
 	// Hash new password
 	hashedPassword, err := helpers.HashPassword(newPassword)
 	if err != nil {
 		logger.Error("Failed to hash new password", "userID", userID, "error", err)
-		return err
+		return nil, err
 	}
 
-	// Update password
+	// Update password and invalidate tokens held by other sessions
 	user.Password = hashedPassword
+	user.TokenVersion++
 	err = s.userRepo.Update(user)
 	if err != nil {
 		logger.Error("Failed to update password", "userID", userID, "error", err)
-		return err
+		return nil, err
+	}
+
+	if err := s.tokenRepo.RevokeAllSessions(user.ID); err != nil {
+		logger.Error("Failed to revoke sessions after password change", "userID", userID, "error", err)
+		return nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user)
+	if err != nil {
+		logger.Error("Failed to generate token after password change", "userID", userID, "error", err)
+		return nil, err
 	}
 
 	logger.Info("Password change successful", "userID", userID)
+	return tokens, nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. The presented
+// refresh token is rotated; presenting an already rotated token is treated as
+// token theft and revokes every session of the user. Tokens revoked by a logout
+// are simply invalid.
+func (s *userServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (*AuthTokens, *entity.User, error) {
+	logger.Info("Starting token refresh")
+
+	stored, err := s.tokenRepo.GetRefreshTokenByHash(helpers.HashToken(refreshToken))
+	if err != nil {
+		logger.Error("Token refresh failed - refresh token not found", "error", err)
+		return nil, nil, errors.New("invalid refresh token")
+	}
+
+	if stored.RevokedAt != nil {
+		if stored.ReplacedBy == nil {
+			logger.Error("Token refresh failed - refresh token revoked", "userID", stored.UserID, "tokenID", stored.ID)
+			return nil, nil, errors.New("invalid refresh token")
+		}
+		logger.Error("Token refresh failed - refresh token reuse detected", "userID", stored.UserID, "tokenID", stored.ID)
+		if err := s.tokenRepo.RevokeAllSessions(stored.UserID); err != nil {
+			logger.Error("Failed to revoke sessions after reuse detection", "userID", stored.UserID, "error", err)
+		}
+		return nil, nil, errors.New("invalid refresh token")
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		logger.Error("Token refresh failed - refresh token expired", "userID", stored.UserID, "tokenID", stored.ID)
+		return nil, nil, errors.New("refresh token has expired")
+	}
+
+	user, err := s.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		logger.Error("Token refresh failed - user not found", "userID", stored.UserID, "error", err)
+		return nil, nil, errors.New("invalid refresh token")
+	}
+
+	if user.IsDisabled() {
+		logger.Error("Token refresh failed - account is disabled", "userID", user.ID)
+		return nil, nil, errors.New("account is disabled")
+	}
+
+	if s.mfaEnrollmentRequired(user) {
+		logger.Error("Token refresh failed - MFA enrollment required", "userID", user.ID)
+		return nil, nil, errors.New("multi-factor authentication enrollment required, please log in again")
+	}
+
+	var sessionID uint
+	if stored.SessionID != nil {
+		session, err := s.tokenRepo.GetSession(*stored.SessionID)
+		if err != nil || !session.IsActive(time.Now()) {
+			logger.Error("Token refresh failed - session revoked or expired", "userID", user.ID, "sessionID", *stored.SessionID, "error", err)
+			return nil, nil, errors.New("invalid refresh token")
+		}
+		sessionID = session.ID
+	} else {
+		// Refresh tokens issued before sessions existed get a session on their first use
+		session, err := s.newSession(ctx, user.ID)
+		if err != nil {
+			logger.Error("Failed to create session for legacy refresh token", "userID", user.ID, "error", err)
+			return nil, nil, err
+		}
+		sessionID = session.ID
+	}
+
+	accessToken, err := helpers.GenerateToken(user.ID, user.Email, user.Role, user.TokenVersion, sessionID)
+	if err != nil {
+		logger.Error("Failed to generate access token", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+
+	rotatedToken, replacement, err := newRefreshToken(user.ID, sessionID)
+	if err != nil {
+		logger.Error("Failed to generate refresh token", "userID", user.ID, "error", err)
+		return nil, nil, err
+	}
+
+	if err := s.tokenRepo.RotateRefreshToken(stored, replacement); err != nil {
+		logger.Error("Failed to rotate refresh token", "userID", user.ID, "error", err)
+		return nil, nil, errors.New("invalid refresh token")
+	}
+
+	logger.Info("Token refresh successful", "userID", user.ID)
+	return &AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: rotatedToken,
+		ExpiresIn:    helpers.AccessTokenTTL(),
+	}, user, nil
+}
+
+// Logout revokes the caller's access token and session and, if provided, the
+// refresh token of the same session
+func (s *userServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	logger.Info("Starting logout")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Logout failed - unauthenticated", "error", err)
+		return err
+	}
+
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		logger.Error("Logout failed - token claims not found", "userID", user.ID)
+		return errors.New("unauthenticated")
+	}
+
+	if err := s.tokenRepo.RevokeAccessToken(claims.ID, user.ID, claims.ExpiresAt.Time); err != nil {
+		logger.Error("Failed to revoke access token", "userID", user.ID, "error", err)
+		return err
+	}
+
+	if claims.SessionID != 0 {
+		if err := s.tokenRepo.RevokeSession(claims.SessionID); err != nil {
+			logger.Error("Failed to revoke session", "userID", user.ID, "sessionID", claims.SessionID, "error", err)
+			return err
+		}
+	}
+
+	if refreshToken != "" {
+		stored, err := s.tokenRepo.GetRefreshTokenByHash(helpers.HashToken(refreshToken))
+		if err == nil && stored.UserID == user.ID {
+			if err := s.tokenRepo.RevokeRefreshToken(stored.ID); err != nil {
+				logger.Error("Failed to revoke refresh token", "userID", user.ID, "error", err)
+				return err
+			}
+		}
+	}
+
+	logger.Info("Logout successful", "userID", user.ID)
 	return nil
 }
+
+// LogoutAllDevices revokes every session, access and refresh token of the caller
+func (s *userServiceImpl) LogoutAllDevices(ctx context.Context) error {
+	logger.Info("Starting logout from all devices")
+
+	user, err := currentUser(ctx)
+	if err != nil {
+		logger.Error("Logout from all devices failed - unauthenticated", "error", err)
+		return err
+	}
+
+	// Bumping the token version invalidates every access token issued so far.
+	// The user in the context may be a cached copy, so it is not saved back.
+	if err := s.userRepo.IncrementTokenVersion(user.ID); err != nil {
+		logger.Error("Failed to update token version", "userID", user.ID, "error", err)
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllSessions(user.ID); err != nil {
+		logger.Error("Failed to revoke sessions", "userID", user.ID, "error", err)
+		return err
+	}
+
+	logger.Info("Logout from all devices successful", "userID", user.ID)
+	return nil
+}
+
+// issueTokens starts a new session for the user on the calling device and
+// generates its access token and refresh token
+func (s *userServiceImpl) issueTokens(ctx context.Context, user *entity.User) (*AuthTokens, error) {
+	session, err := s.newSession(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := helpers.GenerateToken(user.ID, user.Email, user.Role, user.TokenVersion, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, stored, err := newRefreshToken(user.ID, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.CreateRefreshToken(stored); err != nil {
+		return nil, err
+	}
+
+	return &AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    helpers.AccessTokenTTL(),
+	}, nil
+}
+
+// newRefreshToken generates a refresh token and the entity used to persist its hash
+func newRefreshToken(userID, sessionID uint) (string, *entity.RefreshToken, error) {
+	refreshToken, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return refreshToken, &entity.RefreshToken{
+		UserID:    userID,
+		SessionID: &sessionID,
+		TokenHash: helpers.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(helpers.RefreshTokenTTL()),
+	}, nil
+}
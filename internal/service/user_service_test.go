@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+)
+
+func newTestUserService(t *testing.T, repos *testRepos) *userServiceImpl {
+	t.Helper()
+
+	initTestTokens(t)
+	return NewUserService(repos.users, repos.tokens, repos.attempts, repos.mfa, repos.resets, repos.oidc, nil, nil,
+		config.LoginLockoutConfig{}, config.MFAConfig{}, config.PasswordResetConfig{}, config.EmailVerificationConfig{}, config.OIDCConfig{}).(*userServiceImpl)
+}
+
+// activeSessions counts the unrevoked sessions of user
+func (r *testRepos) activeSessions(t *testing.T, user *entity.User) int {
+	t.Helper()
+
+	sessions, err := r.tokens.GetActiveSessions(user.ID)
+	if err != nil {
+		t.Fatalf("GetActiveSessions: %v", err)
+	}
+	return len(sessions)
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	issued, err := service.issueTokens(context.Background(), user)
+	if err != nil {
+		t.Fatalf("issueTokens: %v", err)
+	}
+
+	refreshed, _, err := service.RefreshToken(context.Background(), issued.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if refreshed.RefreshToken == issued.RefreshToken {
+		t.Error("RefreshToken did not rotate the refresh token")
+	}
+	before, _ := helpers.ValidateToken(issued.AccessToken)
+	after, err := helpers.ValidateToken(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if after.SessionID != before.SessionID {
+		t.Errorf("refreshed access token has session %d, want %d", after.SessionID, before.SessionID)
+	}
+
+	// The rotated token works once more
+	if _, _, err := service.RefreshToken(context.Background(), refreshed.RefreshToken); err != nil {
+		t.Errorf("RefreshToken() with the rotated token: %v", err)
+	}
+}
+
+func TestRefreshTokenReuseRevokesEverySession(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	stolen, _ := service.issueTokens(context.Background(), user)
+	other, _ := service.issueTokens(context.Background(), user)
+
+	legitimate, _, err := service.RefreshToken(context.Background(), stolen.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	// Presenting the rotated token again means two parties hold it
+	if _, _, err := service.RefreshToken(context.Background(), stolen.RefreshToken); err == nil {
+		t.Fatal("RefreshToken accepted a rotated token")
+	}
+	if n := repos.activeSessions(t, user); n != 0 {
+		t.Errorf("%d sessions active after refresh token reuse, want 0", n)
+	}
+	for name, token := range map[string]string{"replacement": legitimate.RefreshToken, "other session": other.RefreshToken} {
+		if _, _, err := service.RefreshToken(context.Background(), token); err == nil {
+			t.Errorf("refresh token of the %s still works after reuse", name)
+		}
+	}
+}
+
+func TestRefreshTokenRevokedByLogoutIsNotReuse(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	loggedOut, _ := service.issueTokens(context.Background(), user)
+	other, _ := service.issueTokens(context.Background(), user)
+
+	if err := service.Logout(authenticated(t, user, loggedOut.AccessToken), loggedOut.RefreshToken); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+
+	if _, _, err := service.RefreshToken(context.Background(), loggedOut.RefreshToken); err == nil || err.Error() != "invalid refresh token" {
+		t.Errorf("RefreshToken() after logout = %v, want invalid refresh token", err)
+	}
+	if n := repos.activeSessions(t, user); n != 1 {
+		t.Errorf("%d sessions active after refreshing a logged out token, want the other one", n)
+	}
+	if _, _, err := service.RefreshToken(context.Background(), other.RefreshToken); err != nil {
+		t.Errorf("RefreshToken() of the other session: %v", err)
+	}
+}
+
+func TestLogoutAllDevicesKeepsConcurrentChanges(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	issued, _ := service.issueTokens(context.Background(), user)
+	ctx := authenticated(t, user, issued.AccessToken)
+
+	// The user in the context is a stale copy once the profile changes
+	if err := repos.db.Model(&entity.User{}).Where("id = ?", user.ID).Update("name", "Renamed").Error; err != nil {
+		t.Fatalf("rename user: %v", err)
+	}
+
+	if err := service.LogoutAllDevices(ctx); err != nil {
+		t.Fatalf("LogoutAllDevices: %v", err)
+	}
+
+	after := repos.reload(t, user)
+	if after.Name != "Renamed" {
+		t.Errorf("name after LogoutAllDevices = %q, want the concurrent change kept", after.Name)
+	}
+	if after.TokenVersion != user.TokenVersion+1 {
+		t.Errorf("token version after LogoutAllDevices = %d, want %d", after.TokenVersion, user.TokenVersion+1)
+	}
+	if n := repos.activeSessions(t, user); n != 0 {
+		t.Errorf("%d sessions active after LogoutAllDevices, want 0", n)
+	}
+}
+
+func TestUpdateProfileEmailIgnoresCase(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	repos.createUser(t, "other", entity.RoleUser)
+
+	updated, err := service.UpdateProfile(user.ID, user.Name, " Reader@Example.com", "", "")
+	if err != nil {
+		t.Fatalf("UpdateProfile() with the own email in another case: %v", err)
+	}
+	if updated.Email != "reader@example.com" || !updated.IsEmailVerified() {
+		t.Errorf("user after UpdateProfile = email %q, verified %v, want the same verified address", updated.Email, updated.IsEmailVerified())
+	}
+
+	if _, err := service.UpdateProfile(user.ID, user.Name, "OTHER@example.com", "", ""); err == nil {
+		t.Error("UpdateProfile took the email of another user in another case")
+	}
+}
+
+func TestEmailTakenIsReported(t *testing.T) {
+	repos := newTestRepos(t)
+	service := newTestUserService(t, repos)
+	user := repos.createUser(t, "reader", entity.RoleUser)
+	repos.createUser(t, "other", entity.RoleUser)
+
+	if _, err := service.UpdateProfile(user.ID, user.Name, "other@example.com", "", ""); !errors.Is(err, ErrEmailTaken) {
+		t.Errorf("UpdateProfile() with a taken email = %v, want ErrEmailTaken", err)
+	}
+	if _, err := service.Register("Other", "Other@Example.com", "a long enough passphrase 42"); !errors.Is(err, ErrEmailTaken) {
+		t.Errorf("Register() with a taken email = %v, want ErrEmailTaken", err)
+	}
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/nabil/book-store-system/pkg/helpers"
+)
+
+// AddressRequestDTO represents the data transfer object for creating or updating an address
+type AddressRequestDTO struct {
+	RecipientName string `json:"recipient_name" validate:"required,min=2,max=100"`
+	Phone         string `json:"phone" validate:"required,min=6,max=20"`
+	Street        string `json:"street" validate:"required,min=3,max=255"`
+	District      string `json:"district" validate:"omitempty,max=100"`
+	City          string `json:"city" validate:"required,max=100"`
+	Province      string `json:"province" validate:"required,max=100"`
+	PostalCode    string `json:"postal_code" validate:"required,numeric,len=5"`
+}
+
+// ValidateAddressRequest trims and validates the AddressRequestDTO
+func (a *AddressRequestDTO) ValidateAddressRequest() error {
+	for _, field := range []*string{&a.RecipientName, &a.Phone, &a.Street, &a.District, &a.City, &a.Province, &a.PostalCode} {
+		*field = strings.TrimSpace(*field)
+	}
+	return helpers.ValidateStruct(a)
+}
+
+// AddressIDRequestDTO represents the data transfer object for requests addressing a single address
+type AddressIDRequestDTO struct {
+	ID uint32 `json:"id" validate:"required,min=1"`
+}
+
+// ValidateAddressIDRequest validates the AddressIDRequestDTO
+func (a *AddressIDRequestDTO) ValidateAddressIDRequest() error {
+	return helpers.ValidateStruct(a)
+}
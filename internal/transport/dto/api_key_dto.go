@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nabil/book-store-system/pkg/helpers"
+)
+
+// CreateAPIKeyRequestDTO represents the data transfer object for creating an API key
+type CreateAPIKeyRequestDTO struct {
+	Name               string   `json:"name" validate:"required,min=2,max=100"`
+	Scopes             []string `json:"scopes" validate:"required,min=1,dive,required,max=50"`
+	RateLimitPerMinute int32    `json:"rate_limit_per_minute" validate:"omitempty,min=1,max=100000"`
+	ExpiresAt          string   `json:"expires_at" validate:"omitempty"`
+}
+
+// ValidateCreateAPIKeyRequest validates the CreateAPIKeyRequestDTO and returns the parsed expiry
+func (c *CreateAPIKeyRequestDTO) ValidateCreateAPIKeyRequest() (*time.Time, error) {
+	// First validate using struct tags
+	if err := helpers.ValidateStruct(c); err != nil {
+		return nil, err
+	}
+
+	if c.ExpiresAt == "" {
+		return nil, nil
+	}
+
+	// Validate expiry format (RFC3339)
+	expiresAt, err := time.Parse(time.RFC3339, c.ExpiresAt)
+	if err != nil {
+		return nil, errors.New("expires_at must be in RFC3339 format")
+	}
+	if !expiresAt.After(time.Now()) {
+		return nil, errors.New("expires_at must be in the future")
+	}
+
+	return &expiresAt, nil
+}
+
+// ListAPIKeysRequestDTO represents the data transfer object for listing API keys
+type ListAPIKeysRequestDTO struct {
+	Page  int32 `json:"page" validate:"omitempty,min=1"`
+	Limit int32 `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ValidateListAPIKeysRequest validates the ListAPIKeysRequestDTO
+func (l *ListAPIKeysRequestDTO) ValidateListAPIKeysRequest() error {
+	// Set default values if not provided
+	if l.Page < 1 {
+		l.Page = 1
+	}
+	if l.Limit < 1 {
+		l.Limit = 10
+	}
+	return helpers.ValidateStruct(l)
+}
+
+// APIKeyIDRequestDTO represents the data transfer object for operations on a single API key
+type APIKeyIDRequestDTO struct {
+	ID uint32 `json:"id" validate:"required,min=1"`
+}
+
+// ValidateAPIKeyIDRequest validates the APIKeyIDRequestDTO
+func (a *APIKeyIDRequestDTO) ValidateAPIKeyIDRequest() error {
+	return helpers.ValidateStruct(a)
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nabil/book-store-system/pkg/helpers"
+)
+
+// ListAuditEventsRequestDTO represents the data transfer object for listing audit events
+type ListAuditEventsRequestDTO struct {
+	Page       int32  `json:"page" validate:"omitempty,min=1"`
+	Limit      int32  `json:"limit" validate:"omitempty,min=1,max=100"`
+	Action     string `json:"action" validate:"omitempty,max=50"`
+	EntityType string `json:"entity_type" validate:"omitempty,max=50"`
+	RequestID  string `json:"request_id" validate:"omitempty,max=128"`
+	From       string `json:"from" validate:"omitempty"`
+	To         string `json:"to" validate:"omitempty"`
+}
+
+// ValidateListAuditEventsRequest validates the ListAuditEventsRequestDTO and
+// returns the parsed time range
+func (l *ListAuditEventsRequestDTO) ValidateListAuditEventsRequest() (*time.Time, *time.Time, error) {
+	// Set default values if not provided
+	if l.Page < 1 {
+		l.Page = 1
+	}
+	if l.Limit < 1 {
+		l.Limit = 10
+	}
+	if err := helpers.ValidateStruct(l); err != nil {
+		return nil, nil, err
+	}
+
+	var from, to *time.Time
+	if l.From != "" {
+		parsed, err := time.Parse(time.RFC3339, l.From)
+		if err != nil {
+			return nil, nil, errors.New("from must be in RFC3339 format")
+		}
+		from = &parsed
+	}
+	if l.To != "" {
+		parsed, err := time.Parse(time.RFC3339, l.To)
+		if err != nil {
+			return nil, nil, errors.New("to must be in RFC3339 format")
+		}
+		to = &parsed
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, errors.New("from must be before to")
+	}
+
+	return from, to, nil
+}
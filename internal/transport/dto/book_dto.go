@@ -7,12 +7,13 @@ import (
 type CreateBookRequestDTO struct {
 	Title       string  `json:"title" validate:"required,min=2,max=200"`
 	Author      string  `json:"author" validate:"required,min=2,max=100"`
+	ISBN        string  `json:"isbn" validate:"omitempty,max=20"`
+	Description string  `json:"description" validate:"max=5000"`
 	ImageBase64 string  `json:"image_base64"`
 	Year        int32   `json:"year" validate:"required,min=1900,max=2025"`
 	Price       float64 `json:"price" validate:"required,min=0.01"`
 	Stock       int32   `json:"stock" validate:"required,min=0"`
 	CategoryID  uint32  `json:"category_id" validate:"required,min=1"`
-	Token       string  `json:"token" validate:"required"`
 }
 
 // ValidateCreateBookRequest validates the CreateBookRequestDTO
@@ -24,12 +25,13 @@ type UpdateBookRequestDTO struct {
 	ID          uint32  `json:"id" validate:"required,min=1"`
 	Title       string  `json:"title" validate:"required,min=2,max=200"`
 	Author      string  `json:"author" validate:"required,min=2,max=100"`
+	ISBN        string  `json:"isbn" validate:"omitempty,max=20"`
+	Description string  `json:"description" validate:"max=5000"`
 	ImageBase64 string  `json:"image_base64"`
 	Year        int32   `json:"year" validate:"required,min=1900,max=2025"`
 	Price       float64 `json:"price" validate:"required,min=0.01"`
 	Stock       int32   `json:"stock" validate:"required,min=0"`
 	CategoryID  uint32  `json:"category_id" validate:"required,min=1"`
-	Token       string  `json:"token" validate:"required"`
 }
 
 // ValidateUpdateBookRequest validates the UpdateBookRequestDTO
@@ -37,9 +39,17 @@ func (u *UpdateBookRequestDTO) ValidateUpdateBookRequest() error {
 	return helpers.ValidateStruct(u)
 }
 
+type UploadBookCoverRequestDTO struct {
+	BookID uint32 `json:"book_id" validate:"required,min=1"`
+}
+
+// ValidateUploadBookCoverRequest validates the UploadBookCoverRequestDTO
+func (u *UploadBookCoverRequestDTO) ValidateUploadBookCoverRequest() error {
+	return helpers.ValidateStruct(u)
+}
+
 type DeleteBookRequestDTO struct {
-	ID    uint32 `json:"id" validate:"required,min=1"`
-	Token string `json:"token" validate:"required"`
+	ID uint32 `json:"id" validate:"required,min=1"`
 }
 
 // ValidateDeleteBookRequest validates the DeleteBookRequestDTO
@@ -48,9 +58,10 @@ func (d *DeleteBookRequestDTO) ValidateDeleteBookRequest() error {
 }
 
 type GetBooksRequestDTO struct {
-	Page   int32  `json:"page" validate:"omitempty,min=1"`
-	Limit  int32  `json:"limit" validate:"omitempty,min=1,max=100"`
-	Search string `json:"search"`
+	Page       int32  `json:"page" validate:"omitempty,min=1"`
+	Limit      int32  `json:"limit" validate:"omitempty,min=1,max=100"`
+	Search     string `json:"search" validate:"max=200"`
+	SearchMode string `json:"search_mode" validate:"omitempty,oneof=fulltext substring"`
 }
 
 // ValidateGetBooksRequest validates the GetBooksRequestDTO
@@ -74,6 +85,15 @@ func (g *GetBookRequestDTO) ValidateGetBookRequest() error {
 	return helpers.ValidateStruct(g)
 }
 
+type GetBookByISBNRequestDTO struct {
+	ISBN string `json:"isbn" validate:"required,max=20"`
+}
+
+// ValidateGetBookByISBNRequest validates the GetBookByISBNRequestDTO
+func (g *GetBookByISBNRequestDTO) ValidateGetBookByISBNRequest() error {
+	return helpers.ValidateStruct(g)
+}
+
 type GetBooksByCategoryRequestDTO struct {
 	CategoryID uint32 `json:"category_id" validate:"required,min=1"`
 	Page       int32  `json:"page" validate:"omitempty,min=1"`
@@ -90,3 +110,16 @@ func (g *GetBooksByCategoryRequestDTO) ValidateGetBooksByCategoryRequest() error
 	}
 	return helpers.ValidateStruct(g)
 }
+
+type SuggestBooksRequestDTO struct {
+	Prefix string `json:"prefix" validate:"required,min=2,max=100"`
+	Limit  int32  `json:"limit" validate:"omitempty,min=1,max=10"`
+}
+
+// ValidateSuggestBooksRequest validates the SuggestBooksRequestDTO
+func (s *SuggestBooksRequestDTO) ValidateSuggestBooksRequest() error {
+	if s.Limit < 1 {
+		s.Limit = 5
+	}
+	return helpers.ValidateStruct(s)
+}
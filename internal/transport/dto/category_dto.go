@@ -5,8 +5,7 @@ import (
 )
 
 type CreateCategoryRequestDTO struct {
-	Name  string `json:"name" validate:"required,min=2,max=100"`
-	Token string `json:"token" validate:"required"`
+	Name string `json:"name" validate:"required,min=2,max=100"`
 }
 
 // ValidateCreateCategoryRequest validates the CreateCategoryRequestDTO
@@ -15,9 +14,8 @@ func (c *CreateCategoryRequestDTO) ValidateCreateCategoryRequest() error {
 }
 
 type UpdateCategoryRequestDTO struct {
-	ID    uint32 `json:"id" validate:"required,min=1"`
-	Name  string `json:"name" validate:"required,min=2,max=100"`
-	Token string `json:"token" validate:"required"`
+	ID   uint32 `json:"id" validate:"required,min=1"`
+	Name string `json:"name" validate:"required,min=2,max=100"`
 }
 
 // ValidateUpdateCategoryRequest validates the UpdateCategoryRequestDTO
@@ -26,8 +24,7 @@ func (u *UpdateCategoryRequestDTO) ValidateUpdateCategoryRequest() error {
 }
 
 type DeleteCategoryRequestDTO struct {
-	ID    uint32 `json:"id" validate:"required,min=1"`
-	Token string `json:"token" validate:"required"`
+	ID uint32 `json:"id" validate:"required,min=1"`
 }
 
 // ValidateDeleteCategoryRequest validates the DeleteCategoryRequestDTO
@@ -59,4 +56,4 @@ type GetCategoryRequestDTO struct {
 // ValidateGetCategoryRequest validates the GetCategoryRequestDTO
 func (g *GetCategoryRequestDTO) ValidateGetCategoryRequest() error {
 	return helpers.ValidateStruct(g)
-}
\ No newline at end of file
+}
@@ -19,8 +19,8 @@ func (o *OrderItemRequestDTO) ValidateOrderItemRequest() error {
 
 // CreateOrderRequestDTO represents the data transfer object for creating order
 type CreateOrderRequestDTO struct {
-	Items []OrderItemRequestDTO `json:"items" validate:"required,min=1,dive"`
-	Token string                `json:"token" validate:"required"`
+	Items     []OrderItemRequestDTO `json:"items" validate:"required,min=1,dive"`
+	AddressID uint32                `json:"address_id"`
 }
 
 // ValidateCreateOrderRequest validates the CreateOrderRequestDTO
@@ -38,9 +38,8 @@ func (c *CreateOrderRequestDTO) ValidateCreateOrderRequest() error {
 
 // GetOrdersRequestDTO represents the data transfer object for getting orders with pagination
 type GetOrdersRequestDTO struct {
-	Token string `json:"token" validate:"required"`
-	Page  int32  `json:"page" validate:"omitempty,min=1"`
-	Limit int32  `json:"limit" validate:"omitempty,min=1,max=100"`
+	Page  int32 `json:"page" validate:"omitempty,min=1"`
+	Limit int32 `json:"limit" validate:"omitempty,min=1,max=100"`
 }
 
 // ValidateGetOrdersRequest validates the GetOrdersRequestDTO
@@ -57,8 +56,7 @@ func (g *GetOrdersRequestDTO) ValidateGetOrdersRequest() error {
 
 // GetOrderRequestDTO represents the data transfer object for getting single order
 type GetOrderRequestDTO struct {
-	ID    uint32 `json:"id" validate:"required,min=1"`
-	Token string `json:"token" validate:"required"`
+	ID uint32 `json:"id" validate:"required,min=1"`
 }
 
 // ValidateGetOrderRequest validates the GetOrderRequestDTO
@@ -70,7 +68,6 @@ func (g *GetOrderRequestDTO) ValidateGetOrderRequest() error {
 type UpdateOrderStatusRequestDTO struct {
 	ID     uint32 `json:"id" validate:"required,min=1"`
 	Status string `json:"status"`
-	Token  string `json:"token" validate:"required"`
 }
 
 // ValidateUpdateOrderStatusRequest validates the UpdateOrderStatusRequestDTO
@@ -81,7 +78,6 @@ func (u *UpdateOrderStatusRequestDTO) ValidateUpdateOrderStatusRequest() error {
 // ProcessPaymentRequestDTO represents the data transfer object for processing payment
 type ProcessPaymentRequestDTO struct {
 	OrderID uint32 `json:"order_id" validate:"required,min=1"`
-	Token   string `json:"token" validate:"required"`
 }
 
 // ValidateProcessPaymentRequest validates the ProcessPaymentRequestDTO
@@ -11,7 +11,6 @@ import (
 type GetSalesReportRequestDTO struct {
 	StartDate string `json:"start_date" validate:"required"`
 	EndDate   string `json:"end_date" validate:"required"`
-	Token     string `json:"token" validate:"required"`
 }
 
 // ValidateGetSalesReportRequest validates the GetSalesReportRequestDTO
@@ -56,8 +55,7 @@ func (g *GetSalesReportRequestDTO) ValidateGetSalesReportRequest() error {
 }
 
 type GetTopBooksRequestDTO struct {
-	Limit int32  `json:"limit" validate:"omitempty,min=1,max=100"`
-	Token string `json:"token" validate:"required"`
+	Limit int32 `json:"limit" validate:"omitempty,min=1,max=100"`
 }
 
 func (g *GetTopBooksRequestDTO) ValidateGetTopBooksRequest() error {
@@ -68,11 +66,3 @@ func (g *GetTopBooksRequestDTO) ValidateGetTopBooksRequest() error {
 
 	return helpers.ValidateStruct(g)
 }
-
-type GetBookPriceStatisticsRequestDTO struct {
-	Token string `json:"token" validate:"required"`
-}
-
-func (g *GetBookPriceStatisticsRequestDTO) ValidateGetBookPriceStatisticsRequest() error {
-	return helpers.ValidateStruct(g)
-}
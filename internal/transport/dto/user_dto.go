@@ -8,7 +8,7 @@ import (
 type RegisterRequestDTO struct {
 	Name     string `json:"name" validate:"required,min=2,max=100"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6,max=100"`
+	Password string `json:"password" validate:"required,max=1024"` // length is checked by the password policy
 }
 
 // ValidateRegisterRequest validates the RegisterRequestDTO
@@ -27,12 +27,183 @@ func (l *LoginRequestDTO) ValidateLoginRequest() error {
 	return helpers.ValidateStruct(l)
 }
 
-// GetProfileRequestDTO represents the data transfer object for getting user profile
-type GetProfileRequestDTO struct {
-	Token string `json:"token" validate:"required"`
+// UpdateProfileRequestDTO represents the data transfer object for updating user profile
+type UpdateProfileRequestDTO struct {
+	Name    string `json:"name" validate:"required,min=2,max=100"`
+	Email   string `json:"email" validate:"required,email"`
+	Phone   string `json:"phone" validate:"omitempty,min=6,max=20"`
+	Address string `json:"address" validate:"omitempty,max=255"`
 }
 
-// ValidateGetProfileRequest validates the GetProfileRequestDTO
-func (g *GetProfileRequestDTO) ValidateGetProfileRequest() error {
-	return helpers.ValidateStruct(g)
-}
\ No newline at end of file
+// ValidateUpdateProfileRequest validates the UpdateProfileRequestDTO
+func (u *UpdateProfileRequestDTO) ValidateUpdateProfileRequest() error {
+	return helpers.ValidateStruct(u)
+}
+
+// ChangePasswordRequestDTO represents the data transfer object for changing user password
+type ChangePasswordRequestDTO struct {
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,max=1024,nefield=OldPassword"` // length is checked by the password policy
+}
+
+// ValidateChangePasswordRequest validates the ChangePasswordRequestDTO
+func (c *ChangePasswordRequestDTO) ValidateChangePasswordRequest() error {
+	return helpers.ValidateStruct(c)
+}
+
+// RefreshTokenRequestDTO represents the data transfer object for refreshing tokens
+type RefreshTokenRequestDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// ValidateRefreshTokenRequest validates the RefreshTokenRequestDTO
+func (r *RefreshTokenRequestDTO) ValidateRefreshTokenRequest() error {
+	return helpers.ValidateStruct(r)
+}
+
+// ConfirmMFARequestDTO represents the data transfer object for confirming MFA enrollment
+type ConfirmMFARequestDTO struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// ValidateConfirmMFARequest validates the ConfirmMFARequestDTO
+func (c *ConfirmMFARequestDTO) ValidateConfirmMFARequest() error {
+	return helpers.ValidateStruct(c)
+}
+
+// VerifyMFARequestDTO represents the data transfer object for completing an MFA login
+type VerifyMFARequestDTO struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,min=6,max=20"`
+}
+
+// ValidateVerifyMFARequest validates the VerifyMFARequestDTO
+func (v *VerifyMFARequestDTO) ValidateVerifyMFARequest() error {
+	return helpers.ValidateStruct(v)
+}
+
+// RequestPasswordResetRequestDTO represents the data transfer object for requesting a password reset
+type RequestPasswordResetRequestDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ValidateRequestPasswordResetRequest validates the RequestPasswordResetRequestDTO
+func (r *RequestPasswordResetRequestDTO) ValidateRequestPasswordResetRequest() error {
+	return helpers.ValidateStruct(r)
+}
+
+// ConfirmPasswordResetRequestDTO represents the data transfer object for setting a new password with a reset token
+type ConfirmPasswordResetRequestDTO struct {
+	Token       string `json:"token" validate:"required,max=100"`
+	NewPassword string `json:"new_password" validate:"required,max=1024"` // length is checked by the password policy
+}
+
+// ValidateConfirmPasswordResetRequest validates the ConfirmPasswordResetRequestDTO
+func (c *ConfirmPasswordResetRequestDTO) ValidateConfirmPasswordResetRequest() error {
+	return helpers.ValidateStruct(c)
+}
+
+// VerifyEmailRequestDTO represents the data transfer object for verifying an email address
+type VerifyEmailRequestDTO struct {
+	Token string `json:"token" validate:"required,max=2048"`
+}
+
+// ValidateVerifyEmailRequest validates the VerifyEmailRequestDTO
+func (v *VerifyEmailRequestDTO) ValidateVerifyEmailRequest() error {
+	return helpers.ValidateStruct(v)
+}
+
+// BeginOIDCLoginRequestDTO represents the data transfer object for starting a login with an identity provider
+type BeginOIDCLoginRequestDTO struct {
+	Provider string `json:"provider" validate:"required,max=50"`
+}
+
+// ValidateBeginOIDCLoginRequest validates the BeginOIDCLoginRequestDTO
+func (b *BeginOIDCLoginRequestDTO) ValidateBeginOIDCLoginRequest() error {
+	return helpers.ValidateStruct(b)
+}
+
+// CompleteOIDCLoginRequestDTO represents the data transfer object for completing a login with an identity provider
+type CompleteOIDCLoginRequestDTO struct {
+	State string `json:"state" validate:"required,max=128"`
+	Code  string `json:"code" validate:"required,max=2048"`
+}
+
+// ValidateCompleteOIDCLoginRequest validates the CompleteOIDCLoginRequestDTO
+func (c *CompleteOIDCLoginRequestDTO) ValidateCompleteOIDCLoginRequest() error {
+	return helpers.ValidateStruct(c)
+}
+
+// RevokeSessionRequestDTO represents the data transfer object for revoking one of the caller's sessions
+type RevokeSessionRequestDTO struct {
+	ID uint32 `json:"id" validate:"required,min=1"`
+}
+
+// ValidateRevokeSessionRequest validates the RevokeSessionRequestDTO
+func (r *RevokeSessionRequestDTO) ValidateRevokeSessionRequest() error {
+	return helpers.ValidateStruct(r)
+}
+
+// DeleteMyAccountRequestDTO represents the data transfer object for deleting the caller's account
+type DeleteMyAccountRequestDTO struct {
+	// Password is empty for accounts without one
+	Password string `json:"password" validate:"max=1024"`
+}
+
+// ValidateDeleteMyAccountRequest validates the DeleteMyAccountRequestDTO
+func (d *DeleteMyAccountRequestDTO) ValidateDeleteMyAccountRequest() error {
+	return helpers.ValidateStruct(d)
+}
+
+// ListUsersRequestDTO represents the data transfer object for listing users
+type ListUsersRequestDTO struct {
+	Page   int32  `json:"page" validate:"omitempty,min=1"`
+	Limit  int32  `json:"limit" validate:"omitempty,min=1,max=100"`
+	Search string `json:"search" validate:"omitempty,max=100"`
+	Role   string `json:"role" validate:"omitempty,max=50"`
+}
+
+// ValidateListUsersRequest validates the ListUsersRequestDTO
+func (l *ListUsersRequestDTO) ValidateListUsersRequest() error {
+	// Set default values if not provided
+	if l.Page < 1 {
+		l.Page = 1
+	}
+	if l.Limit < 1 {
+		l.Limit = 10
+	}
+	return helpers.ValidateStruct(l)
+}
+
+// UserIDRequestDTO represents the data transfer object for admin operations on a single user
+type UserIDRequestDTO struct {
+	ID uint32 `json:"id" validate:"required,min=1"`
+}
+
+// ValidateUserIDRequest validates the UserIDRequestDTO
+func (u *UserIDRequestDTO) ValidateUserIDRequest() error {
+	return helpers.ValidateStruct(u)
+}
+
+// SetUserRoleRequestDTO represents the data transfer object for changing a user's role
+type SetUserRoleRequestDTO struct {
+	ID   uint32 `json:"id" validate:"required,min=1"`
+	Role string `json:"role" validate:"required,max=50"`
+}
+
+// ValidateSetUserRoleRequest validates the SetUserRoleRequestDTO
+func (s *SetUserRoleRequestDTO) ValidateSetUserRoleRequest() error {
+	return helpers.ValidateStruct(s)
+}
+
+// UpsertRoleRequestDTO represents the data transfer object for creating or updating a role
+type UpsertRoleRequestDTO struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+	Permissions []string `json:"permissions" validate:"dive,required"`
+}
+
+// ValidateUpsertRoleRequest validates the UpsertRoleRequestDTO
+func (u *UpsertRoleRequestDTO) ValidateUpsertRoleRequest() error {
+	return helpers.ValidateStruct(u)
+}
@@ -0,0 +1,200 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/internal/transport/dto"
+	"github.com/nabil/book-store-system/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddressHandler handles gRPC requests for the address book
+type AddressHandler struct {
+	proto.UnimplementedAddressServiceServer
+	addressService service.AddressService
+}
+
+// NewAddressHandler creates a new AddressHandler
+func NewAddressHandler(addressService service.AddressService) *AddressHandler {
+	return &AddressHandler{
+		addressService: addressService,
+	}
+}
+
+// CreateAddress handles adding an address to the caller's address book
+func (h *AddressHandler) CreateAddress(ctx context.Context, req *proto.CreateAddressRequest) (*proto.CreateAddressResponse, error) {
+	// Validate request using DTO
+	addressDTO := &dto.AddressRequestDTO{
+		RecipientName: req.RecipientName,
+		Phone:         req.Phone,
+		Street:        req.Street,
+		District:      req.District,
+		City:          req.City,
+		Province:      req.Province,
+		PostalCode:    req.PostalCode,
+	}
+
+	if err := addressDTO.ValidateAddressRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	address, err := h.addressService.CreateAddress(ctx, toAddressFields(addressDTO), req.IsDefault)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to create address: %v", err)
+	}
+
+	return &proto.CreateAddressResponse{
+		Success: true,
+		Message: "Address created successfully",
+		Address: toProtoAddress(address),
+	}, nil
+}
+
+// ListAddresses retrieves the caller's addresses
+func (h *AddressHandler) ListAddresses(ctx context.Context, req *proto.ListAddressesRequest) (*proto.ListAddressesResponse, error) {
+	addresses, err := h.addressService.ListAddresses(ctx)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to list addresses: %v", err)
+	}
+
+	var protoAddresses []*proto.Address
+	for _, address := range addresses {
+		protoAddresses = append(protoAddresses, toProtoAddress(address))
+	}
+
+	return &proto.ListAddressesResponse{
+		Success:   true,
+		Message:   "Addresses retrieved successfully",
+		Addresses: protoAddresses,
+	}, nil
+}
+
+// GetAddress retrieves one of the caller's addresses
+func (h *AddressHandler) GetAddress(ctx context.Context, req *proto.GetAddressRequest) (*proto.GetAddressResponse, error) {
+	// Validate request using DTO
+	idDTO := &dto.AddressIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := idDTO.ValidateAddressIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	address, err := h.addressService.GetAddress(ctx, uint(idDTO.ID))
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to get address: %v", err)
+	}
+
+	return &proto.GetAddressResponse{
+		Success: true,
+		Message: "Address retrieved successfully",
+		Address: toProtoAddress(address),
+	}, nil
+}
+
+// UpdateAddress handles updating one of the caller's addresses
+func (h *AddressHandler) UpdateAddress(ctx context.Context, req *proto.UpdateAddressRequest) (*proto.UpdateAddressResponse, error) {
+	// Validate request using DTO
+	idDTO := &dto.AddressIDRequestDTO{
+		ID: req.Id,
+	}
+	addressDTO := &dto.AddressRequestDTO{
+		RecipientName: req.RecipientName,
+		Phone:         req.Phone,
+		Street:        req.Street,
+		District:      req.District,
+		City:          req.City,
+		Province:      req.Province,
+		PostalCode:    req.PostalCode,
+	}
+
+	if err := idDTO.ValidateAddressIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+	if err := addressDTO.ValidateAddressRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	address, err := h.addressService.UpdateAddress(ctx, uint(idDTO.ID), toAddressFields(addressDTO), req.IsDefault)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to update address: %v", err)
+	}
+
+	return &proto.UpdateAddressResponse{
+		Success: true,
+		Message: "Address updated successfully",
+		Address: toProtoAddress(address),
+	}, nil
+}
+
+// DeleteAddress handles removing one of the caller's addresses
+func (h *AddressHandler) DeleteAddress(ctx context.Context, req *proto.DeleteAddressRequest) (*proto.DeleteAddressResponse, error) {
+	// Validate request using DTO
+	idDTO := &dto.AddressIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := idDTO.ValidateAddressIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.addressService.DeleteAddress(ctx, uint(idDTO.ID)); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to delete address: %v", err)
+	}
+
+	return &proto.DeleteAddressResponse{
+		Success: true,
+		Message: "Address deleted successfully",
+	}, nil
+}
+
+// toAddressFields converts a validated address DTO into entity fields
+func toAddressFields(addressDTO *dto.AddressRequestDTO) entity.AddressFields {
+	return entity.AddressFields{
+		RecipientName: addressDTO.RecipientName,
+		Phone:         addressDTO.Phone,
+		Street:        addressDTO.Street,
+		District:      addressDTO.District,
+		City:          addressDTO.City,
+		Province:      addressDTO.Province,
+		PostalCode:    addressDTO.PostalCode,
+	}
+}
+
+// toProtoAddress converts an address entity into its protobuf representation
+func toProtoAddress(address *entity.Address) *proto.Address {
+	return &proto.Address{
+		Id:            uint32(address.ID),
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Street:        address.Street,
+		District:      address.District,
+		City:          address.City,
+		Province:      address.Province,
+		PostalCode:    address.PostalCode,
+		IsDefault:     address.IsDefault,
+		CreatedAt:     address.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     address.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// toProtoShippingAddress converts the address snapshot of an order, or returns
+// nil for orders placed without one
+func toProtoShippingAddress(fields entity.AddressFields) *proto.ShippingAddress {
+	if fields == (entity.AddressFields{}) {
+		return nil
+	}
+	return &proto.ShippingAddress{
+		RecipientName: fields.RecipientName,
+		Phone:         fields.Phone,
+		Street:        fields.Street,
+		District:      fields.District,
+		City:          fields.City,
+		Province:      fields.Province,
+		PostalCode:    fields.PostalCode,
+	}
+}
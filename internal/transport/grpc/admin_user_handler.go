@@ -0,0 +1,350 @@
+package grpc
+
+import (
+	"context"
+	"io"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/internal/transport/dto"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminUserHandler handles gRPC requests for user and role management
+type AdminUserHandler struct {
+	proto.UnimplementedAdminUserServiceServer
+	adminUserService service.AdminUserService
+	privacyService   service.PrivacyService
+}
+
+// NewAdminUserHandler creates a new AdminUserHandler
+func NewAdminUserHandler(adminUserService service.AdminUserService, privacyService service.PrivacyService) *AdminUserHandler {
+	return &AdminUserHandler{
+		adminUserService: adminUserService,
+		privacyService:   privacyService,
+	}
+}
+
+// ListUsers retrieves users with pagination and filters
+func (h *AdminUserHandler) ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+	// Validate request using DTO
+	listUsersDTO := &dto.ListUsersRequestDTO{
+		Page:   req.Page,
+		Limit:  req.Limit,
+		Search: req.Search,
+		Role:   req.Role,
+	}
+
+	if err := listUsersDTO.ValidateListUsersRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	users, total, err := h.adminUserService.ListUsers(ctx, int(listUsersDTO.Page), int(listUsersDTO.Limit), req.Search, req.Role)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to list users: %v", err)
+	}
+
+	var protoUsers []*proto.User
+	for _, user := range users {
+		protoUsers = append(protoUsers, toProtoUser(user))
+	}
+
+	// Calculate pagination metadata using validated DTO values
+	paginationMeta := helpers.CalculatePaginationMetadata(int(listUsersDTO.Page), int(listUsersDTO.Limit), total)
+
+	return &proto.ListUsersResponse{
+		Success:     true,
+		Message:     "Users retrieved successfully",
+		Users:       protoUsers,
+		Total:       int32(total),
+		CurrentPage: paginationMeta.CurrentPage,
+		TotalPages:  paginationMeta.TotalPages,
+		HasNext:     paginationMeta.HasNext,
+		HasPrevious: paginationMeta.HasPrevious,
+	}, nil
+}
+
+// GetUser retrieves a user by ID
+func (h *AdminUserHandler) GetUser(ctx context.Context, req *proto.GetUserRequest) (*proto.GetUserResponse, error) {
+	// Validate request using DTO
+	getUserDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := getUserDTO.ValidateUserIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	user, err := h.adminUserService.GetUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.NotFound), "User not found: %v", err)
+	}
+
+	return &proto.GetUserResponse{
+		Success: true,
+		Message: "User retrieved successfully",
+		User:    toProtoUser(user),
+	}, nil
+}
+
+// SetUserRole changes the role of a user
+func (h *AdminUserHandler) SetUserRole(ctx context.Context, req *proto.SetUserRoleRequest) (*proto.SetUserRoleResponse, error) {
+	// Validate request using DTO
+	setRoleDTO := &dto.SetUserRoleRequestDTO{
+		ID:   req.Id,
+		Role: req.Role,
+	}
+
+	if err := setRoleDTO.ValidateSetUserRoleRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	user, err := h.adminUserService.SetUserRole(ctx, uint(req.Id), req.Role)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to set user role: %v", err)
+	}
+
+	return &proto.SetUserRoleResponse{
+		Success: true,
+		Message: "User role updated successfully",
+		User:    toProtoUser(user),
+	}, nil
+}
+
+// DisableUser blocks a user account
+func (h *AdminUserHandler) DisableUser(ctx context.Context, req *proto.DisableUserRequest) (*proto.DisableUserResponse, error) {
+	// Validate request using DTO
+	disableDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := disableDTO.ValidateUserIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	user, err := h.adminUserService.DisableUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to disable user: %v", err)
+	}
+
+	return &proto.DisableUserResponse{
+		Success: true,
+		Message: "User disabled successfully",
+		User:    toProtoUser(user),
+	}, nil
+}
+
+// EnableUser re-enables a disabled user account
+func (h *AdminUserHandler) EnableUser(ctx context.Context, req *proto.EnableUserRequest) (*proto.EnableUserResponse, error) {
+	// Validate request using DTO
+	enableDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := enableDTO.ValidateUserIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	user, err := h.adminUserService.EnableUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to enable user: %v", err)
+	}
+
+	return &proto.EnableUserResponse{
+		Success: true,
+		Message: "User enabled successfully",
+		User:    toProtoUser(user),
+	}, nil
+}
+
+// DeleteUser deletes a user account
+func (h *AdminUserHandler) DeleteUser(ctx context.Context, req *proto.DeleteUserRequest) (*proto.DeleteUserResponse, error) {
+	// Validate request using DTO
+	deleteDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := deleteDTO.ValidateUserIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.adminUserService.DeleteUser(ctx, uint(req.Id)); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to delete user: %v", err)
+	}
+
+	return &proto.DeleteUserResponse{
+		Success: true,
+		Message: "User deleted successfully",
+	}, nil
+}
+
+// ExportUserData streams a JSON archive of a user's personal data
+func (h *AdminUserHandler) ExportUserData(req *proto.ExportUserDataRequest, stream proto.AdminUserService_ExportUserDataServer) error {
+	// Validate request using DTO
+	exportDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := exportDTO.ValidateUserIDRequest(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	err := streamExport(stream, func(w io.Writer) error {
+		return h.privacyService.ExportUserData(stream.Context(), uint(req.Id), w)
+	})
+	if err != nil {
+		return status.Errorf(errorCode(err, codes.Internal), "Failed to export user data: %v", err)
+	}
+	return nil
+}
+
+// AnonymizeUser erases a user's personal data while keeping their orders
+func (h *AdminUserHandler) AnonymizeUser(ctx context.Context, req *proto.AnonymizeUserRequest) (*proto.AnonymizeUserResponse, error) {
+	// Validate request using DTO
+	anonymizeDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := anonymizeDTO.ValidateUserIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	user, err := h.privacyService.AnonymizeUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to anonymize user: %v", err)
+	}
+
+	return &proto.AnonymizeUserResponse{
+		Success: true,
+		Message: "User anonymized successfully",
+		User:    toProtoUser(user),
+	}, nil
+}
+
+// ImpersonateUser issues a short-lived token to act as a customer
+func (h *AdminUserHandler) ImpersonateUser(ctx context.Context, req *proto.ImpersonateUserRequest) (*proto.ImpersonateUserResponse, error) {
+	// Validate request using DTO
+	impersonateDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := impersonateDTO.ValidateUserIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	tokens, user, err := h.adminUserService.ImpersonateUser(ctx, uint(req.Id))
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.FailedPrecondition), "Failed to impersonate user: %v", err)
+	}
+
+	return &proto.ImpersonateUserResponse{
+		Success:   true,
+		Message:   "Impersonation token issued",
+		Token:     tokens.AccessToken,
+		ExpiresIn: int64(tokens.ExpiresIn.Seconds()),
+		User:      toProtoUser(user),
+	}, nil
+}
+
+// UnlockAccount clears the login lockout of a user
+func (h *AdminUserHandler) UnlockAccount(ctx context.Context, req *proto.UnlockAccountRequest) (*proto.UnlockAccountResponse, error) {
+	// Validate request using DTO
+	unlockDTO := &dto.UserIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := unlockDTO.ValidateUserIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.adminUserService.UnlockAccount(ctx, uint(req.Id)); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to unlock account: %v", err)
+	}
+
+	return &proto.UnlockAccountResponse{
+		Success: true,
+		Message: "Account unlocked successfully",
+	}, nil
+}
+
+// ListRoles retrieves every role with its permissions
+func (h *AdminUserHandler) ListRoles(ctx context.Context, req *proto.ListRolesRequest) (*proto.ListRolesResponse, error) {
+	roles, err := h.adminUserService.ListRoles(ctx)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to list roles: %v", err)
+	}
+
+	var protoRoles []*proto.Role
+	for _, role := range roles {
+		protoRoles = append(protoRoles, toProtoRole(role))
+	}
+
+	return &proto.ListRolesResponse{
+		Success: true,
+		Message: "Roles retrieved successfully",
+		Roles:   protoRoles,
+	}, nil
+}
+
+// ListPermissions retrieves every permission that can be granted to a role
+func (h *AdminUserHandler) ListPermissions(ctx context.Context, req *proto.ListPermissionsRequest) (*proto.ListPermissionsResponse, error) {
+	permissions, err := h.adminUserService.ListPermissions(ctx)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to list permissions: %v", err)
+	}
+
+	var protoPermissions []*proto.Permission
+	for _, permission := range permissions {
+		protoPermissions = append(protoPermissions, &proto.Permission{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+
+	return &proto.ListPermissionsResponse{
+		Success:     true,
+		Message:     "Permissions retrieved successfully",
+		Permissions: protoPermissions,
+	}, nil
+}
+
+// UpsertRole creates or updates a role
+func (h *AdminUserHandler) UpsertRole(ctx context.Context, req *proto.UpsertRoleRequest) (*proto.UpsertRoleResponse, error) {
+	// Validate request using DTO
+	upsertRoleDTO := &dto.UpsertRoleRequestDTO{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+
+	if err := upsertRoleDTO.ValidateUpsertRoleRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	role, err := h.adminUserService.UpsertRole(ctx, req.Name, req.Description, req.Permissions)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to save role: %v", err)
+	}
+
+	return &proto.UpsertRoleResponse{
+		Success: true,
+		Message: "Role saved successfully",
+		Role:    toProtoRole(role),
+	}, nil
+}
+
+// toProtoRole converts a role entity into its protobuf representation
+func toProtoRole(role *entity.Role) *proto.Role {
+	protoRole := &proto.Role{
+		Id:          uint32(role.ID),
+		Name:        role.Name,
+		Description: role.Description,
+	}
+	for _, permission := range role.Permissions {
+		protoRole.Permissions = append(protoRole.Permissions, permission.Name)
+	}
+	return protoRole
+}
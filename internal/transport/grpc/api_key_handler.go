@@ -0,0 +1,138 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/internal/transport/dto"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHandler handles gRPC requests for API key management
+type APIKeyHandler struct {
+	proto.UnimplementedAPIKeyServiceServer
+	apiKeyService service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKey creates a new API key and returns the plain key once
+func (h *APIKeyHandler) CreateAPIKey(ctx context.Context, req *proto.CreateAPIKeyRequest) (*proto.CreateAPIKeyResponse, error) {
+	// Validate request using DTO
+	createDTO := &dto.CreateAPIKeyRequestDTO{
+		Name:               req.Name,
+		Scopes:             req.Scopes,
+		RateLimitPerMinute: req.RateLimitPerMinute,
+		ExpiresAt:          req.ExpiresAt,
+	}
+
+	expiresAt, err := createDTO.ValidateCreateAPIKeyRequest()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	apiKey, key, err := h.apiKeyService.CreateAPIKey(ctx, req.Name, req.Scopes, int(req.RateLimitPerMinute), expiresAt)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.InvalidArgument), "Failed to create API key: %v", err)
+	}
+
+	return &proto.CreateAPIKeyResponse{
+		Success: true,
+		Message: "API key created successfully, store the key now as it cannot be shown again",
+		ApiKey:  toProtoAPIKey(apiKey),
+		Key:     key,
+	}, nil
+}
+
+// ListAPIKeys retrieves API keys with pagination
+func (h *APIKeyHandler) ListAPIKeys(ctx context.Context, req *proto.ListAPIKeysRequest) (*proto.ListAPIKeysResponse, error) {
+	// Validate request using DTO
+	listDTO := &dto.ListAPIKeysRequestDTO{
+		Page:  req.Page,
+		Limit: req.Limit,
+	}
+
+	if err := listDTO.ValidateListAPIKeysRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	apiKeys, total, err := h.apiKeyService.ListAPIKeys(ctx, int(listDTO.Page), int(listDTO.Limit), req.IncludeRevoked)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to list API keys: %v", err)
+	}
+
+	var protoAPIKeys []*proto.APIKey
+	for _, apiKey := range apiKeys {
+		protoAPIKeys = append(protoAPIKeys, toProtoAPIKey(apiKey))
+	}
+
+	// Calculate pagination metadata using validated DTO values
+	paginationMeta := helpers.CalculatePaginationMetadata(int(listDTO.Page), int(listDTO.Limit), total)
+
+	return &proto.ListAPIKeysResponse{
+		Success:     true,
+		Message:     "API keys retrieved successfully",
+		ApiKeys:     protoAPIKeys,
+		Total:       int32(total),
+		CurrentPage: paginationMeta.CurrentPage,
+		TotalPages:  paginationMeta.TotalPages,
+		HasNext:     paginationMeta.HasNext,
+		HasPrevious: paginationMeta.HasPrevious,
+	}, nil
+}
+
+// RevokeAPIKey revokes an API key
+func (h *APIKeyHandler) RevokeAPIKey(ctx context.Context, req *proto.RevokeAPIKeyRequest) (*proto.RevokeAPIKeyResponse, error) {
+	// Validate request using DTO
+	revokeDTO := &dto.APIKeyIDRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := revokeDTO.ValidateAPIKeyIDRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(ctx, uint(req.Id)); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.NotFound), "Failed to revoke API key: %v", err)
+	}
+
+	return &proto.RevokeAPIKeyResponse{
+		Success: true,
+		Message: "API key revoked successfully",
+	}, nil
+}
+
+// toProtoAPIKey converts an API key entity into its protobuf representation
+func toProtoAPIKey(apiKey *entity.APIKey) *proto.APIKey {
+	protoAPIKey := &proto.APIKey{
+		Id:                 uint32(apiKey.ID),
+		Name:               apiKey.Name,
+		Prefix:             apiKey.Prefix,
+		Scopes:             apiKey.ScopeList(),
+		RateLimitPerMinute: int32(apiKey.RateLimitPerMinute),
+		CreatedById:        uint32(apiKey.CreatedByID),
+		CreatedAt:          apiKey.CreatedAt.Format(time.RFC3339),
+	}
+
+	if apiKey.ExpiresAt != nil {
+		protoAPIKey.ExpiresAt = apiKey.ExpiresAt.Format(time.RFC3339)
+	}
+	if apiKey.LastUsedAt != nil {
+		protoAPIKey.LastUsedAt = apiKey.LastUsedAt.Format(time.RFC3339)
+	}
+	if apiKey.RevokedAt != nil {
+		protoAPIKey.RevokedAt = apiKey.RevokedAt.Format(time.RFC3339)
+	}
+
+	return protoAPIKey
+}
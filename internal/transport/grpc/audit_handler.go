@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/internal/transport/dto"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuditHandler handles gRPC requests for the audit log
+type AuditHandler struct {
+	proto.UnimplementedAuditServiceServer
+	auditService service.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListAuditEvents retrieves audit events with pagination and filters
+func (h *AuditHandler) ListAuditEvents(ctx context.Context, req *proto.ListAuditEventsRequest) (*proto.ListAuditEventsResponse, error) {
+	// Validate request using DTO
+	listDTO := &dto.ListAuditEventsRequestDTO{
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		RequestID:  req.RequestId,
+		From:       req.From,
+		To:         req.To,
+	}
+
+	from, to, err := listDTO.ValidateListAuditEventsRequest()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	filter := repository.AuditFilter{
+		ActorID:    uint(req.ActorId),
+		Action:     listDTO.Action,
+		EntityType: listDTO.EntityType,
+		EntityID:   uint(req.EntityId),
+		RequestID:  listDTO.RequestID,
+		From:       from,
+		To:         to,
+	}
+
+	events, total, err := h.auditService.ListAuditEvents(ctx, filter, int(listDTO.Page), int(listDTO.Limit))
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to list audit events: %v", err)
+	}
+
+	var protoEvents []*proto.AuditEvent
+	for _, event := range events {
+		protoEvents = append(protoEvents, toProtoAuditEvent(event))
+	}
+
+	// Calculate pagination metadata using validated DTO values
+	paginationMeta := helpers.CalculatePaginationMetadata(int(listDTO.Page), int(listDTO.Limit), total)
+
+	return &proto.ListAuditEventsResponse{
+		Success:     true,
+		Message:     "Audit events retrieved successfully",
+		Events:      protoEvents,
+		Total:       int32(total),
+		CurrentPage: paginationMeta.CurrentPage,
+		TotalPages:  paginationMeta.TotalPages,
+		HasNext:     paginationMeta.HasNext,
+		HasPrevious: paginationMeta.HasPrevious,
+	}, nil
+}
+
+// toProtoAuditEvent converts an audit event entity into its protobuf representation
+func toProtoAuditEvent(event *entity.AuditEvent) *proto.AuditEvent {
+	protoEvent := &proto.AuditEvent{
+		Id:         uint32(event.ID),
+		ActorId:    uint32(event.ActorID),
+		ActorEmail: event.ActorEmail,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityId:   uint32(event.EntityID),
+		RequestId:  event.RequestID,
+		CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+	}
+
+	if event.APIKeyID != nil {
+		protoEvent.ApiKeyId = uint32(*event.APIKeyID)
+	}
+	if event.Before != nil {
+		protoEvent.Before = *event.Before
+	}
+	if event.After != nil {
+		protoEvent.After = *event.After
+	}
+
+	return protoEvent
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"github.com/nabil/book-store-system/proto"
+)
+
+// AccessPolicy returns the access level required by every RPC exposed by the server.
+// RPCs that are not listed here are rejected by the auth interceptor. Staff-only
+// RPCs are AccessUser here; the services check the required permission themselves.
+// Staff RPCs that machine clients need are AccessAPIKey so they also accept API keys.
+func AccessPolicy() middleware.AccessPolicy {
+	return middleware.AccessPolicy{
+		// User service
+		proto.UserService_Register_FullMethodName:             middleware.AccessPublic,
+		proto.UserService_Login_FullMethodName:                middleware.AccessPublic,
+		proto.UserService_RefreshToken_FullMethodName:         middleware.AccessPublic,
+		proto.UserService_VerifyMFA_FullMethodName:            middleware.AccessPublic,
+		proto.UserService_RequestPasswordReset_FullMethodName: middleware.AccessPublic,
+		proto.UserService_ConfirmPasswordReset_FullMethodName: middleware.AccessPublic,
+		proto.UserService_VerifyEmail_FullMethodName:          middleware.AccessPublic,
+		proto.UserService_BeginOIDCLogin_FullMethodName:       middleware.AccessPublic,
+		proto.UserService_CompleteOIDCLogin_FullMethodName:    middleware.AccessPublic,
+		proto.UserService_GetProfile_FullMethodName:           middleware.AccessMFAEnrollment,
+		proto.UserService_UpdateProfile_FullMethodName:        middleware.AccessUser,
+		proto.UserService_ChangePassword_FullMethodName:       middleware.AccessUser,
+		proto.UserService_Logout_FullMethodName:               middleware.AccessUser,
+		proto.UserService_LogoutAllDevices_FullMethodName:     middleware.AccessUser,
+		proto.UserService_EnrollMFA_FullMethodName:            middleware.AccessMFAEnrollment,
+		proto.UserService_ConfirmMFA_FullMethodName:           middleware.AccessMFAEnrollment,
+		proto.UserService_ExportMyData_FullMethodName:         middleware.AccessUser,
+		proto.UserService_DeleteMyAccount_FullMethodName:      middleware.AccessUser,
+		proto.UserService_ResendVerification_FullMethodName:   middleware.AccessUser,
+		proto.UserService_ListMySessions_FullMethodName:       middleware.AccessUser,
+		proto.UserService_RevokeSession_FullMethodName:        middleware.AccessUser,
+
+		// Admin user service
+		proto.AdminUserService_ListUsers_FullMethodName:       middleware.AccessUser,
+		proto.AdminUserService_GetUser_FullMethodName:         middleware.AccessUser,
+		proto.AdminUserService_SetUserRole_FullMethodName:     middleware.AccessUser,
+		proto.AdminUserService_DisableUser_FullMethodName:     middleware.AccessUser,
+		proto.AdminUserService_EnableUser_FullMethodName:      middleware.AccessUser,
+		proto.AdminUserService_DeleteUser_FullMethodName:      middleware.AccessUser,
+		proto.AdminUserService_UnlockAccount_FullMethodName:   middleware.AccessUser,
+		proto.AdminUserService_ListRoles_FullMethodName:       middleware.AccessUser,
+		proto.AdminUserService_ListPermissions_FullMethodName: middleware.AccessUser,
+		proto.AdminUserService_UpsertRole_FullMethodName:      middleware.AccessUser,
+		proto.AdminUserService_ExportUserData_FullMethodName:  middleware.AccessUser,
+		proto.AdminUserService_AnonymizeUser_FullMethodName:   middleware.AccessUser,
+		proto.AdminUserService_ImpersonateUser_FullMethodName: middleware.AccessUser,
+
+		// API key service
+		proto.APIKeyService_CreateAPIKey_FullMethodName: middleware.AccessUser,
+		proto.APIKeyService_ListAPIKeys_FullMethodName:  middleware.AccessUser,
+		proto.APIKeyService_RevokeAPIKey_FullMethodName: middleware.AccessUser,
+
+		// Category service
+		proto.CategoryService_GetCategories_FullMethodName:  middleware.AccessPublic,
+		proto.CategoryService_GetCategory_FullMethodName:    middleware.AccessPublic,
+		proto.CategoryService_CreateCategory_FullMethodName: middleware.AccessAPIKey,
+		proto.CategoryService_UpdateCategory_FullMethodName: middleware.AccessAPIKey,
+		proto.CategoryService_DeleteCategory_FullMethodName: middleware.AccessAPIKey,
+
+		// Book service
+		proto.BookService_GetBooks_FullMethodName:           middleware.AccessPublic,
+		proto.BookService_GetBook_FullMethodName:            middleware.AccessPublic,
+		proto.BookService_GetBookByISBN_FullMethodName:      middleware.AccessPublic,
+		proto.BookService_GetBooksByCategory_FullMethodName: middleware.AccessPublic,
+		proto.BookService_SuggestBooks_FullMethodName:       middleware.AccessPublic,
+		proto.BookService_CreateBook_FullMethodName:         middleware.AccessAPIKey,
+		proto.BookService_UpdateBook_FullMethodName:         middleware.AccessAPIKey,
+		proto.BookService_UploadBookCover_FullMethodName:    middleware.AccessAPIKey,
+		proto.BookService_DeleteBook_FullMethodName:         middleware.AccessAPIKey,
+
+		// Order service
+		proto.OrderService_CreateOrder_FullMethodName:       middleware.AccessUser,
+		proto.OrderService_GetOrders_FullMethodName:         middleware.AccessUser,
+		proto.OrderService_GetOrder_FullMethodName:          middleware.AccessAPIKey,
+		proto.OrderService_ProcessPayment_FullMethodName:    middleware.AccessUser,
+		proto.OrderService_UpdateOrderStatus_FullMethodName: middleware.AccessAPIKey,
+
+		// Report service
+		proto.ReportService_GetSalesReport_FullMethodName:         middleware.AccessAPIKey,
+		proto.ReportService_GetTopBooks_FullMethodName:            middleware.AccessAPIKey,
+		proto.ReportService_GetBookPriceStatistics_FullMethodName: middleware.AccessAPIKey,
+
+		// Audit service
+		proto.AuditService_ListAuditEvents_FullMethodName: middleware.AccessUser,
+
+		// Address service
+		proto.AddressService_CreateAddress_FullMethodName: middleware.AccessUser,
+		proto.AddressService_ListAddresses_FullMethodName: middleware.AccessUser,
+		proto.AddressService_GetAddress_FullMethodName:    middleware.AccessUser,
+		proto.AddressService_UpdateAddress_FullMethodName: middleware.AccessUser,
+		proto.AddressService_DeleteAddress_FullMethodName: middleware.AccessUser,
+
+		// Server reflection
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      middleware.AccessPublic,
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": middleware.AccessPublic,
+	}
+}
+
+// ImpersonationDeniedMethods returns the RPCs that only the account owner may
+// call. Tokens issued by ImpersonateUser are refused by these, so support staff
+// can look around a customer's account but not pay, change credentials or
+// close it. UpdateProfile is included because a changed email address would
+// let a password reset take the account over.
+func ImpersonationDeniedMethods() []string {
+	return []string{
+		proto.UserService_UpdateProfile_FullMethodName,
+		proto.UserService_ChangePassword_FullMethodName,
+		proto.UserService_EnrollMFA_FullMethodName,
+		proto.UserService_ConfirmMFA_FullMethodName,
+		proto.UserService_LogoutAllDevices_FullMethodName,
+		proto.UserService_RevokeSession_FullMethodName,
+		proto.UserService_DeleteMyAccount_FullMethodName,
+		proto.OrderService_ProcessPayment_FullMethodName,
+	}
+}
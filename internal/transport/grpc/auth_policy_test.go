@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nabil/book-store-system/proto"
+)
+
+func TestAccessPolicyCoversEveryRPC(t *testing.T) {
+	policy := AccessPolicy()
+
+	// Every RPC declared in bookstore.proto, so that a new RPC fails here
+	// instead of being denied at runtime
+	declared := make(map[string]bool)
+	services := proto.File_proto_bookstore_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		methods := service.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			method := fmt.Sprintf("/%s/%s", service.FullName(), methods.Get(j).Name())
+			declared[method] = true
+			if _, ok := policy[method]; !ok {
+				t.Errorf("%s has no access policy", method)
+			}
+		}
+	}
+
+	// Other entries, such as server reflection, belong to services registered
+	// from other packages
+	prefix := fmt.Sprintf("/%s.", proto.File_proto_bookstore_proto.Package())
+	for method := range policy {
+		if strings.HasPrefix(method, prefix) && !declared[method] {
+			t.Errorf("access policy lists %s, which is not an RPC", method)
+		}
+	}
+}
+
+func TestImpersonationDeniedMethods(t *testing.T) {
+	policy := AccessPolicy()
+	denied := make(map[string]bool)
+	for _, method := range ImpersonationDeniedMethods() {
+		denied[method] = true
+		if _, ok := policy[method]; !ok {
+			t.Errorf("impersonation denies %s, which is not an RPC", method)
+		}
+	}
+
+	// Payments and credential changes stay with the account owner
+	for _, method := range []string{
+		proto.OrderService_ProcessPayment_FullMethodName,
+		proto.UserService_ChangePassword_FullMethodName,
+		proto.UserService_UpdateProfile_FullMethodName,
+		proto.UserService_DeleteMyAccount_FullMethodName,
+	} {
+		if !denied[method] {
+			t.Errorf("%s can be called while impersonating", method)
+		}
+	}
+}
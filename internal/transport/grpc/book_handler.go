@@ -2,10 +2,17 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"io"
 
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/internal/service"
 	"github.com/nabil/book-store-system/internal/transport/dto"
 	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/imaging"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"github.com/nabil/book-store-system/pkg/storage"
 	"github.com/nabil/book-store-system/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,12 +22,16 @@ import (
 type BookHandler struct {
 	proto.UnimplementedBookServiceServer
 	bookService service.BookService
+	blobs       storage.BlobStore
+	coverCfg    config.CoverConfig
 }
 
 // NewBookHandler creates a new BookHandler
-func NewBookHandler(bookService service.BookService) *BookHandler {
+func NewBookHandler(bookService service.BookService, blobs storage.BlobStore, coverCfg config.CoverConfig) *BookHandler {
 	return &BookHandler{
 		bookService: bookService,
+		blobs:       blobs,
+		coverCfg:    coverCfg,
 	}
 }
 
@@ -30,8 +41,9 @@ func (h *BookHandler) CreateBook(ctx context.Context, req *proto.CreateBookReque
 	createDTO := &dto.CreateBookRequestDTO{
 		Title:       req.Title,
 		Author:      req.Author,
+		ISBN:        req.Isbn,
+		Description: req.Description,
 		ImageBase64: req.ImageBase64,
-		Token:       req.Token,
 		Price:       req.Price,
 		Stock:       req.Stock,
 		Year:        req.Year,
@@ -42,31 +54,41 @@ func (h *BookHandler) CreateBook(ctx context.Context, req *proto.CreateBookReque
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
+	cover, err := decodeCover(req.ImageBase64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
 	book, err := h.bookService.CreateBook(
+		ctx,
 		req.Title,
 		req.Author,
-		req.ImageBase64,
-		req.Token,
+		req.Isbn,
+		req.Description,
+		cover,
 		req.Price,
 		int(req.Stock),
 		int(req.Year),
 		uint(req.CategoryId),
 	)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to create book: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to create book: %v", err)
 	}
 
 	return &proto.CreateBookResponse{
 		Success: true,
 		Book: &proto.Book{
-			Id:          uint32(book.ID),
-			Title:       book.Title,
-			Author:      book.Author,
-			Price:       book.Price,
-			Stock:       int32(book.Stock),
-			Year:        int32(book.Year),
-			CategoryId:  uint32(book.CategoryID),
-			ImageBase64: book.ImageBase64,
+			Id:           uint32(book.ID),
+			Title:        book.Title,
+			Author:       book.Author,
+			Description:  book.Description,
+			Isbn:         stringValue(book.ISBN),
+			Price:        book.Price,
+			Stock:        int32(book.Stock),
+			Year:         int32(book.Year),
+			CategoryId:   uint32(book.CategoryID),
+			CoverUrl:     coverURL(h.blobs, book.CoverKey),
+			ThumbnailUrl: coverURL(h.blobs, book.ThumbnailKey),
 			Category: &proto.Category{
 				Id:   uint32(book.Category.ID),
 				Name: book.Category.Name,
@@ -80,9 +102,10 @@ func (h *BookHandler) CreateBook(ctx context.Context, req *proto.CreateBookReque
 func (h *BookHandler) GetBooks(ctx context.Context, req *proto.GetBooksRequest) (*proto.GetBooksResponse, error) {
 	// Validate request using DTO
 	getBooksDTO := &dto.GetBooksRequestDTO{
-		Page:   req.Page,
-		Limit:  req.Limit,
-		Search: req.Search,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Search:     req.Search,
+		SearchMode: req.SearchMode,
 	}
 
 	if err := getBooksDTO.ValidateGetBooksRequest(); err != nil {
@@ -90,7 +113,7 @@ func (h *BookHandler) GetBooks(ctx context.Context, req *proto.GetBooksRequest)
 	}
 
 	// Use validated DTO values instead of raw request values
-	books, total, err := h.bookService.GetBooks(int(getBooksDTO.Page), int(getBooksDTO.Limit), req.Search)
+	books, total, didYouMean, err := h.bookService.GetBooks(int(getBooksDTO.Page), int(getBooksDTO.Limit), req.Search, req.SearchMode)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to get books: %v", err)
 	}
@@ -98,14 +121,18 @@ func (h *BookHandler) GetBooks(ctx context.Context, req *proto.GetBooksRequest)
 	var protoBooks []*proto.Book
 	for _, book := range books {
 		protoBook := &proto.Book{
-			Id:          uint32(book.ID),
-			Title:       book.Title,
-			Author:      book.Author,
-			Price:       book.Price,
-			Stock:       int32(book.Stock),
-			Year:        int32(book.Year),
-			CategoryId:  uint32(book.CategoryID),
-			ImageBase64: book.ImageBase64,
+			Id:              uint32(book.ID),
+			Title:           book.Title,
+			Author:          book.Author,
+			Description:     book.Description,
+			Isbn:            stringValue(book.ISBN),
+			Price:           book.Price,
+			Stock:           int32(book.Stock),
+			Year:            int32(book.Year),
+			CategoryId:      uint32(book.CategoryID),
+			CoverUrl:        coverURL(h.blobs, book.CoverKey),
+			ThumbnailUrl:    coverURL(h.blobs, book.ThumbnailKey),
+			SearchHighlight: book.SearchHighlight,
 		}
 
 		if book.Category.ID != 0 {
@@ -130,6 +157,7 @@ func (h *BookHandler) GetBooks(ctx context.Context, req *proto.GetBooksRequest)
 		TotalPages:  paginationMeta.TotalPages,
 		HasNext:     paginationMeta.HasNext,
 		HasPrevious: paginationMeta.HasPrevious,
+		DidYouMean:  didYouMean,
 	}, nil
 }
 
@@ -150,14 +178,17 @@ func (h *BookHandler) GetBook(ctx context.Context, req *proto.GetBookRequest) (*
 	}
 
 	protoBook := &proto.Book{
-		Id:          uint32(book.ID),
-		Title:       book.Title,
-		Author:      book.Author,
-		Price:       book.Price,
-		Stock:       int32(book.Stock),
-		Year:        int32(book.Year),
-		CategoryId:  uint32(book.CategoryID),
-		ImageBase64: book.ImageBase64,
+		Id:           uint32(book.ID),
+		Title:        book.Title,
+		Author:       book.Author,
+		Description:  book.Description,
+		Isbn:         stringValue(book.ISBN),
+		Price:        book.Price,
+		Stock:        int32(book.Stock),
+		Year:         int32(book.Year),
+		CategoryId:   uint32(book.CategoryID),
+		CoverUrl:     coverURL(h.blobs, book.CoverKey),
+		ThumbnailUrl: coverURL(h.blobs, book.ThumbnailKey),
 	}
 
 	if book.Category.ID != 0 {
@@ -174,6 +205,50 @@ func (h *BookHandler) GetBook(ctx context.Context, req *proto.GetBookRequest) (*
 	}, nil
 }
 
+// GetBookByISBN retrieves a book by ISBN-10 or ISBN-13
+func (h *BookHandler) GetBookByISBN(ctx context.Context, req *proto.GetBookByISBNRequest) (*proto.GetBookByISBNResponse, error) {
+	// Validate request using DTO
+	getBookDTO := &dto.GetBookByISBNRequestDTO{
+		ISBN: req.Isbn,
+	}
+
+	if err := getBookDTO.ValidateGetBookByISBNRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	book, err := h.bookService.GetBookByISBN(req.Isbn)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.NotFound), "Book not found: %v", err)
+	}
+
+	protoBook := &proto.Book{
+		Id:           uint32(book.ID),
+		Title:        book.Title,
+		Author:       book.Author,
+		Description:  book.Description,
+		Isbn:         stringValue(book.ISBN),
+		Price:        book.Price,
+		Stock:        int32(book.Stock),
+		Year:         int32(book.Year),
+		CategoryId:   uint32(book.CategoryID),
+		CoverUrl:     coverURL(h.blobs, book.CoverKey),
+		ThumbnailUrl: coverURL(h.blobs, book.ThumbnailKey),
+	}
+
+	if book.Category.ID != 0 {
+		protoBook.Category = &proto.Category{
+			Id:   uint32(book.Category.ID),
+			Name: book.Category.Name,
+		}
+	}
+
+	return &proto.GetBookByISBNResponse{
+		Success: true,
+		Message: "Book retrieved successfully",
+		Book:    protoBook,
+	}, nil
+}
+
 // UpdateBook updates an existing book
 func (h *BookHandler) UpdateBook(ctx context.Context, req *proto.UpdateBookRequest) (*proto.UpdateBookResponse, error) {
 	// Validate request using DTO
@@ -181,7 +256,8 @@ func (h *BookHandler) UpdateBook(ctx context.Context, req *proto.UpdateBookReque
 		ID:          req.Id,
 		Title:       req.Title,
 		Author:      req.Author,
-		Token:       req.Token,
+		ISBN:        req.Isbn,
+		Description: req.Description,
 		Price:       req.Price,
 		Stock:       req.Stock,
 		ImageBase64: req.ImageBase64,
@@ -193,32 +269,42 @@ func (h *BookHandler) UpdateBook(ctx context.Context, req *proto.UpdateBookReque
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
+	cover, err := decodeCover(req.ImageBase64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
 	book, err := h.bookService.UpdateBook(
+		ctx,
 		uint(req.Id),
 		req.Title,
 		req.Author,
-		req.ImageBase64,
-		req.Token,
+		req.Isbn,
+		req.Description,
+		cover,
 		req.Price,
 		int(req.Stock),
 		int(req.Year),
 		uint(req.CategoryId),
 	)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update book: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to update book: %v", err)
 	}
 
 	return &proto.UpdateBookResponse{
 		Success: true,
 		Book: &proto.Book{
-			Id:          uint32(book.ID),
-			Title:       book.Title,
-			Author:      book.Author,
-			Price:       book.Price,
-			Stock:       int32(book.Stock),
-			Year:        int32(book.Year),
-			CategoryId:  uint32(book.CategoryID),
-			ImageBase64: book.ImageBase64,
+			Id:           uint32(book.ID),
+			Title:        book.Title,
+			Author:       book.Author,
+			Description:  book.Description,
+			Isbn:         stringValue(book.ISBN),
+			Price:        book.Price,
+			Stock:        int32(book.Stock),
+			Year:         int32(book.Year),
+			CategoryId:   uint32(book.CategoryID),
+			CoverUrl:     coverURL(h.blobs, book.CoverKey),
+			ThumbnailUrl: coverURL(h.blobs, book.ThumbnailKey),
 			Category: &proto.Category{
 				Id:   uint32(book.Category.ID),
 				Name: book.Category.Name,
@@ -228,21 +314,101 @@ func (h *BookHandler) UpdateBook(ctx context.Context, req *proto.UpdateBookReque
 	}, nil
 }
 
+// UploadBookCover receives a cover image streamed in chunks after the book ID
+// and makes it the cover of the book
+func (h *BookHandler) UploadBookCover(stream proto.BookService_UploadBookCoverServer) error {
+	// Check the permission before reading the stream, so callers who may not
+	// change covers cannot make the server buffer up to COVER_MAX_BYTES
+	if err := middleware.RequirePermission(stream.Context(), entity.PermissionBooksWrite); err != nil {
+		return status.Errorf(errorCode(err, codes.PermissionDenied), "Failed to upload book cover: %v", err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Failed to receive book ID: %v", err)
+	}
+
+	// Validate request using DTO
+	uploadDTO := &dto.UploadBookCoverRequestDTO{
+		BookID: first.GetBookId(),
+	}
+
+	if err := uploadDTO.ValidateUploadBookCoverRequest(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	var data []byte
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		chunk, ok := req.Data.(*proto.UploadBookCoverRequest_Chunk)
+		if !ok {
+			return status.Error(codes.InvalidArgument, "Validation failed: only the first message may carry the book ID")
+		}
+		// Stop reading oversized covers early instead of buffering them
+		if h.coverCfg.MaxBytes > 0 && len(data)+len(chunk.Chunk) > h.coverCfg.MaxBytes {
+			return status.Errorf(codes.InvalidArgument, "Validation failed: cover exceeds the maximum of %d bytes", h.coverCfg.MaxBytes)
+		}
+		data = append(data, chunk.Chunk...)
+	}
+
+	if len(data) == 0 {
+		return status.Error(codes.InvalidArgument, "Validation failed: cover image is empty")
+	}
+
+	book, err := h.bookService.UploadBookCover(stream.Context(), uint(uploadDTO.BookID), data)
+	if err != nil {
+		return status.Errorf(errorCode(err, codes.Internal), "Failed to upload book cover: %v", err)
+	}
+
+	protoBook := &proto.Book{
+		Id:           uint32(book.ID),
+		Title:        book.Title,
+		Author:       book.Author,
+		Description:  book.Description,
+		Isbn:         stringValue(book.ISBN),
+		Price:        book.Price,
+		Stock:        int32(book.Stock),
+		Year:         int32(book.Year),
+		CategoryId:   uint32(book.CategoryID),
+		CoverUrl:     coverURL(h.blobs, book.CoverKey),
+		ThumbnailUrl: coverURL(h.blobs, book.ThumbnailKey),
+	}
+
+	if book.Category.ID != 0 {
+		protoBook.Category = &proto.Category{
+			Id:   uint32(book.Category.ID),
+			Name: book.Category.Name,
+		}
+	}
+
+	return stream.SendAndClose(&proto.UploadBookCoverResponse{
+		Success: true,
+		Message: "Book cover uploaded successfully",
+		Book:    protoBook,
+	})
+}
+
 // DeleteBook deletes a book
 func (h *BookHandler) DeleteBook(ctx context.Context, req *proto.DeleteBookRequest) (*proto.DeleteBookResponse, error) {
 	// Validate request using DTO
 	deleteDTO := &dto.DeleteBookRequestDTO{
-		ID:    req.Id,
-		Token: req.Token,
+		ID: req.Id,
 	}
 
 	if err := deleteDTO.ValidateDeleteBookRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
-	err := h.bookService.DeleteBook(uint(req.Id), req.Token)
+	err := h.bookService.DeleteBook(ctx, uint(req.Id))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to delete book: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to delete book: %v", err)
 	}
 
 	return &proto.DeleteBookResponse{
@@ -273,14 +439,17 @@ func (h *BookHandler) GetBooksByCategory(ctx context.Context, req *proto.GetBook
 	var protoBooks []*proto.Book
 	for _, book := range books {
 		protoBook := &proto.Book{
-			Id:          uint32(book.ID),
-			Title:       book.Title,
-			Author:      book.Author,
-			Price:       book.Price,
-			Stock:       int32(book.Stock),
-			Year:        int32(book.Year),
-			CategoryId:  uint32(book.CategoryID),
-			ImageBase64: book.ImageBase64,
+			Id:           uint32(book.ID),
+			Title:        book.Title,
+			Author:       book.Author,
+			Description:  book.Description,
+			Isbn:         stringValue(book.ISBN),
+			Price:        book.Price,
+			Stock:        int32(book.Stock),
+			Year:         int32(book.Year),
+			CategoryId:   uint32(book.CategoryID),
+			CoverUrl:     coverURL(h.blobs, book.CoverKey),
+			ThumbnailUrl: coverURL(h.blobs, book.ThumbnailKey),
 		}
 
 		if book.Category.ID != 0 {
@@ -307,3 +476,66 @@ func (h *BookHandler) GetBooksByCategory(ctx context.Context, req *proto.GetBook
 		HasPrevious: paginationMeta.HasPrevious,
 	}, nil
 }
+
+// SuggestBooks returns title and author completions of a prefix for autocomplete
+func (h *BookHandler) SuggestBooks(ctx context.Context, req *proto.SuggestBooksRequest) (*proto.SuggestBooksResponse, error) {
+	// Validate request using DTO
+	suggestBooksDTO := &dto.SuggestBooksRequestDTO{
+		Prefix: req.Prefix,
+		Limit:  req.Limit,
+	}
+
+	if err := suggestBooksDTO.ValidateSuggestBooksRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	suggestions, didYouMean, err := h.bookService.SuggestBooks(suggestBooksDTO.Prefix, int(suggestBooksDTO.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get book suggestions: %v", err)
+	}
+
+	var protoSuggestions []*proto.BookSuggestion
+	for _, suggestion := range suggestions {
+		protoSuggestions = append(protoSuggestions, &proto.BookSuggestion{
+			Text:   suggestion.Text,
+			Field:  suggestion.Field,
+			BookId: uint32(suggestion.BookID),
+		})
+	}
+
+	return &proto.SuggestBooksResponse{
+		Success:     true,
+		Message:     "Book suggestions retrieved successfully",
+		Suggestions: protoSuggestions,
+		DidYouMean:  didYouMean,
+	}, nil
+}
+
+// stringValue returns the value of an optional string, or "" if it is not set
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// coverURL returns the public URL of a cover blob, or "" if the book has none
+func coverURL(blobs storage.BlobStore, key string) string {
+	if key == "" {
+		return ""
+	}
+	return blobs.URL(key)
+}
+
+// decodeCover decodes a cover sent through the deprecated image_base64 field.
+// Its type, size and dimensions are checked by the book service.
+func decodeCover(imageBase64 string) ([]byte, error) {
+	if imageBase64 == "" {
+		return nil, nil
+	}
+	cover, err := imaging.DecodeBase64(imageBase64)
+	if err != nil {
+		return nil, errors.New("image_base64 is not valid base64")
+	}
+	return cover, nil
+}
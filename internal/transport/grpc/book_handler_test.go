@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"github.com/nabil/book-store-system/proto"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// uploadStream is a client stream of cover chunks that counts the messages
+// the handler receives
+type uploadStream struct {
+	grpclib.ServerStream
+	ctx      context.Context
+	messages []*proto.UploadBookCoverRequest
+	received int
+}
+
+func (s *uploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadStream) Recv() (*proto.UploadBookCoverRequest, error) {
+	if s.received == len(s.messages) {
+		return nil, io.EOF
+	}
+	s.received++
+	return s.messages[s.received-1], nil
+}
+
+func (s *uploadStream) SendAndClose(*proto.UploadBookCoverResponse) error {
+	return nil
+}
+
+func TestUploadBookCoverChecksPermissionBeforeReading(t *testing.T) {
+	handler := NewBookHandler(nil, nil, config.CoverConfig{MaxBytes: 1024})
+
+	tests := []struct {
+		name        string
+		permissions []string
+		messages    []*proto.UploadBookCoverRequest
+		code        codes.Code
+		received    int
+	}{
+		{
+			name:        "without books:write",
+			permissions: []string{entity.PermissionOrdersRead},
+			messages: []*proto.UploadBookCoverRequest{
+				{Data: &proto.UploadBookCoverRequest_BookId{BookId: 1}},
+				{Data: &proto.UploadBookCoverRequest_Chunk{Chunk: make([]byte, 512)}},
+			},
+			code:     codes.PermissionDenied,
+			received: 0,
+		},
+		{
+			// Reads the stream and stops at the empty cover check
+			name:        "with books:write",
+			permissions: []string{entity.PermissionBooksWrite},
+			messages: []*proto.UploadBookCoverRequest{
+				{Data: &proto.UploadBookCoverRequest_BookId{BookId: 1}},
+			},
+			code:     codes.InvalidArgument,
+			received: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := middleware.ContextWithUser(context.Background(), &entity.User{ID: 1}, nil, tt.permissions)
+			stream := &uploadStream{ctx: ctx, messages: tt.messages}
+
+			err := handler.UploadBookCover(stream)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("UploadBookCover() = %v, want code %v", err, tt.code)
+			}
+			if stream.received != tt.received {
+				t.Errorf("handler received %d messages, want %d", stream.received, tt.received)
+			}
+		})
+	}
+}
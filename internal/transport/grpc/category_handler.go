@@ -28,17 +28,16 @@ func NewCategoryHandler(categoryService service.CategoryService) *CategoryHandle
 func (h *CategoryHandler) CreateCategory(ctx context.Context, req *proto.CreateCategoryRequest) (*proto.CreateCategoryResponse, error) {
 	// Validate request using DTO
 	createDTO := &dto.CreateCategoryRequestDTO{
-		Name:  req.Name,
-		Token: req.Token,
+		Name: req.Name,
 	}
 
 	if err := createDTO.ValidateCreateCategoryRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
-	category, err := h.categoryService.CreateCategory(req.Name, req.Token)
+	category, err := h.categoryService.CreateCategory(ctx, req.Name)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to create category: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to create category: %v", err)
 	}
 
 	return &proto.CreateCategoryResponse{
@@ -122,18 +121,17 @@ func (h *CategoryHandler) GetCategory(ctx context.Context, req *proto.GetCategor
 func (h *CategoryHandler) UpdateCategory(ctx context.Context, req *proto.UpdateCategoryRequest) (*proto.UpdateCategoryResponse, error) {
 	// Validate request using DTO
 	updateDTO := &dto.UpdateCategoryRequestDTO{
-		ID:    req.Id,
-		Name:  req.Name,
-		Token: req.Token,
+		ID:   req.Id,
+		Name: req.Name,
 	}
 
 	if err := updateDTO.ValidateUpdateCategoryRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
-	category, err := h.categoryService.UpdateCategory(uint(req.Id), req.Name, req.Token)
+	category, err := h.categoryService.UpdateCategory(ctx, uint(req.Id), req.Name)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update category: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to update category: %v", err)
 	}
 
 	return &proto.UpdateCategoryResponse{
@@ -150,17 +148,16 @@ func (h *CategoryHandler) UpdateCategory(ctx context.Context, req *proto.UpdateC
 func (h *CategoryHandler) DeleteCategory(ctx context.Context, req *proto.DeleteCategoryRequest) (*proto.DeleteCategoryResponse, error) {
 	// Validate request using DTO
 	deleteDTO := &dto.DeleteCategoryRequestDTO{
-		ID:    req.Id,
-		Token: req.Token,
+		ID: req.Id,
 	}
 
 	if err := deleteDTO.ValidateDeleteCategoryRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
-	err := h.categoryService.DeleteCategory(uint(req.Id), req.Token)
+	err := h.categoryService.DeleteCategory(ctx, uint(req.Id))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to delete category: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to delete category: %v", err)
 	}
 
 	return &proto.DeleteCategoryResponse{
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"errors"
+
+	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"google.golang.org/grpc/codes"
+)
+
+// errorCode picks the gRPC status code for a service error, keeping
+// permission, password policy, verification, taken email, missing address or session,
+// unknown identity provider, ISBN, cover image and re-authentication failures
+// distinguishable from other errors
+func errorCode(err error, fallback codes.Code) codes.Code {
+	switch {
+	case errors.Is(err, middleware.ErrPermissionDenied):
+		return codes.PermissionDenied
+	case errors.Is(err, helpers.ErrPasswordPolicy):
+		return codes.InvalidArgument
+	case errors.Is(err, service.ErrEmailNotVerified):
+		return codes.FailedPrecondition
+	case errors.Is(err, service.ErrEmailTaken):
+		return codes.AlreadyExists
+	case errors.Is(err, service.ErrAddressNotFound):
+		return codes.NotFound
+	case errors.Is(err, service.ErrUnknownOIDCProvider):
+		return codes.InvalidArgument
+	case errors.Is(err, service.ErrSessionNotFound):
+		return codes.NotFound
+	case errors.Is(err, helpers.ErrInvalidISBN):
+		return codes.InvalidArgument
+	case errors.Is(err, service.ErrDuplicateISBN):
+		return codes.AlreadyExists
+	case errors.Is(err, service.ErrInvalidCover):
+		return codes.InvalidArgument
+	case errors.Is(err, service.ErrReauthenticationRequired):
+		return codes.Unauthenticated
+	}
+	return fallback
+}
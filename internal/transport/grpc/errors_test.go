@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/pkg/middleware"
+	"google.golang.org/grpc/codes"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"email taken", service.ErrEmailTaken, codes.AlreadyExists},
+		{"wrapped email taken", fmt.Errorf("update profile: %w", service.ErrEmailTaken), codes.AlreadyExists},
+		{"permission denied", fmt.Errorf("%w: users:write", middleware.ErrPermissionDenied), codes.PermissionDenied},
+		{"email not verified", service.ErrEmailNotVerified, codes.FailedPrecondition},
+		{"other error", errors.New("database is down"), codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := errorCode(tt.err, codes.Internal); got != tt.want {
+			t.Errorf("%s: errorCode() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/nabil/book-store-system/proto"
+)
+
+// exportChunkSize is the maximum payload of one DataExportChunk
+const exportChunkSize = 32 * 1024
+
+// chunkSender is implemented by every server stream of DataExportChunk messages
+type chunkSender interface {
+	Send(*proto.DataExportChunk) error
+}
+
+// chunkWriter sends everything written to it as DataExportChunk messages
+type chunkWriter struct {
+	stream chunkSender
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := len(p)
+		if n > exportChunkSize {
+			n = exportChunkSize
+		}
+		// The stream may hold on to the message, so the data is copied
+		data := make([]byte, n)
+		copy(data, p[:n])
+		if err := w.stream.Send(&proto.DataExportChunk{Data: data}); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// streamExport runs export with a writer that batches its output into chunks
+// of exportChunkSize and sends them on stream
+func streamExport(stream chunkSender, export func(w io.Writer) error) error {
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, exportChunkSize)
+	if err := export(w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -3,9 +3,10 @@ package grpc
 import (
 	"context"
 
-	"github.com/nabil/book-store-system/internal/transport/dto"
 	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/internal/transport/dto"
 	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/storage"
 	"github.com/nabil/book-store-system/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,12 +16,14 @@ import (
 type OrderHandler struct {
 	proto.UnimplementedOrderServiceServer
 	orderService service.OrderService
+	blobs        storage.BlobStore
 }
 
 // NewOrderHandler creates a new OrderHandler
-func NewOrderHandler(orderService service.OrderService) *OrderHandler {
+func NewOrderHandler(orderService service.OrderService, blobs storage.BlobStore) *OrderHandler {
 	return &OrderHandler{
 		orderService: orderService,
+		blobs:        blobs,
 	}
 }
 
@@ -34,12 +37,12 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *proto.CreateOrderRe
 			Quantity: item.Quantity,
 		})
 	}
-	
+
 	createOrderDTO := &dto.CreateOrderRequestDTO{
-		Items: dtoItems,
-		Token: req.Token,
+		Items:     dtoItems,
+		AddressID: req.AddressId,
 	}
-	
+
 	if err := createOrderDTO.ValidateCreateOrderRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
@@ -52,12 +55,12 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *proto.CreateOrderRe
 			Quantity: int(item.Quantity),
 		})
 	}
-	
-	order, err := h.orderService.CreateOrder(items, req.Token)
+
+	order, err := h.orderService.CreateOrder(ctx, items, uint(createOrderDTO.AddressID))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to create order: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to create order: %v", err)
 	}
-	
+
 	// Convert order items to proto
 	var protoItems []*proto.OrderItem
 	for _, item := range order.OrderItems {
@@ -67,32 +70,34 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *proto.CreateOrderRe
 			Quantity: int32(item.Quantity),
 			Price:    item.Price,
 		}
-		
+
 		if item.Book.ID != 0 {
 			protoItem.Book = &proto.Book{
-				Id:          uint32(item.Book.ID),
-				Title:       item.Book.Title,
-				Author:      item.Book.Author,
-				Price:       item.Book.Price,
-				Stock:       int32(item.Book.Stock),
-				Year:        int32(item.Book.Year),
-				CategoryId:  uint32(item.Book.CategoryID),
-				ImageBase64: item.Book.ImageBase64,
+				Id:           uint32(item.Book.ID),
+				Title:        item.Book.Title,
+				Author:       item.Book.Author,
+				Price:        item.Book.Price,
+				Stock:        int32(item.Book.Stock),
+				Year:         int32(item.Book.Year),
+				CategoryId:   uint32(item.Book.CategoryID),
+				CoverUrl:     coverURL(h.blobs, item.Book.CoverKey),
+				ThumbnailUrl: coverURL(h.blobs, item.Book.ThumbnailKey),
 			}
 		}
-		
+
 		protoItems = append(protoItems, protoItem)
 	}
-	
+
 	return &proto.CreateOrderResponse{
 		Success: true,
 		Message: "Order created successfully",
 		Order: &proto.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			TotalPrice: order.TotalPrice,
-			Status:     order.Status,
-			Items:      protoItems,
+			Id:              uint32(order.ID),
+			UserId:          uint32(order.UserID),
+			TotalPrice:      order.TotalPrice,
+			Status:          order.Status,
+			Items:           protoItems,
+			ShippingAddress: toProtoShippingAddress(order.ShippingAddress),
 		},
 	}, nil
 }
@@ -101,17 +106,16 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *proto.CreateOrderRe
 func (h *OrderHandler) GetOrders(ctx context.Context, req *proto.GetOrdersRequest) (*proto.GetOrdersResponse, error) {
 	// Validate request using DTO
 	getOrdersDTO := &dto.GetOrdersRequestDTO{
-		Token: req.Token,
 		Page:  req.Page,
 		Limit: req.Limit,
 	}
-	
+
 	if err := getOrdersDTO.ValidateGetOrdersRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
-	
+
 	// Use validated DTO values instead of raw request values
-	orders, total, err := h.orderService.GetOrders(req.Token, int(getOrdersDTO.Page), int(getOrdersDTO.Limit))
+	orders, total, err := h.orderService.GetOrders(ctx, int(getOrdersDTO.Page), int(getOrdersDTO.Limit))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to get orders: %v", err)
 	}
@@ -127,46 +131,48 @@ func (h *OrderHandler) GetOrders(ctx context.Context, req *proto.GetOrdersReques
 				Quantity: int32(item.Quantity),
 				Price:    item.Price,
 			}
-			
+
 			if item.Book.ID != 0 {
 				protoItem.Book = &proto.Book{
-					Id:          uint32(item.Book.ID),
-					Title:       item.Book.Title,
-					Author:      item.Book.Author,
-					Price:       item.Book.Price,
-					Stock:       int32(item.Book.Stock),
-					Year:        int32(item.Book.Year),
-					CategoryId:  uint32(item.Book.CategoryID),
-					ImageBase64: item.Book.ImageBase64,
+					Id:           uint32(item.Book.ID),
+					Title:        item.Book.Title,
+					Author:       item.Book.Author,
+					Price:        item.Book.Price,
+					Stock:        int32(item.Book.Stock),
+					Year:         int32(item.Book.Year),
+					CategoryId:   uint32(item.Book.CategoryID),
+					CoverUrl:     coverURL(h.blobs, item.Book.CoverKey),
+					ThumbnailUrl: coverURL(h.blobs, item.Book.ThumbnailKey),
 				}
 			}
-			
+
 			protoItems = append(protoItems, protoItem)
 		}
-		
+
 		protoOrder := &proto.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			TotalPrice: order.TotalPrice,
-			Status:     order.Status,
-			Items:      protoItems,
+			Id:              uint32(order.ID),
+			UserId:          uint32(order.UserID),
+			TotalPrice:      order.TotalPrice,
+			Status:          order.Status,
+			Items:           protoItems,
+			ShippingAddress: toProtoShippingAddress(order.ShippingAddress),
 		}
-		
+
 		protoOrders = append(protoOrders, protoOrder)
 	}
-	
+
 	// Calculate pagination metadata using validated DTO values
 	paginationMeta := helpers.CalculatePaginationMetadata(int(getOrdersDTO.Page), int(getOrdersDTO.Limit), total)
 
 	return &proto.GetOrdersResponse{
-		Success:      true,
-		Message:      "Orders retrieved successfully",
-		Orders:       protoOrders,
-		Total:        int32(total),
-		CurrentPage:  paginationMeta.CurrentPage,
-		TotalPages:   paginationMeta.TotalPages,
-		HasNext:      paginationMeta.HasNext,
-		HasPrevious:  paginationMeta.HasPrevious,
+		Success:     true,
+		Message:     "Orders retrieved successfully",
+		Orders:      protoOrders,
+		Total:       int32(total),
+		CurrentPage: paginationMeta.CurrentPage,
+		TotalPages:  paginationMeta.TotalPages,
+		HasNext:     paginationMeta.HasNext,
+		HasPrevious: paginationMeta.HasPrevious,
 	}, nil
 }
 
@@ -174,19 +180,18 @@ func (h *OrderHandler) GetOrders(ctx context.Context, req *proto.GetOrdersReques
 func (h *OrderHandler) GetOrder(ctx context.Context, req *proto.GetOrderRequest) (*proto.GetOrderResponse, error) {
 	// Validate request using DTO
 	getOrderDTO := &dto.GetOrderRequestDTO{
-		ID:    req.Id,
-		Token: req.Token,
+		ID: req.Id,
 	}
-	
+
 	if err := getOrderDTO.ValidateGetOrderRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
-	
-	order, err := h.orderService.GetOrder(uint(req.Id), req.Token)
+
+	order, err := h.orderService.GetOrder(ctx, uint(req.Id))
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Order not found: %v", err)
 	}
-	
+
 	// Convert order items to proto
 	var protoItems []*proto.OrderItem
 	for _, item := range order.OrderItems {
@@ -196,32 +201,34 @@ func (h *OrderHandler) GetOrder(ctx context.Context, req *proto.GetOrderRequest)
 			Quantity: int32(item.Quantity),
 			Price:    item.Price,
 		}
-		
+
 		if item.Book.ID != 0 {
 			protoItem.Book = &proto.Book{
-				Id:          uint32(item.Book.ID),
-				Title:       item.Book.Title,
-				Author:      item.Book.Author,
-				Price:       item.Book.Price,
-				Stock:       int32(item.Book.Stock),
-				Year:        int32(item.Book.Year),
-				CategoryId:  uint32(item.Book.CategoryID),
-				ImageBase64: item.Book.ImageBase64,
+				Id:           uint32(item.Book.ID),
+				Title:        item.Book.Title,
+				Author:       item.Book.Author,
+				Price:        item.Book.Price,
+				Stock:        int32(item.Book.Stock),
+				Year:         int32(item.Book.Year),
+				CategoryId:   uint32(item.Book.CategoryID),
+				CoverUrl:     coverURL(h.blobs, item.Book.CoverKey),
+				ThumbnailUrl: coverURL(h.blobs, item.Book.ThumbnailKey),
 			}
 		}
-		
+
 		protoItems = append(protoItems, protoItem)
 	}
-	
+
 	return &proto.GetOrderResponse{
 		Success: true,
 		Message: "Order retrieved successfully",
 		Order: &proto.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			TotalPrice: order.TotalPrice,
-			Status:     order.Status,
-			Items:       protoItems,
+			Id:              uint32(order.ID),
+			UserId:          uint32(order.UserID),
+			TotalPrice:      order.TotalPrice,
+			Status:          order.Status,
+			Items:           protoItems,
+			ShippingAddress: toProtoShippingAddress(order.ShippingAddress),
 		},
 	}, nil
 }
@@ -232,26 +239,26 @@ func (h *OrderHandler) UpdateOrderStatus(ctx context.Context, req *proto.UpdateO
 	updateStatusDTO := &dto.UpdateOrderStatusRequestDTO{
 		ID:     req.Id,
 		Status: req.Status,
-		Token:  req.Token,
 	}
-	
+
 	if err := updateStatusDTO.ValidateUpdateOrderStatusRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
-	
-	order, err := h.orderService.UpdateOrderStatus(uint(req.Id), req.Status, req.Token)
+
+	order, err := h.orderService.UpdateOrderStatus(ctx, uint(req.Id), req.Status)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update order status: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to update order status: %v", err)
 	}
-	
+
 	return &proto.UpdateOrderStatusResponse{
 		Success: true,
 		Message: "Order status updated successfully",
 		Order: &proto.Order{
-			Id:         uint32(order.ID),
-			UserId:     uint32(order.UserID),
-			TotalPrice: order.TotalPrice,
-			Status:     order.Status,
+			Id:              uint32(order.ID),
+			UserId:          uint32(order.UserID),
+			TotalPrice:      order.TotalPrice,
+			Status:          order.Status,
+			ShippingAddress: toProtoShippingAddress(order.ShippingAddress),
 		},
 	}, nil
 }
@@ -261,21 +268,20 @@ func (h *OrderHandler) ProcessPayment(ctx context.Context, req *proto.ProcessPay
 	// Validate request using DTO
 	processPaymentDTO := &dto.ProcessPaymentRequestDTO{
 		OrderID: req.OrderId,
-		Token:   req.Token,
 	}
-	
+
 	if err := processPaymentDTO.ValidateProcessPaymentRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
-	
-	paymentURL, err := h.orderService.ProcessPayment(uint(req.OrderId), req.Token)
+
+	paymentURL, err := h.orderService.ProcessPayment(ctx, uint(req.OrderId))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to process payment: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to process payment: %v", err)
 	}
-	
+
 	return &proto.ProcessPaymentResponse{
 		Success:    true,
 		PaymentUrl: paymentURL,
 		Message:    "Payment URL generated successfully",
 	}, nil
-}
\ No newline at end of file
+}
@@ -2,8 +2,9 @@ package grpc
 
 import (
 	"context"
-	"github.com/nabil/book-store-system/internal/transport/dto"
 	"github.com/nabil/book-store-system/internal/service"
+	"github.com/nabil/book-store-system/internal/transport/dto"
+	"github.com/nabil/book-store-system/pkg/storage"
 	"github.com/nabil/book-store-system/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,12 +16,14 @@ import (
 type ReportHandler struct {
 	proto.UnimplementedReportServiceServer
 	reportService service.ReportService
+	blobs         storage.BlobStore
 }
 
 // NewReportHandler creates a new ReportHandler
-func NewReportHandler(reportService service.ReportService) *ReportHandler {
+func NewReportHandler(reportService service.ReportService, blobs storage.BlobStore) *ReportHandler {
 	return &ReportHandler{
 		reportService: reportService,
+		blobs:         blobs,
 	}
 }
 
@@ -30,30 +33,29 @@ func (h *ReportHandler) GetSalesReport(ctx context.Context, req *proto.GetSalesR
 	salesReportDTO := &dto.GetSalesReportRequestDTO{
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
-		Token:     req.Token,
 	}
-	
+
 	if err := salesReportDTO.ValidateGetSalesReportRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
-	
+
 	// Parse dates
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid start date format: %v", err)
 	}
-	
+
 	endDate, err := time.Parse("2006-01-02", req.EndDate)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid end date format: %v", err)
 	}
-	
+
 	// Get sales report
-	reportItems, totalSales, err := h.reportService.GetSalesReport(startDate, endDate, req.Token)
+	reportItems, totalSales, err := h.reportService.GetSalesReport(ctx, startDate, endDate)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to get sales report: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to get sales report: %v", err)
 	}
-	
+
 	// Convert to proto format
 	var protoItems []*proto.SalesReportItem
 	for _, item := range reportItems {
@@ -63,7 +65,7 @@ func (h *ReportHandler) GetSalesReport(ctx context.Context, req *proto.GetSalesR
 			TotalOrders: int32(item.TotalOrders),
 		})
 	}
-	
+
 	return &proto.GetSalesReportResponse{
 		Success:      true,
 		Message:      "Sales report retrieved successfully",
@@ -77,45 +79,45 @@ func (h *ReportHandler) GetTopBooks(ctx context.Context, req *proto.GetTopBooksR
 	// Validate request using DTO
 	topBooksDTO := &dto.GetTopBooksRequestDTO{
 		Limit: req.Limit,
-		Token: req.Token,
 	}
-	
+
 	if err := topBooksDTO.ValidateGetTopBooksRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
-	
-	topBooks, err := h.reportService.GetTopBooks(int(req.Limit), req.Token)
+
+	topBooks, err := h.reportService.GetTopBooks(ctx, int(req.Limit))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to get top books: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to get top books: %v", err)
 	}
-	
+
 	// Convert to proto format
 	var protoItems []*proto.TopBookItem
 	for _, item := range topBooks {
 		protoBook := &proto.Book{
-			Id:          uint32(item.Book.ID),
-			Title:       item.Book.Title,
-			Author:      item.Book.Author,
-			Price:       item.Book.Price,
-			Stock:       int32(item.Book.Stock),
-			Year:        int32(item.Book.Year),
-			CategoryId:  uint32(item.Book.CategoryID),
-			ImageBase64: item.Book.ImageBase64,
+			Id:           uint32(item.Book.ID),
+			Title:        item.Book.Title,
+			Author:       item.Book.Author,
+			Price:        item.Book.Price,
+			Stock:        int32(item.Book.Stock),
+			Year:         int32(item.Book.Year),
+			CategoryId:   uint32(item.Book.CategoryID),
+			CoverUrl:     coverURL(h.blobs, item.Book.CoverKey),
+			ThumbnailUrl: coverURL(h.blobs, item.Book.ThumbnailKey),
 		}
-		
+
 		if item.Book.Category.ID != 0 {
 			protoBook.Category = &proto.Category{
 				Id:   uint32(item.Book.Category.ID),
 				Name: item.Book.Category.Name,
 			}
 		}
-		
+
 		protoItems = append(protoItems, &proto.TopBookItem{
 			Book:      protoBook,
 			TotalSold: int32(item.TotalSold),
 		})
 	}
-	
+
 	return &proto.GetTopBooksResponse{
 		Success: true,
 		Message: "Top books retrieved successfully",
@@ -125,21 +127,12 @@ func (h *ReportHandler) GetTopBooks(ctx context.Context, req *proto.GetTopBooksR
 
 // GetBookPriceStatistics retrieves book price statistics (admin only)
 func (h *ReportHandler) GetBookPriceStatistics(ctx context.Context, req *proto.GetBookPriceStatisticsRequest) (*proto.GetBookPriceStatisticsResponse, error) {
-	// Validate request using DTO
-	priceStatsDTO := &dto.GetBookPriceStatisticsRequestDTO{
-		Token: req.Token,
-	}
-	
-	if err := priceStatsDTO.ValidateGetBookPriceStatisticsRequest(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
-	}
-	
 	// Get book price statistics
-	stats, err := h.reportService.GetBookPriceStatistics(req.Token)
+	stats, err := h.reportService.GetBookPriceStatistics(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to get book price statistics: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to get book price statistics: %v", err)
 	}
-	
+
 	return &proto.GetBookPriceStatisticsResponse{
 		Success:    true,
 		Message:    "Book price statistics retrieved successfully",
@@ -148,4 +141,4 @@ func (h *ReportHandler) GetBookPriceStatistics(ctx context.Context, req *proto.G
 		AvgPrice:   stats.AvgPrice,
 		TotalBooks: int32(stats.TotalBooks),
 	}, nil
-}
\ No newline at end of file
+}
@@ -2,24 +2,33 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"io"
+	"strconv"
+	"time"
 
+	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/internal/service"
 	"github.com/nabil/book-store-system/internal/transport/dto"
 	"github.com/nabil/book-store-system/proto"
+	grpcServer "google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 // UserHandler handles gRPC requests for user operations
 type UserHandler struct {
 	proto.UnimplementedUserServiceServer
-	userService service.UserService
+	userService    service.UserService
+	privacyService service.PrivacyService
 }
 
 // NewUserHandler creates a new UserHandler
-func NewUserHandler(userService service.UserService) *UserHandler {
+func NewUserHandler(userService service.UserService, privacyService service.PrivacyService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:    userService,
+		privacyService: privacyService,
 	}
 }
 
@@ -38,17 +47,12 @@ func (h *UserHandler) Register(ctx context.Context, req *proto.RegisterRequest)
 
 	user, err := h.userService.Register(req.Name, req.Email, req.Password)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to register user: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to register user: %v", err)
 	}
 
 	return &proto.RegisterResponse{
 		Success: true,
-		User: &proto.User{
-			Id:    uint32(user.ID),
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
+		User:    toProtoUser(user),
 		Message: "User registered successfully",
 	}, nil
 }
@@ -65,46 +69,476 @@ func (h *UserHandler) Login(ctx context.Context, req *proto.LoginRequest) (*prot
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
-	token, user, err := h.userService.Login(req.Email, req.Password)
+	result, err := h.userService.Login(ctx, req.Email, req.Password)
 	if err != nil {
+		var lockedErr *service.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			grpcServer.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(lockedErr.RetryAfter.Seconds()))))
+			return nil, status.Errorf(codes.ResourceExhausted, "Login failed: %v", err)
+		}
 		return nil, status.Errorf(codes.Unauthenticated, "Login failed: %v", err)
 	}
 
-	return &proto.LoginResponse{
-		Success: true,
-		Token:   token,
-		User: &proto.User{
-			Id:    uint32(user.ID),
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
-		Message: "Login successful",
-	}, nil
+	return toProtoLoginResponse(result), nil
+}
+
+// toProtoLoginResponse converts the result of a password or OIDC login
+func toProtoLoginResponse(result *service.LoginResult) *proto.LoginResponse {
+	if result.MFARequired {
+		return &proto.LoginResponse{
+			Success:     true,
+			MfaRequired: true,
+			MfaToken:    result.MFAToken,
+			User:        toProtoUser(result.User),
+			Message:     "MFA verification required",
+		}
+	}
+
+	response := &proto.LoginResponse{
+		Success:      true,
+		Token:        result.Tokens.AccessToken,
+		RefreshToken: result.Tokens.RefreshToken,
+		ExpiresIn:    int64(result.Tokens.ExpiresIn.Seconds()),
+		User:         toProtoUser(result.User),
+		Message:      "Login successful",
+	}
+	if result.MFAEnrollmentRequired {
+		response.MfaEnrollmentRequired = true
+		response.Message = "MFA enrollment required"
+	}
+
+	return response
 }
 
 // GetProfile retrieves user profile
 func (h *UserHandler) GetProfile(ctx context.Context, req *proto.GetProfileRequest) (*proto.GetProfileResponse, error) {
+	user, err := h.userService.GetProfile(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Failed to get profile: %v", err)
+	}
+
+	return &proto.GetProfileResponse{
+		User: toProtoUser(user),
+	}, nil
+}
+
+// UpdateProfile updates the profile of the user owning the token
+func (h *UserHandler) UpdateProfile(ctx context.Context, req *proto.UpdateProfileRequest) (*proto.UpdateProfileResponse, error) {
+	// Validate request using DTO
+	updateProfileDTO := &dto.UpdateProfileRequestDTO{
+		Name:    req.Name,
+		Email:   req.Email,
+		Phone:   req.Phone,
+		Address: req.Address,
+	}
+
+	if err := updateProfileDTO.ValidateUpdateProfileRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	currentUser, err := h.userService.GetProfile(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Failed to update profile: %v", err)
+	}
+
+	user, err := h.userService.UpdateProfile(currentUser.ID, req.Name, req.Email, req.Phone, req.Address)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to update profile: %v", err)
+	}
+
+	return &proto.UpdateProfileResponse{
+		Success: true,
+		User:    toProtoUser(user),
+		Message: "Profile updated successfully",
+	}, nil
+}
+
+// ChangePassword changes the password of the user owning the token
+func (h *UserHandler) ChangePassword(ctx context.Context, req *proto.ChangePasswordRequest) (*proto.ChangePasswordResponse, error) {
+	// Validate request using DTO
+	changePasswordDTO := &dto.ChangePasswordRequestDTO{
+		OldPassword: req.OldPassword,
+		NewPassword: req.NewPassword,
+	}
+
+	if err := changePasswordDTO.ValidateChangePasswordRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	currentUser, err := h.userService.GetProfile(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Failed to change password: %v", err)
+	}
+
+	tokens, err := h.userService.ChangePassword(ctx, currentUser.ID, req.OldPassword, req.NewPassword)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to change password: %v", err)
+	}
+
+	return &proto.ChangePasswordResponse{
+		Success:      true,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+		Message:      "Password changed successfully",
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair
+func (h *UserHandler) RefreshToken(ctx context.Context, req *proto.RefreshTokenRequest) (*proto.RefreshTokenResponse, error) {
+	// Validate request using DTO
+	refreshDTO := &dto.RefreshTokenRequestDTO{
+		RefreshToken: req.RefreshToken,
+	}
+
+	if err := refreshDTO.ValidateRefreshTokenRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	tokens, _, err := h.userService.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Failed to refresh token: %v", err)
+	}
+
+	return &proto.RefreshTokenResponse{
+		Success:      true,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+		Message:      "Token refreshed successfully",
+	}, nil
+}
+
+// Logout revokes the caller's access token and refresh token
+func (h *UserHandler) Logout(ctx context.Context, req *proto.LogoutRequest) (*proto.LogoutResponse, error) {
+	if err := h.userService.Logout(ctx, req.RefreshToken); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Failed to logout: %v", err)
+	}
+
+	return &proto.LogoutResponse{
+		Success: true,
+		Message: "Logout successful",
+	}, nil
+}
+
+// LogoutAllDevices revokes every token of the caller
+func (h *UserHandler) LogoutAllDevices(ctx context.Context, req *proto.LogoutAllDevicesRequest) (*proto.LogoutAllDevicesResponse, error) {
+	if err := h.userService.LogoutAllDevices(ctx); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Failed to logout from all devices: %v", err)
+	}
+
+	return &proto.LogoutAllDevicesResponse{
+		Success: true,
+		Message: "Logged out from all devices successfully",
+	}, nil
+}
+
+// EnrollMFA starts TOTP enrollment for the authenticated user
+func (h *UserHandler) EnrollMFA(ctx context.Context, req *proto.EnrollMFARequest) (*proto.EnrollMFAResponse, error) {
+	secret, provisioningURI, err := h.userService.EnrollMFA(ctx)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.FailedPrecondition), "Failed to enroll MFA: %v", err)
+	}
+
+	return &proto.EnrollMFAResponse{
+		Success:         true,
+		Message:         "Scan the provisioning URI and confirm with a code",
+		Secret:          secret,
+		ProvisioningUri: provisioningURI,
+	}, nil
+}
+
+// ConfirmMFA enables MFA after the first valid code
+func (h *UserHandler) ConfirmMFA(ctx context.Context, req *proto.ConfirmMFARequest) (*proto.ConfirmMFAResponse, error) {
 	// Validate request using DTO
-	getProfileDTO := &dto.GetProfileRequestDTO{
+	confirmDTO := &dto.ConfirmMFARequestDTO{
+		Code: req.Code,
+	}
+
+	if err := confirmDTO.ValidateConfirmMFARequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	recoveryCodes, tokens, err := h.userService.ConfirmMFA(ctx, req.Code)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.FailedPrecondition), "Failed to confirm MFA: %v", err)
+	}
+
+	return &proto.ConfirmMFAResponse{
+		Success:       true,
+		Message:       "MFA enabled successfully",
+		RecoveryCodes: recoveryCodes,
+		Token:         tokens.AccessToken,
+		RefreshToken:  tokens.RefreshToken,
+		ExpiresIn:     int64(tokens.ExpiresIn.Seconds()),
+	}, nil
+}
+
+// VerifyMFA completes a login that requires a second factor
+func (h *UserHandler) VerifyMFA(ctx context.Context, req *proto.VerifyMFARequest) (*proto.VerifyMFAResponse, error) {
+	// Validate request using DTO
+	verifyDTO := &dto.VerifyMFARequestDTO{
+		MFAToken: req.MfaToken,
+		Code:     req.Code,
+	}
+
+	if err := verifyDTO.ValidateVerifyMFARequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	tokens, user, err := h.userService.VerifyMFA(ctx, req.MfaToken, req.Code)
+	if err != nil {
+		var lockedErr *service.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			grpcServer.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(lockedErr.RetryAfter.Seconds()))))
+			return nil, status.Errorf(codes.ResourceExhausted, "MFA verification failed: %v", err)
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "MFA verification failed: %v", err)
+	}
+
+	return &proto.VerifyMFAResponse{
+		Success:      true,
+		Message:      "Login successful",
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+		User:         toProtoUser(user),
+	}, nil
+}
+
+// RequestPasswordReset emails a password reset code to the user
+func (h *UserHandler) RequestPasswordReset(ctx context.Context, req *proto.RequestPasswordResetRequest) (*proto.RequestPasswordResetResponse, error) {
+	// Validate request using DTO
+	requestDTO := &dto.RequestPasswordResetRequestDTO{
+		Email: req.Email,
+	}
+
+	if err := requestDTO.ValidateRequestPasswordResetRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.userService.RequestPasswordReset(ctx, req.Email); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to request password reset: %v", err)
+	}
+
+	return &proto.RequestPasswordResetResponse{
+		Success: true,
+		Message: "If the email is registered, a password reset code has been sent",
+	}, nil
+}
+
+// ConfirmPasswordReset sets a new password with a reset code
+func (h *UserHandler) ConfirmPasswordReset(ctx context.Context, req *proto.ConfirmPasswordResetRequest) (*proto.ConfirmPasswordResetResponse, error) {
+	// Validate request using DTO
+	confirmDTO := &dto.ConfirmPasswordResetRequestDTO{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}
+
+	if err := confirmDTO.ValidateConfirmPasswordResetRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.userService.ConfirmPasswordReset(ctx, req.Token, req.NewPassword); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.FailedPrecondition), "Failed to reset password: %v", err)
+	}
+
+	return &proto.ConfirmPasswordResetResponse{
+		Success: true,
+		Message: "Password reset successfully, please log in again",
+	}, nil
+}
+
+// VerifyEmail marks the email address of the code's user as verified
+func (h *UserHandler) VerifyEmail(ctx context.Context, req *proto.VerifyEmailRequest) (*proto.VerifyEmailResponse, error) {
+	// Validate request using DTO
+	verifyDTO := &dto.VerifyEmailRequestDTO{
 		Token: req.Token,
 	}
 
-	if err := getProfileDTO.ValidateGetProfileRequest(); err != nil {
+	if err := verifyDTO.ValidateVerifyEmailRequest(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
 	}
 
-	user, err := h.userService.GetProfile(req.Token)
+	user, err := h.userService.VerifyEmail(ctx, req.Token)
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "Failed to get profile: %v", err)
+		return nil, status.Errorf(errorCode(err, codes.FailedPrecondition), "Failed to verify email: %v", err)
 	}
 
-	return &proto.GetProfileResponse{
-		User: &proto.User{
-			Id:    uint32(user.ID),
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-		},
+	return &proto.VerifyEmailResponse{
+		Success: true,
+		Message: "Email verified successfully",
+		User:    toProtoUser(user),
+	}, nil
+}
+
+// ResendVerification sends a new verification email to the authenticated user
+func (h *UserHandler) ResendVerification(ctx context.Context, req *proto.ResendVerificationRequest) (*proto.ResendVerificationResponse, error) {
+	if err := h.userService.ResendVerification(ctx); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.FailedPrecondition), "Failed to resend verification email: %v", err)
+	}
+
+	return &proto.ResendVerificationResponse{
+		Success: true,
+		Message: "Verification email sent",
+	}, nil
+}
+
+// BeginOIDCLogin returns the URL that signs the user in at an identity provider
+func (h *UserHandler) BeginOIDCLogin(ctx context.Context, req *proto.BeginOIDCLoginRequest) (*proto.BeginOIDCLoginResponse, error) {
+	// Validate request using DTO
+	beginDTO := &dto.BeginOIDCLoginRequestDTO{
+		Provider: req.Provider,
+	}
+
+	if err := beginDTO.ValidateBeginOIDCLoginRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	authURL, state, err := h.userService.BeginOIDCLogin(ctx, req.Provider)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Unavailable), "Failed to start login: %v", err)
+	}
+
+	return &proto.BeginOIDCLoginResponse{
+		Success: true,
+		Message: "Redirect the user to auth_url",
+		AuthUrl: authURL,
+		State:   state,
 	}, nil
 }
+
+// CompleteOIDCLogin logs in with the code returned by an identity provider
+func (h *UserHandler) CompleteOIDCLogin(ctx context.Context, req *proto.CompleteOIDCLoginRequest) (*proto.LoginResponse, error) {
+	// Validate request using DTO
+	completeDTO := &dto.CompleteOIDCLoginRequestDTO{
+		State: req.State,
+		Code:  req.Code,
+	}
+
+	if err := completeDTO.ValidateCompleteOIDCLoginRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	result, err := h.userService.CompleteOIDCLogin(ctx, req.State, req.Code)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Unauthenticated), "Login failed: %v", err)
+	}
+
+	return toProtoLoginResponse(result), nil
+}
+
+// ListMySessions lists the devices the caller is signed in on
+func (h *UserHandler) ListMySessions(ctx context.Context, req *proto.ListMySessionsRequest) (*proto.ListMySessionsResponse, error) {
+	sessions, currentSessionID, err := h.userService.ListMySessions(ctx)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to list sessions: %v", err)
+	}
+
+	protoSessions := make([]*proto.Session, 0, len(sessions))
+	for _, session := range sessions {
+		protoSessions = append(protoSessions, toProtoSession(session, currentSessionID))
+	}
+
+	return &proto.ListMySessionsResponse{
+		Success:  true,
+		Message:  "Sessions retrieved successfully",
+		Sessions: protoSessions,
+	}, nil
+}
+
+// RevokeSession signs the caller out of one of their devices
+func (h *UserHandler) RevokeSession(ctx context.Context, req *proto.RevokeSessionRequest) (*proto.RevokeSessionResponse, error) {
+	// Validate request using DTO
+	revokeDTO := &dto.RevokeSessionRequestDTO{
+		ID: req.Id,
+	}
+
+	if err := revokeDTO.ValidateRevokeSessionRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.userService.RevokeSession(ctx, uint(revokeDTO.ID)); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.Internal), "Failed to revoke session: %v", err)
+	}
+
+	return &proto.RevokeSessionResponse{
+		Success: true,
+		Message: "Session revoked successfully",
+	}, nil
+}
+
+// toProtoSession converts a session entity into its protobuf representation
+func toProtoSession(session *entity.Session, currentSessionID uint) *proto.Session {
+	return &proto.Session{
+		Id:         uint32(session.ID),
+		UserAgent:  session.UserAgent,
+		DeviceName: session.DeviceName,
+		IpAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+		LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+		ExpiresAt:  session.ExpiresAt.Format(time.RFC3339),
+		Current:    session.ID == currentSessionID,
+	}
+}
+
+// ExportMyData streams a JSON archive of the caller's personal data
+func (h *UserHandler) ExportMyData(req *proto.ExportMyDataRequest, stream proto.UserService_ExportMyDataServer) error {
+	err := streamExport(stream, func(w io.Writer) error {
+		return h.privacyService.ExportMyData(stream.Context(), w)
+	})
+	if err != nil {
+		return status.Errorf(errorCode(err, codes.Internal), "Failed to export data: %v", err)
+	}
+	return nil
+}
+
+// DeleteMyAccount erases the caller's personal data and closes the account
+func (h *UserHandler) DeleteMyAccount(ctx context.Context, req *proto.DeleteMyAccountRequest) (*proto.DeleteMyAccountResponse, error) {
+	// Validate request using DTO
+	deleteDTO := &dto.DeleteMyAccountRequestDTO{
+		Password: req.Password,
+	}
+
+	if err := deleteDTO.ValidateDeleteMyAccountRequest(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Validation failed: %v", err)
+	}
+
+	if err := h.privacyService.DeleteMyAccount(ctx, req.Password); err != nil {
+		return nil, status.Errorf(errorCode(err, codes.FailedPrecondition), "Failed to delete account: %v", err)
+	}
+
+	return &proto.DeleteMyAccountResponse{
+		Success: true,
+		Message: "Account deleted successfully",
+	}, nil
+}
+
+// toProtoUser converts a user entity into its protobuf representation
+func toProtoUser(user *entity.User) *proto.User {
+	protoUser := &proto.User{
+		Id:         uint32(user.ID),
+		Name:       user.Name,
+		Email:      user.Email,
+		Role:       user.Role,
+		Phone:      user.Phone,
+		Address:    user.Address,
+		CreatedAt:  user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  user.UpdatedAt.Format(time.RFC3339),
+		MfaEnabled: user.MFAEnabled(),
+	}
+
+	if user.DisabledAt != nil {
+		protoUser.Disabled = true
+		protoUser.DisabledAt = user.DisabledAt.Format(time.RFC3339)
+	}
+	if user.EmailVerifiedAt != nil {
+		protoUser.EmailVerified = true
+		protoUser.EmailVerifiedAt = user.EmailVerifiedAt.Format(time.RFC3339)
+	}
+	if user.AnonymizedAt != nil {
+		protoUser.AnonymizedAt = user.AnonymizedAt.Format(time.RFC3339)
+	}
+
+	return protoUser
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"fmt"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"github.com/nabil/book-store-system/pkg/middleware"
+)
+
+// NewRouter returns the HTTP handler served next to the gRPC API. Blobs of the
+// local blob store are served below /media/ when mediaDir is set.
+func NewRouter(userCache *middleware.UserCache, mediaDir string) nethttp.Handler {
+	mux := nethttp.NewServeMux()
+	mux.HandleFunc("GET /.well-known/jwks.json", JWKSHandler)
+	mux.HandleFunc("GET /metrics", MetricsHandler(userCache))
+	if mediaDir != "" {
+		mux.Handle("GET /media/", nethttp.StripPrefix("/media", MediaHandler(mediaDir)))
+	}
+	return mux
+}
+
+// MediaHandler serves the files below dir, such as book covers. Blob keys are
+// random and never reused, so responses may be cached indefinitely.
+func MediaHandler(dir string) nethttp.HandlerFunc {
+	files := nethttp.FileServer(nethttp.Dir(dir))
+	return func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		// Directory listings would reveal every stored blob
+		if strings.HasSuffix(r.URL.Path, "/") {
+			nethttp.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		files.ServeHTTP(w, r)
+	}
+}
+
+// JWKSHandler serves the public keys that verify access tokens issued by this service
+func JWKSHandler(w nethttp.ResponseWriter, r *nethttp.Request) {
+	body, err := helpers.JWKS()
+	if err != nil {
+		logger.Errorf("Failed to build JWKS document: %v", err)
+		nethttp.Error(w, "internal server error", nethttp.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(body)
+}
+
+// MetricsHandler serves the auth user cache metrics in the Prometheus text format
+func MetricsHandler(userCache *middleware.UserCache) nethttp.HandlerFunc {
+	return func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		stats := userCache.Stats()
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintln(w, "# HELP auth_user_cache_hits_total Users resolved from the auth cache.")
+		fmt.Fprintln(w, "# TYPE auth_user_cache_hits_total counter")
+		fmt.Fprintf(w, "auth_user_cache_hits_total %d\n", stats.Hits)
+		fmt.Fprintln(w, "# HELP auth_user_cache_misses_total Users loaded from the database by the auth interceptor.")
+		fmt.Fprintln(w, "# TYPE auth_user_cache_misses_total counter")
+		fmt.Fprintf(w, "auth_user_cache_misses_total %d\n", stats.Misses)
+		fmt.Fprintln(w, "# HELP auth_user_cache_evictions_total Users evicted because the cache was full.")
+		fmt.Fprintln(w, "# TYPE auth_user_cache_evictions_total counter")
+		fmt.Fprintf(w, "auth_user_cache_evictions_total %d\n", stats.Evictions)
+		fmt.Fprintln(w, "# HELP auth_user_cache_entries Users currently cached.")
+		fmt.Fprintln(w, "# TYPE auth_user_cache_entries gauge")
+		fmt.Fprintf(w, "auth_user_cache_entries %d\n", stats.Entries)
+	}
+}
@@ -33,17 +33,43 @@ func Connect() {
 
 // Migrate runs auto migration for the database models
 func Migrate() {
+	// Accounts created before email verification existed are treated as verified
+	grandfatherEmails := !DB.Migrator().HasColumn(&entity.User{}, "email_verified_at")
+
 	err := DB.AutoMigrate(
 		&entity.User{},
 		&entity.Category{},
 		&entity.Book{},
 		&entity.Order{},
 		&entity.OrderItem{},
+		&entity.RefreshToken{},
+		&entity.RevokedToken{},
+		&entity.Permission{},
+		&entity.Role{},
+		&entity.LoginAttempt{},
+		&entity.MFARecoveryCode{},
+		&entity.APIKey{},
+		&entity.PasswordResetToken{},
+		&entity.AuditEvent{},
+		&entity.Address{},
+		&entity.ExternalIdentity{},
+		&entity.OIDCLoginState{},
+		&entity.Session{},
 	)
 
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
+	if grandfatherEmails {
+		if err := DB.Model(&entity.User{}).Where("email_verified_at IS NULL").Update("email_verified_at", gorm.Expr("created_at")).Error; err != nil {
+			log.Fatalf("Failed to mark existing users as verified: %v", err)
+		}
+		logger.Info("Existing users marked as email verified")
+	}
+
+	migrateBookSearch()
+	seedRoles()
+
 	logger.Info("Database migration completed")
-}
\ No newline at end of file
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"log"
+
+	"github.com/nabil/book-store-system/pkg/logger"
+)
+
+// bookSearchMigrations maintain books.search_vector, the tsvector searched by
+// GetBooks. A generated column cannot read the category name from another
+// table, so the vector is kept up to date by triggers instead: one on books and
+// one refreshing the books of a category when the category is renamed.
+// Weights rank matches in the title above author, category and description.
+// The 'simple' configuration does not stem, as titles mix languages.
+// Trigram indexes (pg_trgm) serve typo-tolerant matching and SuggestBooks.
+var bookSearchMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE INDEX IF NOT EXISTS idx_books_title_trgm ON books USING GIN (title gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_books_author_trgm ON books USING GIN (author gin_trgm_ops)`,
+	`ALTER TABLE books ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+	`CREATE INDEX IF NOT EXISTS idx_books_search_vector ON books USING GIN (search_vector)`,
+	`CREATE OR REPLACE FUNCTION books_search_vector_update() RETURNS trigger AS $$
+BEGIN
+	NEW.search_vector :=
+		setweight(to_tsvector('simple', coalesce(NEW.title, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(NEW.author, '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce((SELECT name FROM categories WHERE id = NEW.category_id), '')), 'C') ||
+		setweight(to_tsvector('simple', coalesce(NEW.description, '')), 'D');
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS books_search_vector_trigger ON books`,
+	`CREATE TRIGGER books_search_vector_trigger
+	BEFORE INSERT OR UPDATE OF title, author, description, category_id ON books
+	FOR EACH ROW EXECUTE FUNCTION books_search_vector_update()`,
+	`CREATE OR REPLACE FUNCTION categories_search_vector_update() RETURNS trigger AS $$
+BEGIN
+	-- Touching the title fires books_search_vector_trigger for each book
+	UPDATE books SET title = title WHERE category_id = NEW.id;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql`,
+	`DROP TRIGGER IF EXISTS categories_search_vector_trigger ON categories`,
+	`CREATE TRIGGER categories_search_vector_trigger
+	AFTER UPDATE OF name ON categories
+	FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+	EXECUTE FUNCTION categories_search_vector_update()`,
+	// Fill the vector of books created before the trigger existed
+	`UPDATE books SET title = title WHERE search_vector IS NULL`,
+}
+
+// migrateBookSearch creates the full-text search column, the search indexes
+// and triggers
+func migrateBookSearch() {
+	for _, stmt := range bookSearchMigrations {
+		if err := DB.Exec(stmt).Error; err != nil {
+			log.Fatalf("Failed to migrate book search: %v", err)
+		}
+	}
+	logger.Info("Book search index migrated")
+}
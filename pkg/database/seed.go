@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"log"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// defaultPermissions lists every permission known to the services
+var defaultPermissions = []entity.Permission{
+	{Name: entity.PermissionBooksWrite, Description: "Create, update and delete books and manage stock"},
+	{Name: entity.PermissionCategoriesWrite, Description: "Create, update and delete categories"},
+	{Name: entity.PermissionOrdersRead, Description: "Read orders of every customer"},
+	{Name: entity.PermissionOrdersUpdateStatus, Description: "Change the status of orders"},
+	{Name: entity.PermissionReportsRead, Description: "Read sales reports and statistics"},
+	{Name: entity.PermissionUsersRead, Description: "List and read user accounts"},
+	{Name: entity.PermissionUsersWrite, Description: "Change roles, disable and delete user accounts"},
+	{Name: entity.PermissionRolesManage, Description: "Create and edit roles"},
+	{Name: entity.PermissionAPIKeysManage, Description: "Create, list and revoke API keys"},
+	{Name: entity.PermissionAuditRead, Description: "Read the audit log of administrative changes"},
+	{Name: entity.PermissionUsersImpersonate, Description: "Sign in as a customer to see what they see"},
+}
+
+// defaultRoles maps the built-in roles to the permissions they get when first created.
+// super_admin always holds every permission.
+var defaultRoles = []struct {
+	name        string
+	description string
+	permissions []string
+}{
+	{entity.RoleSuperAdmin, "Full access to every operation", nil},
+	{entity.RoleInventoryManager, "Manages books, stock and categories", []string{
+		entity.PermissionBooksWrite,
+		entity.PermissionCategoriesWrite,
+	}},
+	{entity.RoleOrderOperator, "Processes customer orders", []string{
+		entity.PermissionOrdersRead,
+		entity.PermissionOrdersUpdateStatus,
+	}},
+	{entity.RoleFinance, "Reads reports and orders", []string{
+		entity.PermissionReportsRead,
+		entity.PermissionOrdersRead,
+	}},
+	{entity.RoleUser, "Customer account", []string{}},
+}
+
+// seedRoles seeds the roles of DB and stops the server if that fails
+func seedRoles() {
+	if err := SeedRoles(DB); err != nil {
+		log.Fatalf("Failed to seed roles: %v", err)
+	}
+
+	logger.Info("Roles and permissions seeded")
+}
+
+// SeedRoles creates the built-in permissions and roles that do not exist yet and
+// migrates accounts still using the legacy "admin" role to super_admin
+func SeedRoles(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, permission := range defaultPermissions {
+			p := permission
+			if err := tx.Where(entity.Permission{Name: p.Name}).Attrs(entity.Permission{Description: p.Description}).FirstOrCreate(&p).Error; err != nil {
+				return err
+			}
+		}
+
+		var allPermissions []entity.Permission
+		if err := tx.Find(&allPermissions).Error; err != nil {
+			return err
+		}
+
+		for _, def := range defaultRoles {
+			var role entity.Role
+			err := tx.Where("name = ?", def.name).First(&role).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			created := errors.Is(err, gorm.ErrRecordNotFound)
+			if created {
+				role = entity.Role{Name: def.name, Description: def.description}
+				if err := tx.Omit("Permissions").Create(&role).Error; err != nil {
+					return err
+				}
+			}
+
+			// Roles edited by an administrator keep their permissions, except
+			// super_admin which must always be able to do everything
+			switch {
+			case def.name == entity.RoleSuperAdmin:
+				if err := tx.Model(&role).Association("Permissions").Replace(allPermissions); err != nil {
+					return err
+				}
+			case created:
+				var permissions []entity.Permission
+				if len(def.permissions) > 0 {
+					if err := tx.Where("name IN ?", def.permissions).Find(&permissions).Error; err != nil {
+						return err
+					}
+				}
+				if err := tx.Model(&role).Association("Permissions").Replace(permissions); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Accounts created before roles were introduced
+		return tx.Model(&entity.User{}).Where("role = ?", "admin").Update("role", entity.RoleSuperAdmin).Error
+	})
+}
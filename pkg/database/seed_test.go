@@ -0,0 +1,152 @@
+package database
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(&entity.User{}, &entity.Permission{}, &entity.Role{}); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	return db
+}
+
+// rolePermissions returns the sorted permission names of every role
+func rolePermissions(t *testing.T, db *gorm.DB) map[string][]string {
+	t.Helper()
+
+	var roles []entity.Role
+	if err := db.Preload("Permissions").Find(&roles).Error; err != nil {
+		t.Fatalf("load roles: %v", err)
+	}
+	result := make(map[string][]string, len(roles))
+	for _, role := range roles {
+		names := []string{}
+		for _, permission := range role.Permissions {
+			names = append(names, permission.Name)
+		}
+		sort.Strings(names)
+		result[role.Name] = names
+	}
+	return result
+}
+
+func TestSeedRolesCreatesBuiltInRoles(t *testing.T) {
+	db := newTestDB(t)
+	if err := SeedRoles(db); err != nil {
+		t.Fatalf("SeedRoles: %v", err)
+	}
+
+	var permissions int64
+	db.Model(&entity.Permission{}).Count(&permissions)
+	if permissions != int64(len(defaultPermissions)) {
+		t.Errorf("%d permissions seeded, want %d", permissions, len(defaultPermissions))
+	}
+
+	roles := rolePermissions(t, db)
+	want := map[string][]string{
+		entity.RoleInventoryManager: {entity.PermissionBooksWrite, entity.PermissionCategoriesWrite},
+		entity.RoleOrderOperator:    {entity.PermissionOrdersRead, entity.PermissionOrdersUpdateStatus},
+		entity.RoleFinance:          {entity.PermissionOrdersRead, entity.PermissionReportsRead},
+		entity.RoleUser:             {},
+	}
+	for name, permissions := range want {
+		if got, ok := roles[name]; !ok || !equalStrings(got, permissions) {
+			t.Errorf("role %s has permissions %v, want %v", name, got, permissions)
+		}
+	}
+	if got := len(roles[entity.RoleSuperAdmin]); got != len(defaultPermissions) {
+		t.Errorf("super_admin has %d permissions, want all %d", got, len(defaultPermissions))
+	}
+}
+
+func TestSeedRolesKeepsEditedRoles(t *testing.T) {
+	db := newTestDB(t)
+	if err := SeedRoles(db); err != nil {
+		t.Fatalf("SeedRoles: %v", err)
+	}
+
+	// An administrator narrows a built-in role and a new permission appears,
+	// e.g. after an upgrade
+	var role entity.Role
+	db.Where("name = ?", entity.RoleInventoryManager).First(&role)
+	var booksWrite entity.Permission
+	db.Where("name = ?", entity.PermissionBooksWrite).First(&booksWrite)
+	if err := db.Model(&role).Association("Permissions").Replace([]entity.Permission{booksWrite}); err != nil {
+		t.Fatalf("edit role: %v", err)
+	}
+	if err := db.Where("name = ?", entity.PermissionAuditRead).Delete(&entity.Permission{}).Error; err != nil {
+		t.Fatalf("delete permission: %v", err)
+	}
+
+	if err := SeedRoles(db); err != nil {
+		t.Fatalf("SeedRoles again: %v", err)
+	}
+
+	roles := rolePermissions(t, db)
+	if got := roles[entity.RoleInventoryManager]; !equalStrings(got, []string{entity.PermissionBooksWrite}) {
+		t.Errorf("edited inventory_manager has permissions %v after seeding again, want only books:write", got)
+	}
+	// super_admin always gets every permission, including ones added later
+	if got := len(roles[entity.RoleSuperAdmin]); got != len(defaultPermissions) {
+		t.Errorf("super_admin has %d permissions after seeding again, want all %d", got, len(defaultPermissions))
+	}
+}
+
+func TestSeedRolesMigratesLegacyAdmins(t *testing.T) {
+	db := newTestDB(t)
+	legacy := []entity.User{
+		{Name: "Old Admin", Email: "admin@example.com", Password: "x", Role: "admin"},
+		{Name: "Customer", Email: "customer@example.com", Password: "x", Role: entity.RoleUser},
+	}
+	if err := db.Create(&legacy).Error; err != nil {
+		t.Fatalf("create users: %v", err)
+	}
+
+	if err := SeedRoles(db); err != nil {
+		t.Fatalf("SeedRoles: %v", err)
+	}
+
+	var admin, customer entity.User
+	db.First(&admin, legacy[0].ID)
+	db.First(&customer, legacy[1].ID)
+	if admin.Role != entity.RoleSuperAdmin {
+		t.Errorf("legacy admin has role %q, want super_admin", admin.Role)
+	}
+	if customer.Role != entity.RoleUser {
+		t.Errorf("customer has role %q, want user", customer.Role)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
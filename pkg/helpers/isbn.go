@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidISBN is wrapped by every error returned by NormalizeISBN
+var ErrInvalidISBN = errors.New("invalid ISBN")
+
+// NormalizeISBN validates an ISBN-10 or ISBN-13 and returns it as 13 digits
+// without separators. Hyphens and spaces are ignored, and ISBN-10s are
+// converted to their 978-prefixed ISBN-13.
+func NormalizeISBN(isbn string) (string, error) {
+	digits := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(isbn)))
+
+	switch len(digits) {
+	case 10:
+		if !validISBN10(digits) {
+			return "", fmt.Errorf("%w: %q has a wrong check digit or characters", ErrInvalidISBN, isbn)
+		}
+		isbn13 := "978" + digits[:9]
+		return isbn13 + string(isbn13CheckDigit(isbn13)), nil
+	case 13:
+		if !isDigits(digits) || isbn13CheckDigit(digits[:12]) != digits[12] {
+			return "", fmt.Errorf("%w: %q has a wrong check digit or characters", ErrInvalidISBN, isbn)
+		}
+		if !strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979") {
+			return "", fmt.Errorf("%w: %q must start with 978 or 979", ErrInvalidISBN, isbn)
+		}
+		return digits, nil
+	default:
+		return "", fmt.Errorf("%w: %q must have 10 or 13 digits", ErrInvalidISBN, isbn)
+	}
+}
+
+// validISBN10 checks the mod 11 checksum of an ISBN-10, whose last character
+// may be X for 10
+func validISBN10(isbn string) bool {
+	if !isDigits(isbn[:9]) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(isbn[i]-'0')
+	}
+	switch check := isbn[9]; {
+	case check == 'X':
+		sum += 10
+	case check >= '0' && check <= '9':
+		sum += int(check - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// isbn13CheckDigit computes the check digit of the first 12 digits of an ISBN-13
+func isbn13CheckDigit(first12 string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(first12[i]-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
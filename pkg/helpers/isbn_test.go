@@ -0,0 +1,73 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		name  string
+		isbn  string
+		want  string
+		valid bool
+	}{
+		{"ISBN-13", "9780306406157", "9780306406157", true},
+		{"ISBN-13 with hyphens", "978-0-306-40615-7", "9780306406157", true},
+		{"ISBN-13 with spaces", " 978 0 306 40615 7 ", "9780306406157", true},
+		{"ISBN-13 with 979 prefix", "979-10-90636-07-1", "9791090636071", true},
+		{"ISBN-10", "0306406152", "9780306406157", true},
+		{"ISBN-10 with hyphens", "1-86197-271-7", "9781861972712", true},
+		{"ISBN-10 with X check digit", "0-8044-2957-X", "9780804429573", true},
+		{"ISBN-10 with lowercase x check digit", "080442957x", "9780804429573", true},
+
+		{"ISBN-13 with bad checksum", "978-0-306-40615-8", "", false},
+		{"ISBN-10 with bad checksum", "0-306-40615-3", "", false},
+		{"ISBN-10 with X where the checksum is a digit", "030640615X", "", false},
+		{"ISBN-10 with X before the check digit", "08044295X7", "", false},
+		{"ISBN-13 with X", "978080442957X", "", false},
+		{"ISBN-13 without a bookland prefix", "9771234567898", "", false},
+		{"letters", "978-0-306-4O615-7", "", false},
+		{"too short", "978030640615", "", false},
+		{"too long", "97803064061571", "", false},
+		{"other separators", "978.0.306.40615.7", "", false},
+		{"empty", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeISBN(tt.isbn)
+			if !tt.valid {
+				if err == nil {
+					t.Fatalf("NormalizeISBN(%q) = %q, want an error", tt.isbn, got)
+				}
+				if !errors.Is(err, ErrInvalidISBN) {
+					t.Errorf("NormalizeISBN(%q) error = %v, want ErrInvalidISBN", tt.isbn, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeISBN(%q): %v", tt.isbn, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeISBN(%q) = %q, want %q", tt.isbn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISBN13CheckDigit(t *testing.T) {
+	tests := []struct {
+		first12 string
+		want    byte
+	}{
+		{"978030640615", '7'},
+		{"978316148410", '0'},
+		{"978186197271", '2'},
+		{"979109063607", '1'},
+	}
+	for _, tt := range tests {
+		if got := isbn13CheckDigit(tt.first12); got != tt.want {
+			t.Errorf("isbn13CheckDigit(%q) = %c, want %c", tt.first12, got, tt.want)
+		}
+	}
+}
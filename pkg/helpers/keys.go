@@ -0,0 +1,203 @@
+package helpers
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/pkg/logger"
+)
+
+// tokenKey is a key used to sign or verify JWTs, identified by its kid
+type tokenKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	public  crypto.PublicKey
+	private crypto.Signer // nil for verification-only keys
+}
+
+// tokenSettings holds everything needed to issue and verify tokens. It is
+// populated once at startup by InitTokens.
+var tokenSettings struct {
+	signingKey       *tokenKey
+	verificationKeys map[string]*tokenKey
+	issuer           string
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
+}
+
+// InitTokens loads the JWT signing key and the additional verification keys
+// from their PEM files. Keys that are being rotated out stay listed in
+// JWT_VERIFICATION_KEY_FILES until every token they signed has expired.
+// Without a signing key file it fails, unless JWT_ALLOW_EPHEMERAL_KEY opts in
+// to a key that only lives as long as the process.
+func InitTokens(cfg *config.Config) error {
+	tokenSettings.issuer = cfg.JWTIssuer
+	tokenSettings.accessTokenTTL = time.Minute * time.Duration(cfg.AccessTokenExpiry)
+	tokenSettings.refreshTokenTTL = time.Hour * time.Duration(cfg.RefreshTokenExpiry)
+	tokenSettings.verificationKeys = make(map[string]*tokenKey)
+
+	var signingKey *tokenKey
+	if cfg.JWTSigningKeyFile == "" {
+		if !cfg.JWTAllowEphemeralKey {
+			return errors.New("JWT_SIGNING_KEY_FILE is not set; set JWT_ALLOW_EPHEMERAL_KEY=true to sign with a temporary key during development")
+		}
+		logger.Warn("JWT_SIGNING_KEY_FILE is not set, using an ephemeral Ed25519 key; tokens will not survive a restart")
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		signingKey, err = newTokenKey(private.Public(), private)
+		if err != nil {
+			return err
+		}
+	} else {
+		key, err := loadPEMKey(cfg.JWTSigningKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load signing key: %w", err)
+		}
+		if key.private == nil {
+			return fmt.Errorf("signing key %s does not contain a private key", cfg.JWTSigningKeyFile)
+		}
+		signingKey = key
+	}
+
+	tokenSettings.signingKey = signingKey
+	tokenSettings.verificationKeys[signingKey.kid] = signingKey
+	logger.Infof("JWT signing key loaded: kid=%s alg=%s", signingKey.kid, signingKey.method.Alg())
+
+	for _, path := range cfg.JWTVerificationKeyFiles {
+		key, err := loadPEMKey(path)
+		if err != nil {
+			return fmt.Errorf("failed to load verification key %s: %w", path, err)
+		}
+		// Only the public half is needed to verify
+		key.private = nil
+		if _, exists := tokenSettings.verificationKeys[key.kid]; !exists {
+			tokenSettings.verificationKeys[key.kid] = key
+			logger.Infof("JWT verification key loaded: kid=%s alg=%s", key.kid, key.method.Alg())
+		}
+	}
+
+	return nil
+}
+
+// loadPEMKey reads an RSA or Ed25519 key from a PEM file. Both private keys
+// (PKCS#1 or PKCS#8) and public keys (PKIX or PKCS#1) are accepted.
+func loadPEMKey(path string) (*tokenKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return newTokenKey(&key.PublicKey, key)
+	case ed25519.PrivateKey:
+		return newTokenKey(key.Public(), key)
+	case *rsa.PublicKey, ed25519.PublicKey:
+		return newTokenKey(key, nil)
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, only RSA and Ed25519 keys are supported", parsed)
+	}
+}
+
+// newTokenKey picks the signing method for a key and derives its kid from the
+// RFC 7638 JWK thumbprint, so the same key always gets the same kid
+func newTokenKey(public crypto.PublicKey, private crypto.Signer) (*tokenKey, error) {
+	key := &tokenKey{public: public, private: private}
+
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must be at least 2048 bits")
+		}
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", public)
+	}
+
+	// Thumbprint input: required members only, sorted, no whitespace
+	var thumbprintInput string
+	jwk := key.jwk()
+	if jwk["kty"] == "RSA" {
+		thumbprintInput = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk["e"], jwk["n"])
+	} else {
+		thumbprintInput = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, jwk["x"])
+	}
+	sum := sha256.Sum256([]byte(thumbprintInput))
+	key.kid = base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return key, nil
+}
+
+// jwk returns the public JSON Web Key members of the key, without kid/alg/use
+func (k *tokenKey) jwk() map[string]string {
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return map[string]string{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(pub),
+		}
+	}
+	return nil
+}
+
+// JWKS returns the JSON Web Key Set of every key that is currently accepted
+// for verification, for other services that need to verify our tokens
+func JWKS() ([]byte, error) {
+	keys := make([]map[string]string, 0, len(tokenSettings.verificationKeys))
+	for _, key := range tokenSettings.verificationKeys {
+		jwk := key.jwk()
+		jwk["kid"] = key.kid
+		jwk["alg"] = key.method.Alg()
+		jwk["use"] = "sig"
+		keys = append(keys, jwk)
+	}
+
+	return json.Marshal(map[string]interface{}{"keys": keys})
+}
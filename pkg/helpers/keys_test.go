@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nabil/book-store-system/config"
+)
+
+func TestInitTokensRequiresSigningKey(t *testing.T) {
+	cfg := &config.Config{JWTIssuer: "test", AccessTokenExpiry: 15, RefreshTokenExpiry: 24}
+	if err := InitTokens(cfg); err == nil {
+		t.Fatal("InitTokens started without a signing key and without opting in to an ephemeral one")
+	}
+
+	cfg.JWTAllowEphemeralKey = true
+	if err := InitTokens(cfg); err != nil {
+		t.Fatalf("InitTokens() with an ephemeral key: %v", err)
+	}
+	token, err := GenerateToken(1, "reader@example.com", "user", 0, 1)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if _, err := ValidateToken(token); err != nil {
+		t.Errorf("ValidateToken() of a token signed with the ephemeral key: %v", err)
+	}
+}
+
+func TestInitTokensLoadsSigningKeyFile(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwt_signing.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+
+	// The opt-in is not needed, and not used, when a key file is set
+	cfg := &config.Config{JWTIssuer: "test", JWTSigningKeyFile: path, AccessTokenExpiry: 15, RefreshTokenExpiry: 24}
+	if err := InitTokens(cfg); err != nil {
+		t.Fatalf("InitTokens: %v", err)
+	}
+	token, err := GenerateToken(1, "reader@example.com", "user", 0, 1)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if _, err := ValidateToken(token); err != nil {
+		t.Errorf("ValidateToken: %v", err)
+	}
+}
@@ -1,20 +1,219 @@
 package helpers
 
 import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword hashes a password using bcrypt
-func HashPassword(password string) (string, error) {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// ErrPasswordPolicy is wrapped by every error returned by ValidatePasswordPolicy
+var ErrPasswordPolicy = errors.New("password does not meet the password policy")
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+
+	// Bounds accepted when decoding a hash, so that a malformed one can neither
+	// match every password nor exhaust memory
+	argon2MinSaltLength = 8
+	argon2MinKeyLength  = 16
+	argon2MaxKeyLength  = 128
+	argon2MaxMemoryKiB  = 4 * 1024 * 1024
+	argon2MaxIterations = 100
+)
+
+// argon2Params are the argon2id cost parameters encoded in every hash
+type argon2Params struct {
+	memory      uint32 // KiB
+	iterations  uint32
+	parallelism uint8
+}
+
+// validate checks the parameters against the limits of argon2id
+func (p argon2Params) validate() error {
+	if p.iterations < 1 || p.iterations > argon2MaxIterations {
+		return fmt.Errorf("argon2 iterations must be between 1 and %d", argon2MaxIterations)
+	}
+	if p.parallelism < 1 {
+		return errors.New("argon2 parallelism must be at least 1")
+	}
+	if p.memory < 8*uint32(p.parallelism) || p.memory > argon2MaxMemoryKiB {
+		return fmt.Errorf("argon2 memory must be between 8 KiB per thread and %d KiB", argon2MaxMemoryKiB)
+	}
+	return nil
+}
+
+// passwordSettings holds the hashing parameters and password policy. The
+// defaults are used until InitPasswords loads them from the configuration.
+var passwordSettings = struct {
+	argon2    argon2Params
+	minLength int
+	maxLength int
+	blocklist map[string]bool
+}{
+	argon2:    argon2Params{memory: 64 * 1024, iterations: 3, parallelism: 2},
+	minLength: 8,
+	maxLength: 128,
+}
+
+// InitPasswords loads the argon2id parameters and the password policy,
+// including the breached-password blocklist file
+func InitPasswords(cfg *config.Config) error {
+	params := argon2Params{
+		memory:      cfg.Password.Argon2MemoryKiB,
+		iterations:  cfg.Password.Argon2Iterations,
+		parallelism: cfg.Password.Argon2Parallelism,
+	}
+	if err := params.validate(); err != nil {
+		return fmt.Errorf("invalid password hashing parameters: %w", err)
+	}
+	passwordSettings.argon2 = params
+	passwordSettings.minLength = cfg.Password.MinLength
+	passwordSettings.maxLength = cfg.Password.MaxLength
+	passwordSettings.blocklist = nil
+
+	if cfg.Password.BlocklistFile == "" {
+		return nil
+	}
+
+	file, err := os.Open(cfg.Password.BlocklistFile)
 	if err != nil {
+		return fmt.Errorf("failed to open password blocklist: %w", err)
+	}
+	defer file.Close()
+
+	blocklist := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		blocklist[strings.ToLower(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read password blocklist: %w", err)
+	}
+
+	passwordSettings.blocklist = blocklist
+	logger.Infof("Password blocklist loaded: %d entries", len(blocklist))
+	return nil
+}
+
+// ValidatePasswordPolicy checks a new password against the configured length
+// limits and the breached-password blocklist
+func ValidatePasswordPolicy(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < passwordSettings.minLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrPasswordPolicy, passwordSettings.minLength)
+	}
+	if passwordSettings.maxLength > 0 && length > passwordSettings.maxLength {
+		return fmt.Errorf("%w: must be at most %d characters", ErrPasswordPolicy, passwordSettings.maxLength)
+	}
+	if passwordSettings.blocklist[strings.ToLower(password)] {
+		return fmt.Errorf("%w: this password appears in a list of breached passwords", ErrPasswordPolicy)
+	}
+	return nil
+}
+
+// HashPassword hashes a password with argon2id and returns it in the PHC string
+// format: $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
 		return "", err
 	}
-	return string(hashed), nil
+
+	params := passwordSettings.argon2
+	hash := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.iterations, params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	), nil
 }
 
-// CheckPassword checks if a password matches the hashed password
+// CheckPassword checks if a password matches the hashed password. Both argon2id
+// hashes and legacy bcrypt hashes are supported.
 func CheckPassword(password, hashedPassword string) bool {
+	if strings.HasPrefix(hashedPassword, "$argon2id$") {
+		params, salt, hash, err := decodeArgon2Hash(hashedPassword)
+		if err != nil {
+			logger.Errorf("Failed to decode argon2id hash: %v", err)
+			return false
+		}
+		computed := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(hash)))
+		return subtle.ConstantTimeCompare(computed, hash) == 1
+	}
+
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	return err == nil
-}
\ No newline at end of file
+}
+
+// PasswordNeedsRehash reports whether a hash uses a legacy algorithm or
+// outdated argon2id parameters and should be replaced after the next login
+func PasswordNeedsRehash(hashedPassword string) bool {
+	if !strings.HasPrefix(hashedPassword, "$argon2id$") {
+		return true
+	}
+
+	params, _, _, err := decodeArgon2Hash(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return params != passwordSettings.argon2
+}
+
+// decodeArgon2Hash parses an argon2id PHC string
+func decodeArgon2Hash(encoded string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errors.New("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, err
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return params, nil, nil, err
+	}
+	if err := params.validate(); err != nil {
+		return params, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, err
+	}
+	if len(salt) < argon2MinSaltLength {
+		return params, nil, nil, errors.New("argon2id salt too short")
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, err
+	}
+	if len(hash) < argon2MinKeyLength || len(hash) > argon2MaxKeyLength {
+		return params, nil, nil, errors.New("argon2id hash length out of range")
+	}
+
+	return params, salt, hash, nil
+}
@@ -0,0 +1,213 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nabil/book-store-system/config"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastArgon2 keeps the tests quick; the format does not depend on the cost
+var fastArgon2 = argon2Params{memory: 64, iterations: 1, parallelism: 1}
+
+// usePasswordSettings replaces the password settings for the duration of a test
+func usePasswordSettings(t *testing.T, params argon2Params) {
+	t.Helper()
+	saved := passwordSettings
+	passwordSettings.argon2 = params
+	t.Cleanup(func() { passwordSettings = saved })
+}
+
+// encodeArgon2 builds a PHC string independently of HashPassword
+func encodeArgon2(password string, salt []byte, params argon2Params) string {
+	hash := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=19$m=%d,t=%d,p=%d$%s$%s",
+		params.memory, params.iterations, params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+}
+
+func TestHashPasswordArgon2id(t *testing.T) {
+	usePasswordSettings(t, fastArgon2)
+
+	hashed, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if !CheckPassword("correct horse", hashed) {
+		t.Error("CheckPassword rejected the correct password")
+	}
+	if CheckPassword("wrong horse", hashed) {
+		t.Error("CheckPassword accepted a wrong password")
+	}
+	if PasswordNeedsRehash(hashed) {
+		t.Error("PasswordNeedsRehash reported a fresh hash as outdated")
+	}
+
+	again, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	if again == hashed {
+		t.Error("HashPassword returned the same hash twice, want a random salt")
+	}
+}
+
+func TestCheckPasswordPHCString(t *testing.T) {
+	usePasswordSettings(t, fastArgon2)
+
+	hashed := encodeArgon2("s3cret", []byte("0123456789abcdef"), fastArgon2)
+	if !CheckPassword("s3cret", hashed) {
+		t.Errorf("CheckPassword rejected %s", hashed)
+	}
+	if CheckPassword("S3cret", hashed) {
+		t.Error("CheckPassword accepted a wrong password")
+	}
+	if PasswordNeedsRehash(hashed) {
+		t.Error("PasswordNeedsRehash reported a hash with the current parameters as outdated")
+	}
+}
+
+func TestLegacyBcryptHashNeedsRehash(t *testing.T) {
+	usePasswordSettings(t, fastArgon2)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("legacy password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt: %v", err)
+	}
+
+	if !CheckPassword("legacy password", string(hashed)) {
+		t.Error("CheckPassword rejected a valid bcrypt hash")
+	}
+	if CheckPassword("other password", string(hashed)) {
+		t.Error("CheckPassword accepted a wrong password for a bcrypt hash")
+	}
+	if !PasswordNeedsRehash(string(hashed)) {
+		t.Error("PasswordNeedsRehash did not flag a bcrypt hash")
+	}
+}
+
+func TestChangedParametersNeedRehash(t *testing.T) {
+	usePasswordSettings(t, fastArgon2)
+
+	hashed, err := HashPassword("password123")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		params argon2Params
+	}{
+		{"memory", argon2Params{memory: 128, iterations: 1, parallelism: 1}},
+		{"iterations", argon2Params{memory: 64, iterations: 2, parallelism: 1}},
+		{"parallelism", argon2Params{memory: 64, iterations: 1, parallelism: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passwordSettings.argon2 = tt.params
+			if !PasswordNeedsRehash(hashed) {
+				t.Error("PasswordNeedsRehash did not flag a hash with outdated parameters")
+			}
+			// The old hash still verifies until it is replaced
+			if !CheckPassword("password123", hashed) {
+				t.Error("CheckPassword rejected a hash with outdated parameters")
+			}
+		})
+	}
+}
+
+func TestMalformedPHCStrings(t *testing.T) {
+	usePasswordSettings(t, fastArgon2)
+
+	salt := base64.RawStdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	hash := base64.RawStdEncoding.EncodeToString(make([]byte, 32))
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"empty", ""},
+		{"prefix only", "$argon2id$"},
+		{"missing fields", "$argon2id$v=19$m=64,t=1,p=1$" + salt},
+		{"extra field", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$" + hash + "$x"},
+		{"argon2i", "$argon2i$v=19$m=64,t=1,p=1$" + salt + "$" + hash},
+		{"wrong version", "$argon2id$v=16$m=64,t=1,p=1$" + salt + "$" + hash},
+		{"garbage version", "$argon2id$v=x$m=64,t=1,p=1$" + salt + "$" + hash},
+		{"garbage parameters", "$argon2id$v=19$m=x,t=1,p=1$" + salt + "$" + hash},
+		{"zero iterations", "$argon2id$v=19$m=64,t=0,p=1$" + salt + "$" + hash},
+		{"zero parallelism", "$argon2id$v=19$m=64,t=1,p=0$" + salt + "$" + hash},
+		{"parallelism overflow", "$argon2id$v=19$m=64,t=1,p=300$" + salt + "$" + hash},
+		{"memory too low", "$argon2id$v=19$m=1,t=1,p=1$" + salt + "$" + hash},
+		{"memory too high", "$argon2id$v=19$m=4294967295,t=1,p=1$" + salt + "$" + hash},
+		{"bad salt encoding", "$argon2id$v=19$m=64,t=1,p=1$!!!$" + hash},
+		{"short salt", "$argon2id$v=19$m=64,t=1,p=1$c2FsdA$" + hash},
+		{"bad hash encoding", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$!!!"},
+		{"empty hash", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$"},
+		{"not a hash", "password"},
+		{"truncated bcrypt", "$2a$10$abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if CheckPassword("", tt.encoded) || CheckPassword("password", tt.encoded) {
+				t.Errorf("CheckPassword accepted malformed hash %q", tt.encoded)
+			}
+			if !PasswordNeedsRehash(tt.encoded) {
+				t.Errorf("PasswordNeedsRehash(%q) = false, want true", tt.encoded)
+			}
+		})
+	}
+}
+
+func TestInitPasswords(t *testing.T) {
+	saved := passwordSettings
+	t.Cleanup(func() { passwordSettings = saved })
+
+	blocklist := filepath.Join(t.TempDir(), "blocklist.txt")
+	if err := os.WriteFile(blocklist, []byte("# breached\nPassword123\n\nqwertyuiop\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Password: config.PasswordConfig{
+		Argon2MemoryKiB:   64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		MinLength:         10,
+		MaxLength:         20,
+		BlocklistFile:     blocklist,
+	}}
+	if err := InitPasswords(cfg); err != nil {
+		t.Fatalf("InitPasswords: %v", err)
+	}
+
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{"short", false},
+		{"long enough pw", true},
+		{"much too long for the policy", false},
+		{"PASSWORD123", false},
+		{"qwertyuiop", false},
+	}
+	for _, tt := range tests {
+		err := ValidatePasswordPolicy(tt.password)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidatePasswordPolicy(%q) = %v, want valid %v", tt.password, err, tt.valid)
+		}
+		if err != nil && !errors.Is(err, ErrPasswordPolicy) {
+			t.Errorf("ValidatePasswordPolicy(%q) = %v, want ErrPasswordPolicy", tt.password, err)
+		}
+	}
+
+	cfg.Password.Argon2Parallelism = 0
+	if err := InitPasswords(cfg); err == nil {
+		t.Error("InitPasswords accepted a parallelism of 0")
+	}
+}
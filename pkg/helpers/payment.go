@@ -12,8 +12,7 @@ import (
 	"github.com/sony/gobreaker"
 )
 
-func InitMidtrans() {
-	cfg := config.LoadConfig()
+func InitMidtrans(cfg *config.Config) {
 	midtrans.ServerKey = cfg.MidtransKey
 	midtrans.Environment = midtrans.Sandbox
 
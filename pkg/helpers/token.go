@@ -1,12 +1,16 @@
 package helpers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/nabil/book-store-system/config"
 )
 
 // JWTClaims represents the claims in a JWT token
@@ -14,26 +18,101 @@ type JWTClaims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// TokenVersion must match entity.User.TokenVersion for the token to be accepted
+	TokenVersion uint `json:"token_version"`
+	// Scope restricts what the token may be used for; empty for regular access tokens
+	Scope string `json:"scope,omitempty"`
+	// SessionID is the entity.Session of a regular access token
+	SessionID uint `json:"sid,omitempty"`
+	// Actor is set on tokens issued by ImpersonateUser and identifies the staff
+	// member acting as the user (RFC 8693 "act" claim)
+	Actor *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken generates a new JWT token for a user
-func GenerateToken(userID uint, email, role string) (string, error) {
-	cfg := config.LoadConfig()
-
-	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * time.Duration(cfg.JWTExpiry))),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-		},
+// ActorClaim identifies the staff member behind an impersonation token
+type ActorClaim struct {
+	Subject string `json:"sub"`
+	UserID  uint   `json:"user_id"`
+	Email   string `json:"email"`
+	// TokenVersion must match the actor's entity.User.TokenVersion, so the token
+	// dies when the actor logs out everywhere or loses their role
+	TokenVersion uint `json:"token_version"`
+}
+
+// Token scopes
+const (
+	// ScopeMFAChallenge tokens are only accepted by VerifyMFA after a password login
+	ScopeMFAChallenge = "mfa_challenge"
+	// ScopeMFAEnrollment tokens are only accepted by the MFA enrollment RPCs
+	ScopeMFAEnrollment = "mfa_enrollment"
+	// ScopeEmailVerification tokens are only accepted by VerifyEmail
+	ScopeEmailVerification = "email_verification"
+)
+
+// GenerateToken generates a new short-lived JWT access token for a user's session.
+// Every token gets a unique ID (jti) so it can be revoked individually, and is
+// signed with the current signing key whose kid is put in the header.
+func GenerateToken(userID uint, email, role string, tokenVersion, sessionID uint) (string, error) {
+	return signToken(JWTClaims{
+		UserID:       userID,
+		Email:        email,
+		Role:         role,
+		TokenVersion: tokenVersion,
+		SessionID:    sessionID,
+	}, AccessTokenTTL())
+}
+
+// GenerateScopedToken generates a JWT restricted to scope that expires after ttl
+func GenerateScopedToken(userID uint, email, role string, tokenVersion uint, scope string, ttl time.Duration) (string, error) {
+	return signToken(JWTClaims{
+		UserID:       userID,
+		Email:        email,
+		Role:         role,
+		TokenVersion: tokenVersion,
+		Scope:        scope,
+	}, ttl)
+}
+
+// GenerateImpersonationToken generates an access token for a user that
+// records actor as the one really making the calls. It expires after ttl and
+// belongs to no session.
+func GenerateImpersonationToken(userID uint, email, role string, tokenVersion uint, actor ActorClaim, ttl time.Duration) (string, error) {
+	actor.Subject = strconv.FormatUint(uint64(actor.UserID), 10)
+	return signToken(JWTClaims{
+		UserID:       userID,
+		Email:        email,
+		Role:         role,
+		TokenVersion: tokenVersion,
+		Actor:        &actor,
+	}, ttl)
+}
+
+// signToken fills in the registered claims and signs the token
+func signToken(claims JWTClaims, ttl time.Duration) (string, error) {
+	signingKey := tokenSettings.signingKey
+	if signingKey == nil {
+		return "", errors.New("token signing key is not initialized")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(cfg.JWTSecret))
+	jti, err := GenerateRandomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        jti,
+		Issuer:    tokenSettings.issuer,
+		Subject:   strconv.FormatUint(uint64(claims.UserID), 10),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+
+	token := jwt.NewWithClaims(signingKey.method, claims)
+	token.Header["kid"] = signingKey.kid
+	tokenString, err := token.SignedString(signingKey.private)
 	if err != nil {
 		return "", err
 	}
@@ -41,16 +120,23 @@ func GenerateToken(userID uint, email, role string) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
+// ValidateToken validates a JWT token against the verification key named by
+// its kid header and returns the claims
 func ValidateToken(tokenString string) (*JWTClaims, error) {
-	cfg := config.LoadConfig()
-
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := tokenSettings.verificationKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(cfg.JWTSecret), nil
-	})
+		return key.public, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(tokenSettings.issuer),
+	)
 
 	if err != nil {
 		return nil, err
@@ -62,4 +148,58 @@ func ValidateToken(tokenString string) (*JWTClaims, error) {
 	}
 
 	return claims, nil
-}
\ No newline at end of file
+}
+
+// AccessTokenTTL returns how long an access token stays valid
+func AccessTokenTTL() time.Duration {
+	return tokenSettings.accessTokenTTL
+}
+
+// RefreshTokenTTL returns how long a refresh token stays valid
+func RefreshTokenTTL() time.Duration {
+	return tokenSettings.refreshTokenTTL
+}
+
+// GenerateRefreshToken generates an opaque random refresh token
+func GenerateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// APIKeyPrefix marks API keys so they are easy to recognize, e.g. by secret scanners
+const APIKeyPrefix = "bsk_"
+
+// GenerateAPIKey generates a new API key of the form bsk_<id>_<secret> and
+// returns it together with its public prefix bsk_<id>
+func GenerateAPIKey() (key, prefix string, err error) {
+	id, err := GenerateRandomString(6)
+	if err != nil {
+		return "", "", err
+	}
+	secret, err := GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix = APIKeyPrefix + id
+	return prefix + "_" + secret, prefix, nil
+}
+
+// GenerateRandomString returns a hex encoded random string of n bytes
+func GenerateRandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken returns the SHA-256 hex digest of an opaque token, used so that
+// tokens are never stored in plain text
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,143 @@
+package helpers
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 key of the RFC 6238 test vectors, base32 encoded
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestValidateTOTPRFC6238Vectors(t *testing.T) {
+	// The RFC lists 8 digit codes; the last 6 digits are the 6 digit codes
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		step, ok := ValidateTOTP(rfc6238Secret, tt.code, time.Unix(tt.unix, 0), 0)
+		if !ok {
+			t.Errorf("ValidateTOTP(%q) at %d rejected a valid code", tt.code, tt.unix)
+			continue
+		}
+		if want := tt.unix / totpPeriod; step != want {
+			t.Errorf("ValidateTOTP(%q) at %d matched step %d, want %d", tt.code, tt.unix, step, want)
+		}
+	}
+}
+
+func TestValidateTOTPSkewWindow(t *testing.T) {
+	key := []byte("12345678901234567890")
+	now := time.Unix(1700000000, 0)
+	current := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name   string
+		offset int64
+		valid  bool
+	}{
+		{"two steps behind", -2, false},
+		{"one step behind", -1, true},
+		{"current step", 0, true},
+		{"one step ahead", 1, true},
+		{"two steps ahead", 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := hotp(key, uint64(current+tt.offset))
+			step, ok := ValidateTOTP(rfc6238Secret, code, now, 0)
+			if ok != tt.valid {
+				t.Fatalf("ValidateTOTP() ok = %v, want %v", ok, tt.valid)
+			}
+			if ok && step != current+tt.offset {
+				t.Errorf("ValidateTOTP() step = %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPRejectsReplay(t *testing.T) {
+	key := []byte("12345678901234567890")
+	now := time.Unix(1700000000, 0)
+	current := now.Unix() / totpPeriod
+	code := hotp(key, uint64(current))
+
+	step, ok := ValidateTOTP(rfc6238Secret, code, now, 0)
+	if !ok {
+		t.Fatal("ValidateTOTP() rejected a fresh code")
+	}
+
+	// The same code, within its validity window
+	if _, ok := ValidateTOTP(rfc6238Secret, code, now.Add(20*time.Second), step); ok {
+		t.Error("ValidateTOTP() accepted a code that was already used")
+	}
+
+	// An older code is rejected once a later step was used
+	previous := hotp(key, uint64(current-1))
+	if _, ok := ValidateTOTP(rfc6238Secret, previous, now, step); ok {
+		t.Error("ValidateTOTP() accepted a code older than the last used one")
+	}
+
+	// The next code is still accepted
+	next := hotp(key, uint64(current+1))
+	if _, ok := ValidateTOTP(rfc6238Secret, next, now.Add(totpPeriod*time.Second), step); !ok {
+		t.Error("ValidateTOTP() rejected the code of the next step")
+	}
+}
+
+func TestValidateTOTPMalformedInput(t *testing.T) {
+	now := time.Unix(59, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+	}{
+		{"too short", rfc6238Secret, "28708"},
+		{"too long", rfc6238Secret, "2870820"},
+		{"not base32", "not base32!", "287082"},
+		{"empty", rfc6238Secret, ""},
+	}
+	for _, tt := range tests {
+		if _, ok := ValidateTOTP(tt.secret, tt.code, now, 0); ok {
+			t.Errorf("%s: ValidateTOTP(%q, %q) accepted invalid input", tt.name, tt.secret, tt.code)
+		}
+	}
+
+	// Surrounding whitespace and a lower case secret are tolerated
+	if _, ok := ValidateTOTP(strings.ToLower(rfc6238Secret), " 287082 ", now, 0); !ok {
+		t.Error("ValidateTOTP() rejected a padded code with a lower case secret")
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes: %v", err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("GenerateRecoveryCodes(10) returned %d codes", len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("recovery code %q is not formatted as xxxxx-xxxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("recovery code %q generated twice", code)
+		}
+		seen[code] = true
+
+		if got := NormalizeRecoveryCode(" " + strings.ToUpper(code) + " "); got != strings.ReplaceAll(code, "-", "") {
+			t.Errorf("NormalizeRecoveryCode(%q) = %q", code, got)
+		}
+	}
+}
@@ -22,6 +22,12 @@ func ValidateStruct(s interface{}) error {
 	return nil
 }
 
+// NormalizeEmail trims and lowercases an email address, so the same address
+// is stored and looked up the same way however it was typed
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // getErrorMessage returns a user-friendly error message based on the validation tag
 func getErrorMessage(fe validator.FieldError) string {
 	field := strings.ToLower(fe.Field())
@@ -40,6 +46,12 @@ func getErrorMessage(fe validator.FieldError) string {
 			return fmt.Sprintf("%s cannot exceed %s characters", field, fe.Param())
 		}
 		return fmt.Sprintf("%s cannot exceed %s", field, fe.Param())
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s characters long", field, fe.Param())
+	case "numeric":
+		return fmt.Sprintf("%s must contain only digits", field)
+	case "nefield":
+		return fmt.Sprintf("%s must be different from %s", field, strings.ToLower(fe.Param()))
 	case "oneof":
 		return fmt.Sprintf("%s must be one of: %s", field, fe.Param())
 	case "dive":
@@ -47,4 +59,4 @@ func getErrorMessage(fe validator.FieldError) string {
 	default:
 		return fmt.Sprintf("%s is invalid", field)
 	}
-}
\ No newline at end of file
+}
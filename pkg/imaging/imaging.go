@@ -0,0 +1,132 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Quality of the JPEGs written by Normalize and Thumbnail
+const (
+	normalizedQuality = 90
+	thumbnailQuality  = 85
+)
+
+// ContentType is the MIME type of every image returned by Normalize and Thumbnail
+const ContentType = "image/jpeg"
+
+// Errors returned by Normalize, wrapped with the reason the image was rejected
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrImageTooLarge     = errors.New("image too large")
+	ErrMalformedImage    = errors.New("malformed image")
+)
+
+// acceptedTypes are the MIME types Normalize accepts
+var acceptedTypes = []string{"image/jpeg", "image/png", "image/webp"}
+
+// Limits bounds the images accepted by Normalize. Zero values disable a limit.
+type Limits struct {
+	MaxBytes int
+	// MaxDimension is the maximum width and height in pixels
+	MaxDimension int
+}
+
+// Normalize checks that data is a JPEG, PNG or WebP image within limits and
+// re-encodes it as a JPEG, so that stored images never carry metadata such as
+// EXIF location data. The EXIF orientation of JPEGs is applied to the pixels
+// before it is dropped. Transparent areas are filled with white.
+func Normalize(data []byte, limits Limits) (image.Image, []byte, error) {
+	if limits.MaxBytes > 0 && len(data) > limits.MaxBytes {
+		return nil, nil, fmt.Errorf("%w: %d bytes, the maximum is %d", ErrImageTooLarge, len(data), limits.MaxBytes)
+	}
+
+	detected := mimetype.Detect(data)
+	if !mimetype.EqualsAny(detected.String(), acceptedTypes...) {
+		return nil, nil, fmt.Errorf("%w: detected %s, expected JPEG, PNG or WebP", ErrUnsupportedFormat, detected.String())
+	}
+
+	// Check the dimensions before decoding so small files describing huge
+	// images are rejected without allocating their pixels
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrMalformedImage, err)
+	}
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, nil, fmt.Errorf("%w: image is empty", ErrMalformedImage)
+	}
+	if limits.MaxDimension > 0 && (cfg.Width > limits.MaxDimension || cfg.Height > limits.MaxDimension) {
+		return nil, nil, fmt.Errorf("%w: %dx%d pixels, the maximum is %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height, limits.MaxDimension, limits.MaxDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrMalformedImage, err)
+	}
+	if detected.Is("image/jpeg") {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	bounds := img.Bounds()
+	normalized, err := encodeJPEG(img, bounds.Dx(), bounds.Dy(), normalizedQuality)
+	if err != nil {
+		return nil, nil, err
+	}
+	return img, normalized, nil
+}
+
+// Thumbnail scales img down to width pixels wide, keeping its aspect ratio,
+// and encodes it as JPEG. Images narrower than width are not enlarged.
+// Transparent areas are filled with white.
+func Thumbnail(img image.Image, width int) ([]byte, error) {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, errors.New("image is empty")
+	}
+
+	w, h := bounds.Dx(), bounds.Dy()
+	if width > 0 && w > width {
+		h = max(1, h*width/w)
+		w = width
+	}
+	return encodeJPEG(img, w, h, thumbnailQuality)
+}
+
+// encodeJPEG scales img to w x h on a white background and encodes it as JPEG
+func encodeJPEG(img image.Image, w, h, quality int) ([]byte, error) {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	if bounds := img.Bounds(); bounds.Dx() == w && bounds.Dy() == h {
+		draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeBase64 decodes a base64 encoded image, which may also be given as a
+// data URL such as data:image/png;base64,...
+func DecodeBase64(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "data:") {
+		if i := strings.Index(s, ","); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,224 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// halves returns a w x h image whose left half is left and right half is right
+func halves(w, h int, left, right color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, left)
+			} else {
+				img.Set(x, y, right)
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeTestJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatalf("encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGSize rewrites the dimensions in the IHDR chunk of a PNG, keeping its
+// checksum valid, so that a tiny file claims to be a huge image
+func withPNGSize(data []byte, w, h uint32) []byte {
+	patched := append([]byte(nil), data...)
+	// Signature (8), length (4), "IHDR" (4), then width and height
+	ihdr := patched[12 : 12+4+13]
+	binary.BigEndian.PutUint32(ihdr[4:], w)
+	binary.BigEndian.PutUint32(ihdr[8:], h)
+	binary.BigEndian.PutUint32(patched[12+4+13:], crc32.ChecksumIEEE(ihdr))
+	return patched
+}
+
+// isReddish reports whether c is clearly more red than blue
+func isReddish(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > 0xC000 && b < 0x4000
+}
+
+func TestNormalizeLimits(t *testing.T) {
+	small := encodePNG(t, halves(40, 20, color.White, color.Black))
+	var gifData bytes.Buffer
+	if err := gif.Encode(&gifData, halves(4, 4, color.White, color.Black), nil); err != nil {
+		t.Fatalf("encode GIF: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		limits Limits
+		err    error
+	}{
+		{name: "within limits", data: small, limits: Limits{MaxBytes: len(small), MaxDimension: 40}},
+		{name: "no limits", data: small},
+		{name: "too many bytes", data: small, limits: Limits{MaxBytes: len(small) - 1}, err: ErrImageTooLarge},
+		{name: "too wide", data: small, limits: Limits{MaxDimension: 39}, err: ErrImageTooLarge},
+		{name: "too tall", data: encodePNG(t, halves(20, 40, color.White, color.Black)), limits: Limits{MaxDimension: 39}, err: ErrImageTooLarge},
+		{name: "small file of a huge image", data: withPNGSize(small, 100000, 100000), limits: Limits{MaxBytes: 1 << 20, MaxDimension: 4000}, err: ErrImageTooLarge},
+		{name: "GIF", data: gifData.Bytes(), err: ErrUnsupportedFormat},
+		{name: "not an image", data: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), err: ErrUnsupportedFormat},
+		{name: "truncated", data: small[:len(small)/2], err: ErrMalformedImage},
+		{name: "empty image", data: withPNGSize(small, 0, 20), err: ErrMalformedImage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Normalize(tt.data, tt.limits)
+			if tt.err == nil && err != nil {
+				t.Fatalf("Normalize: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("Normalize() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNormalizeReencodesAsJPEG(t *testing.T) {
+	// A PNG with a transparent right half
+	img := halves(32, 16, color.RGBA{R: 255, A: 255}, color.Transparent)
+
+	decoded, normalized, err := Normalize(encodePNG(t, img), Limits{})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if decoded.Bounds().Dx() != 32 || decoded.Bounds().Dy() != 16 {
+		t.Errorf("decoded image is %v, want 32x16", decoded.Bounds())
+	}
+
+	out, err := jpeg.Decode(bytes.NewReader(normalized))
+	if err != nil {
+		t.Fatalf("normalized image is not a JPEG: %v", err)
+	}
+	if !isReddish(out.At(4, 8)) {
+		t.Errorf("left half is %v, want red", out.At(4, 8))
+	}
+	if r, g, b, _ := out.At(28, 8).RGBA(); r < 0xF000 || g < 0xF000 || b < 0xF000 {
+		t.Errorf("transparent half is %v, want white", out.At(28, 8))
+	}
+}
+
+func TestNormalizeDropsEXIF(t *testing.T) {
+	data := encodeTestJPEG(t, halves(32, 16, color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}))
+	tagged := withEXIF(data, binary.BigEndian, 1)
+
+	_, normalized, err := Normalize(tagged, Limits{})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if bytes.Contains(normalized, []byte("Exif\x00\x00")) {
+		t.Error("normalized image still carries EXIF data")
+	}
+}
+
+func TestNormalizeAppliesOrientation(t *testing.T) {
+	// Red on the left, blue on the right, as stored by a camera held sideways
+	data := encodeTestJPEG(t, halves(32, 16, color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}))
+
+	decoded, normalized, err := Normalize(withEXIF(data, binary.LittleEndian, 6), Limits{})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if decoded.Bounds().Dx() != 16 || decoded.Bounds().Dy() != 32 {
+		t.Fatalf("rotated image is %v, want 16x32", decoded.Bounds())
+	}
+	out, err := jpeg.Decode(bytes.NewReader(normalized))
+	if err != nil {
+		t.Fatalf("normalized image is not a JPEG: %v", err)
+	}
+	// Rotating 90° clockwise brings the left half to the top
+	if !isReddish(out.At(8, 4)) || isReddish(out.At(8, 28)) {
+		t.Errorf("top is %v and bottom is %v, want red on top", out.At(8, 4), out.At(8, 28))
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h, width  int
+		wantW, wantH int
+	}{
+		{name: "scaled down", w: 400, h: 200, width: 100, wantW: 100, wantH: 50},
+		{name: "not enlarged", w: 60, h: 90, width: 100, wantW: 60, wantH: 90},
+		{name: "no width", w: 60, h: 90, width: 0, wantW: 60, wantH: 90},
+		{name: "keeps at least one row", w: 1000, h: 1, width: 10, wantW: 10, wantH: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Thumbnail(halves(tt.w, tt.h, color.White, color.Black), tt.width)
+			if err != nil {
+				t.Fatalf("Thumbnail: %v", err)
+			}
+			cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("thumbnail is not a JPEG: %v", err)
+			}
+			if cfg.Width != tt.wantW || cfg.Height != tt.wantH {
+				t.Errorf("thumbnail is %dx%d, want %dx%d", cfg.Width, cfg.Height, tt.wantW, tt.wantH)
+			}
+		})
+	}
+
+	if _, err := Thumbnail(image.NewRGBA(image.Rect(0, 0, 0, 0)), 100); err == nil {
+		t.Error("Thumbnail of an empty image succeeded")
+	}
+}
+
+func TestDecodeBase64(t *testing.T) {
+	want := []byte("\x89PNG\r\n\x1a\n")
+	padded := base64.StdEncoding.EncodeToString(want)
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "padded", in: padded},
+		{name: "unpadded", in: base64.RawStdEncoding.EncodeToString(want)},
+		{name: "data URL", in: "data:image/png;base64," + padded},
+		{name: "surrounding whitespace", in: "\n " + padded + " \n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeBase64(tt.in)
+			if err != nil {
+				t.Fatalf("DecodeBase64: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("DecodeBase64() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	if _, err := DecodeBase64("not base64!"); err == nil {
+		t.Error("DecodeBase64 accepted invalid input")
+	}
+}
@@ -0,0 +1,98 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// withEXIF inserts an APP1 Exif segment holding orientation right after the
+// start of image marker of a JPEG
+func withEXIF(data []byte, order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	entry := tiff[10:]
+	order.PutUint16(entry, exifOrientationTag)
+	order.PutUint16(entry[2:], 3) // SHORT
+	order.PutUint32(entry[4:], 1)
+	order.PutUint16(entry[8:], orientation)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	tagged := append([]byte(nil), data[:2]...)
+	tagged = append(tagged, segment...)
+	return append(tagged, data[2:]...)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	jpeg := encodeTestJPEG(t, image.NewRGBA(image.Rect(0, 0, 8, 8)))
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "big endian", data: withEXIF(jpeg, binary.BigEndian, 6), want: 6},
+		{name: "little endian", data: withEXIF(jpeg, binary.LittleEndian, 8), want: 8},
+		{name: "no EXIF", data: jpeg, want: 1},
+		{name: "out of range", data: withEXIF(jpeg, binary.BigEndian, 9), want: 1},
+		{name: "truncated segment", data: withEXIF(jpeg, binary.BigEndian, 6)[:20], want: 1},
+		{name: "not a JPEG", data: []byte("\x89PNG\r\n\x1a\n"), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.want {
+				t.Errorf("jpegOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// A 3x2 image whose pixels are numbered in reading order:
+	//   0 1 2
+	//   3 4 5
+	src := image.NewGray(image.Rect(0, 0, 3, 2))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i)
+	}
+
+	tests := []struct {
+		orientation int
+		want        [][]uint8
+	}{
+		{1, [][]uint8{{0, 1, 2}, {3, 4, 5}}},
+		{2, [][]uint8{{2, 1, 0}, {5, 4, 3}}},
+		{3, [][]uint8{{5, 4, 3}, {2, 1, 0}}},
+		{4, [][]uint8{{3, 4, 5}, {0, 1, 2}}},
+		{5, [][]uint8{{0, 3}, {1, 4}, {2, 5}}},
+		{6, [][]uint8{{3, 0}, {4, 1}, {5, 2}}},
+		{7, [][]uint8{{5, 2}, {4, 1}, {3, 0}}},
+		{8, [][]uint8{{2, 5}, {1, 4}, {0, 3}}},
+	}
+	for _, tt := range tests {
+		out := applyOrientation(src, tt.orientation)
+		if out.Bounds().Dy() != len(tt.want) || out.Bounds().Dx() != len(tt.want[0]) {
+			t.Errorf("orientation %d: image is %v, want %dx%d", tt.orientation, out.Bounds(), len(tt.want[0]), len(tt.want))
+			continue
+		}
+		for y, row := range tt.want {
+			for x, want := range row {
+				if got := color.GrayModel.Convert(out.At(x, y)).(color.Gray).Y; got != want {
+					t.Errorf("orientation %d: pixel (%d, %d) = %d, want %d", tt.orientation, x, y, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogMailer writes emails to a file or stdout instead of sending them. It is
+// meant for development and tests.
+type LogMailer struct {
+	mu   sync.Mutex
+	from string
+	out  io.Writer
+}
+
+// NewLogMailer creates a mailer appending to path, or writing to stdout when path is empty
+func NewLogMailer(from, path string) (*LogMailer, error) {
+	if path == "" {
+		return &LogMailer{from: from, out: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mail log file: %w", err)
+	}
+	return &LogMailer{from: from, out: file}, nil
+}
+
+// Send writes msg followed by a separator line
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := fmt.Fprintf(m.out, "From: %s\nTo: %s\nSubject: %s\nDate: %s\n\n%s\n%s\n",
+		m.from, msg.To, msg.Subject, time.Now().Format(time.RFC1123Z), msg.Body, strings.Repeat("-", 72))
+	return err
+}
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"errors"
+	"time"
+
 	"github.com/nabil/book-store-system/internal/entity"
 	"github.com/nabil/book-store-system/internal/repository"
 	"github.com/nabil/book-store-system/pkg/helpers"
@@ -9,56 +11,167 @@ import (
 
 // AuthMiddleware handles authentication and authorization
 type AuthMiddleware struct {
-	userRepo repository.UserRepository
+	userRepo   repository.UserRepository
+	tokenRepo  repository.TokenRepository
+	roleRepo   repository.RoleRepository
+	apiKeyRepo repository.APIKeyRepository
+	userCache  *UserCache
+	limiter    *rateLimiter
 }
 
-// NewAuthMiddleware creates a new auth middleware instance
-func NewAuthMiddleware(userRepo repository.UserRepository) *AuthMiddleware {
+// NewAuthMiddleware creates a new auth middleware instance. Resolved users are
+// kept in userCache, which may be nil to always load them from the database.
+func NewAuthMiddleware(userRepo repository.UserRepository, tokenRepo repository.TokenRepository, roleRepo repository.RoleRepository, apiKeyRepo repository.APIKeyRepository, userCache *UserCache) *AuthMiddleware {
 	return &AuthMiddleware{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		roleRepo:   roleRepo,
+		apiKeyRepo: apiKeyRepo,
+		userCache:  userCache,
+		limiter:    newRateLimiter(),
 	}
 }
 
-// ValidateUserToken validates if token belongs to a valid user
-func (m *AuthMiddleware) ValidateUserToken(token string) (*entity.User, error) {
-	// Validate token
-	claims, err := helpers.ValidateToken(token)
-	if err != nil {
-		return nil, err
+// loadUser gets a user from the cache, falling back to the database
+func (m *AuthMiddleware) loadUser(id uint) (*entity.User, error) {
+	if user, ok := m.userCache.Get(id); ok {
+		return user, nil
 	}
 
-	// Get user by ID
-	user, err := m.userRepo.GetByID(claims.UserID)
+	version := m.userCache.Version()
+	user, err := m.userRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	m.userCache.Set(user, version)
 	return user, nil
 }
 
-// ValidateAdminToken validates if token belongs to admin user
-func (m *AuthMiddleware) ValidateAdminToken(token string) (*entity.User, error) {
+// Authenticate validates a token and returns both the user and the token claims
+func (m *AuthMiddleware) Authenticate(token string) (*entity.User, *helpers.JWTClaims, error) {
 	// Validate token
 	claims, err := helpers.ValidateToken(token)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Reject tokens that were explicitly revoked (logout)
+	revoked, err := m.tokenRepo.IsAccessTokenRevoked(claims.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if revoked {
+		return nil, nil, errors.New("token has been revoked")
 	}
 
 	// Get user by ID
-	user, err := m.userRepo.GetByID(claims.UserID)
+	user, err := m.loadUser(claims.UserID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Check if user is admin
-	if user.Role != "admin" {
-		return nil, errors.New("access denied: admin role required")
+	// Disabled accounts lose access immediately, whatever token they hold
+	if user.IsDisabled() {
+		return nil, nil, errors.New("account is disabled")
 	}
 
-	return user, nil
+	// Tokens issued before the last password change or logout from all devices are no longer valid
+	if claims.TokenVersion != user.TokenVersion {
+		return nil, nil, errors.New("token has been revoked")
+	}
+
+	// Tokens of a session the user signed out of remotely are no longer valid
+	if claims.SessionID != 0 {
+		if err := m.checkSession(claims); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Impersonation tokens stop working as soon as the actor loses access
+	if claims.Actor != nil {
+		if err := m.checkActor(claims.Actor); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return user, claims, nil
+}
+
+// checkActor verifies that the staff member behind an impersonation token is
+// still active and still allowed to impersonate users
+func (m *AuthMiddleware) checkActor(actor *helpers.ActorClaim) error {
+	user, err := m.loadUser(actor.UserID)
+	if err != nil {
+		return errors.New("impersonating user not found")
+	}
+	if user.IsDisabled() || user.TokenVersion != actor.TokenVersion {
+		return errors.New("token has been revoked")
+	}
+
+	permissions, err := m.roleRepo.GetPermissionNames(user.Role)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if permission == entity.PermissionUsersImpersonate {
+			return nil
+		}
+	}
+	return errors.New("impersonating user is no longer allowed to impersonate")
+}
+
+// checkSession verifies that the session of a token is still active and
+// records that it was used
+func (m *AuthMiddleware) checkSession(claims *helpers.JWTClaims) error {
+	session, err := m.tokenRepo.GetSession(claims.SessionID)
+	if err != nil || session.UserID != claims.UserID {
+		return errors.New("session not found")
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		return errors.New("session has been revoked")
+	}
+
+	// Only write the last seen time once per minute, like API keys
+	if now.Sub(session.LastSeenAt) >= time.Minute {
+		_ = m.tokenRepo.TouchSession(session.ID, now)
+	}
+	return nil
 }
 
-// ValidateToken validates JWT token and returns user info (general purpose)
-func (m *AuthMiddleware) ValidateToken(token string) (*entity.User, error) {
-	return m.ValidateUserToken(token)
-}
\ No newline at end of file
+// AuthenticateAPIKey validates an API key and returns it together with the
+// user it acts on behalf of
+func (m *AuthMiddleware) AuthenticateAPIKey(key string) (*entity.User, *entity.APIKey, error) {
+	apiKey, err := m.apiKeyRepo.GetByHash(helpers.HashToken(key))
+	if err != nil {
+		return nil, nil, errors.New("unknown API key")
+	}
+
+	now := time.Now()
+	if apiKey.IsRevoked() {
+		return nil, nil, errors.New("API key has been revoked")
+	}
+	if apiKey.IsExpired(now) {
+		return nil, nil, errors.New("API key has expired")
+	}
+
+	// A key stops working together with the account that created it
+	user, err := m.loadUser(apiKey.CreatedByID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.IsDisabled() {
+		return nil, nil, errors.New("account is disabled")
+	}
+
+	// Only write the last used time once per minute to keep busy keys cheap
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= time.Minute {
+		if err := m.apiKeyRepo.TouchLastUsed(apiKey.ID, now); err == nil {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return user, apiKey, nil
+}
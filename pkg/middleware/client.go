@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// maxClientInfoLength caps client supplied values such as the user agent
+const maxClientInfoLength = 255
+
+// ClientIP returns the IP address of the gRPC peer, or an empty string if unknown
+func ClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// UserAgent returns the user-agent sent by the gRPC client
+func UserAgent(ctx context.Context) string {
+	return incomingValue(ctx, "user-agent")
+}
+
+// DeviceName returns the optional x-device-name metadata, a name for the device
+// chosen by the client app (e.g. "Pixel 8")
+func DeviceName(ctx context.Context) string {
+	return incomingValue(ctx, "x-device-name")
+}
+
+// incomingValue returns the first value of an incoming metadata key, truncated
+// to maxClientInfoLength bytes
+func incomingValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	value := values[0]
+	if len(value) > maxClientInfoLength {
+		value = strings.ToValidUTF8(value[:maxClientInfoLength], "")
+	}
+	return value
+}
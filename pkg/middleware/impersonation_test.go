@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// impersonationToken returns a token of user acted by actor
+func impersonationToken(t *testing.T, user, actor *entity.User) string {
+	t.Helper()
+
+	token, err := helpers.GenerateImpersonationToken(user.ID, user.Email, user.Role, user.TokenVersion,
+		helpers.ActorClaim{UserID: actor.ID, Email: actor.Email, TokenVersion: actor.TokenVersion}, time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken: %v", err)
+	}
+	return token
+}
+
+// callGuarded runs the auth interceptor followed by the impersonation guard,
+// which refuses testOwnerMethod, as the server chains them
+func (a *testAuth) callGuarded(method, token string) error {
+	guard := UnaryImpersonationInterceptor(repository.NewAuditRepository(a.db), []string{testOwnerMethod})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, err := a.UnaryServerInterceptor(testPolicy)(withMetadata("authorization", "Bearer "+token), struct{}{}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return guard(ctx, req, info, handler)
+		})
+	return err
+}
+
+// auditActions returns the actions of the audit events about user
+func (a *testAuth) auditActions(t *testing.T, user *entity.User) []string {
+	t.Helper()
+
+	var actions []string
+	if err := a.db.Model(&entity.AuditEvent{}).Where("entity_id = ?", user.ID).Order("id").Pluck("action", &actions).Error; err != nil {
+		t.Fatalf("read audit events: %v", err)
+	}
+	return actions
+}
+
+func TestImpersonationGuard(t *testing.T) {
+	auth := newTestAuth(t)
+	admin, _ := auth.createUser(t, "admin", entity.RoleSuperAdmin)
+	customer, customerToken := auth.createUser(t, "customer", entity.RoleUser)
+	token := impersonationToken(t, customer, admin)
+
+	// The owner's own token is neither audited nor restricted
+	if err := auth.callGuarded(testOwnerMethod, customerToken); err != nil {
+		t.Fatalf("owner calling an owner-only method: %v", err)
+	}
+	if actions := auth.auditActions(t, customer); len(actions) != 0 {
+		t.Errorf("calls of the owner audited as %v, want none", actions)
+	}
+
+	if err := auth.callGuarded(testUserMethod, token); err != nil {
+		t.Fatalf("impersonated call: %v", err)
+	}
+	if err := auth.callGuarded(testOwnerMethod, token); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("impersonated call of an owner-only method = %v, want PermissionDenied", err)
+	}
+	actions := auth.auditActions(t, customer)
+	if len(actions) != 2 || actions[0] != auditActionImpersonatedCall || actions[1] != auditActionImpersonatedDenied {
+		t.Errorf("audited actions = %v, want a call and a denied call", actions)
+	}
+
+	var event entity.AuditEvent
+	if err := auth.db.Where("entity_id = ?", customer.ID).First(&event).Error; err != nil {
+		t.Fatalf("read audit event: %v", err)
+	}
+	if event.ActorID != admin.ID || event.After == nil || *event.After != `{"method":"`+testUserMethod+`"}` {
+		t.Errorf("audit event = actor %d, after %v, want the admin calling %s", event.ActorID, event.After, testUserMethod)
+	}
+
+	// Calls that cannot be audited are refused
+	if err := auth.db.Migrator().DropTable(&entity.AuditEvent{}); err != nil {
+		t.Fatalf("drop audit table: %v", err)
+	}
+	if err := auth.callGuarded(testUserMethod, token); status.Code(err) != codes.Internal {
+		t.Errorf("impersonated call without an audit log = %v, want Internal", err)
+	}
+}
+
+func TestImpersonationTokenEndsWithActorAccess(t *testing.T) {
+	tests := []struct {
+		name   string
+		revoke map[string]interface{}
+	}{
+		{name: "actor disabled", revoke: map[string]interface{}{"disabled_at": time.Now()}},
+		{name: "actor logged out everywhere", revoke: map[string]interface{}{"token_version": 1}},
+		{name: "actor lost users:impersonate", revoke: map[string]interface{}{"role": entity.RoleOrderOperator}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := newTestAuth(t)
+			admin, _ := auth.createUser(t, "admin", entity.RoleSuperAdmin)
+			customer, _ := auth.createUser(t, "customer", entity.RoleUser)
+			token := impersonationToken(t, customer, admin)
+
+			if _, err := auth.callUnary(withMetadata("authorization", "Bearer "+token), testUserMethod, struct{}{}); err != nil {
+				t.Fatalf("impersonated call: %v", err)
+			}
+			if err := auth.db.Model(admin).Updates(tt.revoke).Error; err != nil {
+				t.Fatalf("revoke actor access: %v", err)
+			}
+			if _, err := auth.callUnary(withMetadata("authorization", "Bearer "+token), testUserMethod, struct{}{}); status.Code(err) != codes.Unauthenticated {
+				t.Errorf("impersonated call after the change = %v, want Unauthenticated", err)
+			}
+		})
+	}
+}
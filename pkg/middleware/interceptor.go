@@ -0,0 +1,250 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"github.com/nabil/book-store-system/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AccessLevel describes who may call an RPC
+type AccessLevel int
+
+const (
+	// AccessPublic RPCs can be called without a token
+	AccessPublic AccessLevel = iota
+	// AccessUser RPCs require a valid token. Staff operations additionally
+	// check permissions inside the services with RequirePermission.
+	AccessUser
+	// AccessMFAEnrollment RPCs require a valid token and also accept tokens that
+	// are restricted to MFA enrollment
+	AccessMFAEnrollment
+	// AccessAPIKey RPCs are AccessUser RPCs that also accept an API key in the
+	// x-api-key metadata header
+	AccessAPIKey
+)
+
+// AccessPolicy maps full gRPC method names (e.g. "/bookstore.BookService/CreateBook")
+// to their access level. Methods missing from the policy are denied.
+type AccessPolicy map[string]AccessLevel
+
+type contextKey string
+
+const authInfoKey contextKey = "auth_info"
+
+type authInfo struct {
+	user        *entity.User
+	claims      *helpers.JWTClaims
+	permissions map[string]bool
+	apiKey      *entity.APIKey
+}
+
+// tokenCarrier is implemented by request messages that still have the
+// deprecated token field
+type tokenCarrier interface {
+	GetToken() string
+}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user, token claims
+// and the permissions granted by the user's role
+func ContextWithUser(ctx context.Context, user *entity.User, claims *helpers.JWTClaims, permissions []string) context.Context {
+	granted := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		granted[permission] = true
+	}
+	return context.WithValue(ctx, authInfoKey, &authInfo{user: user, claims: claims, permissions: granted})
+}
+
+// UserFromContext returns the authenticated user stored in ctx by the auth interceptor
+func UserFromContext(ctx context.Context) (*entity.User, bool) {
+	info, ok := ctx.Value(authInfoKey).(*authInfo)
+	if !ok || info.user == nil {
+		return nil, false
+	}
+	return info.user, true
+}
+
+// ClaimsFromContext returns the claims of the token used to authenticate the request
+func ClaimsFromContext(ctx context.Context) (*helpers.JWTClaims, bool) {
+	info, ok := ctx.Value(authInfoKey).(*authInfo)
+	if !ok || info.claims == nil {
+		return nil, false
+	}
+	return info.claims, true
+}
+
+// APIKeyFromContext returns the API key used to authenticate the request, if any
+func APIKeyFromContext(ctx context.Context) (*entity.APIKey, bool) {
+	info, ok := ctx.Value(authInfoKey).(*authInfo)
+	if !ok || info.apiKey == nil {
+		return nil, false
+	}
+	return info.apiKey, true
+}
+
+// UnaryServerInterceptor authenticates unary calls according to the access policy
+func (m *AuthMiddleware) UnaryServerInterceptor(policy AccessPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token := tokenFromMetadata(ctx)
+		if token == "" {
+			// Transition period: fall back to the deprecated token field of the request
+			if carrier, ok := req.(tokenCarrier); ok {
+				token = carrier.GetToken()
+			}
+		}
+
+		ctx, err := m.authorize(ctx, info.FullMethod, token, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming calls according to the access policy.
+// Streams only accept the token from the authorization metadata header.
+func (m *AuthMiddleware) StreamServerInterceptor(policy AccessPolicy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := m.authorize(ss.Context(), info.FullMethod, tokenFromMetadata(ss.Context()), policy)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize enforces the access level of method and returns a context carrying the caller
+func (m *AuthMiddleware) authorize(ctx context.Context, method, token string, policy AccessPolicy) (context.Context, error) {
+	level, ok := policy[method]
+	if !ok {
+		logger.Errorf("Access denied - no access policy for method %s", method)
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	if level == AccessPublic {
+		return ctx, nil
+	}
+
+	if apiKey := apiKeyFromMetadata(ctx); apiKey != "" {
+		return m.authorizeAPIKey(ctx, method, apiKey, level)
+	}
+
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+
+	user, claims, err := m.Authenticate(token)
+	if err != nil {
+		logger.Errorf("Authentication failed for method %s: %v", method, err)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	switch claims.Scope {
+	case "":
+	case helpers.ScopeMFAEnrollment:
+		if level != AccessMFAEnrollment {
+			logger.Errorf("Access denied for method %s - user ID %d must enroll in MFA first", method, user.ID)
+			return nil, status.Error(codes.PermissionDenied, "multi-factor authentication enrollment required")
+		}
+	default:
+		// e.g. MFA challenge tokens, which are only valid as a VerifyMFA argument
+		return nil, status.Error(codes.Unauthenticated, "invalid token: token cannot be used as an access token")
+	}
+
+	permissions, err := m.roleRepo.GetPermissionNames(user.Role)
+	if err != nil {
+		logger.Errorf("Failed to load permissions for method %s: %v", method, err)
+		return nil, status.Error(codes.Internal, "failed to load permissions")
+	}
+
+	return ContextWithUser(ctx, user, claims, permissions), nil
+}
+
+// authorizeAPIKey authenticates a machine client. The key gets the permissions
+// of its scopes that the creating account still holds.
+func (m *AuthMiddleware) authorizeAPIKey(ctx context.Context, method, key string, level AccessLevel) (context.Context, error) {
+	if level != AccessAPIKey {
+		logger.Errorf("Access denied for method %s - API keys are not accepted", method)
+		return nil, status.Error(codes.PermissionDenied, "method cannot be called with an API key")
+	}
+
+	user, apiKey, err := m.AuthenticateAPIKey(key)
+	if err != nil {
+		logger.Errorf("API key authentication failed for method %s: %v", method, err)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid API key: %v", err)
+	}
+
+	if allowed, retryAfter := m.limiter.allow(apiKey.ID, apiKey.RateLimitPerMinute, time.Now()); !allowed {
+		seconds := int(retryAfter.Seconds()) + 1
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+		logger.Warnf("API key rate limit exceeded for method %s - API key ID %d", method, apiKey.ID)
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %d seconds", seconds)
+	}
+
+	rolePermissions, err := m.roleRepo.GetPermissionNames(user.Role)
+	if err != nil {
+		logger.Errorf("Failed to load permissions for method %s: %v", method, err)
+		return nil, status.Error(codes.Internal, "failed to load permissions")
+	}
+
+	held := make(map[string]bool, len(rolePermissions))
+	for _, permission := range rolePermissions {
+		held[permission] = true
+	}
+	granted := make(map[string]bool)
+	for _, scope := range apiKey.ScopeList() {
+		if held[scope] {
+			granted[scope] = true
+		}
+	}
+
+	return context.WithValue(ctx, authInfoKey, &authInfo{user: user, permissions: granted, apiKey: apiKey}), nil
+}
+
+// apiKeyFromMetadata extracts the API key from the x-api-key metadata header
+func apiKeyFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get("x-api-key"); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// tokenFromMetadata extracts the bearer token from the authorization metadata header
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get("authorization") {
+		if len(value) > 7 && strings.EqualFold(value[:7], "bearer ") {
+			return strings.TrimSpace(value[7:])
+		}
+	}
+	return ""
+}
+
+// authenticatedStream overrides the stream context with the authenticated one
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,372 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"github.com/nabil/book-store-system/pkg/database"
+	"github.com/nabil/book-store-system/pkg/helpers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	testPublicMethod = "/bookstore.Test/Public"
+	testUserMethod   = "/bookstore.Test/User"
+	testAPIKeyMethod = "/bookstore.Test/APIKey"
+	testOwnerMethod  = "/bookstore.Test/Owner"
+)
+
+var testPolicy = AccessPolicy{
+	testPublicMethod: AccessPublic,
+	testUserMethod:   AccessUser,
+	testAPIKeyMethod: AccessAPIKey,
+	testOwnerMethod:  AccessUser,
+}
+
+// testAuth is an AuthMiddleware over an in-memory database with the built-in roles
+type testAuth struct {
+	*AuthMiddleware
+	db      *gorm.DB
+	users   repository.UserRepository
+	roles   repository.RoleRepository
+	apiKeys repository.APIKeyRepository
+}
+
+func newTestAuth(t *testing.T) *testAuth {
+	t.Helper()
+
+	if err := helpers.InitTokens(&config.Config{JWTIssuer: "test", JWTAllowEphemeralKey: true, AccessTokenExpiry: 15, RefreshTokenExpiry: 24}); err != nil {
+		t.Fatalf("InitTokens: %v", err)
+	}
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	err = db.AutoMigrate(&entity.User{}, &entity.Permission{}, &entity.Role{}, &entity.RevokedToken{}, &entity.Session{}, &entity.APIKey{}, &entity.AuditEvent{})
+	if err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	if err := database.SeedRoles(db); err != nil {
+		t.Fatalf("seed roles: %v", err)
+	}
+
+	auth := &testAuth{
+		db:      db,
+		users:   repository.NewUserRepository(db),
+		roles:   repository.NewRoleRepository(db),
+		apiKeys: repository.NewAPIKeyRepository(db),
+	}
+	auth.AuthMiddleware = NewAuthMiddleware(auth.users, repository.NewTokenRepository(db), auth.roles, auth.apiKeys, NewUserCache(100, 0))
+	return auth
+}
+
+// createUser stores a user with role and returns an access token for them
+func (a *testAuth) createUser(t *testing.T, name, role string) (*entity.User, string) {
+	t.Helper()
+
+	user := &entity.User{Name: name, Email: name + "@example.com", Password: entity.NoPassword, Role: role}
+	if err := a.users.Create(user); err != nil {
+		t.Fatalf("create user %s: %v", name, err)
+	}
+	token, err := helpers.GenerateToken(user.ID, user.Email, user.Role, user.TokenVersion, 0)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	return user, token
+}
+
+// tokenRequest is a request message with the deprecated token field
+type tokenRequest struct {
+	token string
+}
+
+func (r tokenRequest) GetToken() string {
+	return r.token
+}
+
+// callUnary runs the unary interceptor for method and returns the user the
+// handler saw
+func (a *testAuth) callUnary(ctx context.Context, method string, req interface{}) (*entity.User, error) {
+	var caller *entity.User
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller, _ = UserFromContext(ctx)
+		return nil, nil
+	}
+	_, err := a.UnaryServerInterceptor(testPolicy)(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return caller, err
+}
+
+func withMetadata(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+func TestUnaryInterceptorTokenPrecedence(t *testing.T) {
+	auth := newTestAuth(t)
+	metadataUser, metadataToken := auth.createUser(t, "metadata", entity.RoleUser)
+	fieldUser, fieldToken := auth.createUser(t, "field", entity.RoleUser)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  interface{}
+		want *entity.User
+		code codes.Code
+	}{
+		{
+			name: "metadata only",
+			ctx:  withMetadata("authorization", "Bearer "+metadataToken),
+			req:  tokenRequest{},
+			want: metadataUser,
+		},
+		{
+			name: "lowercase bearer",
+			ctx:  withMetadata("authorization", "bearer "+metadataToken),
+			req:  tokenRequest{},
+			want: metadataUser,
+		},
+		{
+			name: "deprecated field only",
+			ctx:  context.Background(),
+			req:  tokenRequest{token: fieldToken},
+			want: fieldUser,
+		},
+		{
+			name: "metadata wins over the deprecated field",
+			ctx:  withMetadata("authorization", "Bearer "+metadataToken),
+			req:  tokenRequest{token: fieldToken},
+			want: metadataUser,
+		},
+		{
+			name: "invalid metadata token does not fall back to the field",
+			ctx:  withMetadata("authorization", "Bearer not-a-token"),
+			req:  tokenRequest{token: fieldToken},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "authorization without bearer scheme",
+			ctx:  withMetadata("authorization", metadataToken),
+			req:  struct{}{},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "no token",
+			ctx:  context.Background(),
+			req:  tokenRequest{},
+			code: codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller, err := auth.callUnary(tt.ctx, testUserMethod, tt.req)
+			if tt.want == nil {
+				if status.Code(err) != tt.code {
+					t.Fatalf("interceptor returned %v, want %v", err, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("interceptor returned %v", err)
+			}
+			if caller == nil || caller.ID != tt.want.ID {
+				t.Errorf("handler saw user %v, want %d", caller, tt.want.ID)
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptorAccessLevels(t *testing.T) {
+	auth := newTestAuth(t)
+	_, token := auth.createUser(t, "reader", entity.RoleUser)
+	ctx := withMetadata("authorization", "Bearer "+token)
+
+	if _, err := auth.callUnary(context.Background(), testPublicMethod, tokenRequest{}); err != nil {
+		t.Errorf("public method without a token: %v", err)
+	}
+	if _, err := auth.callUnary(ctx, "/bookstore.Test/Unlisted", tokenRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("method missing from the policy = %v, want PermissionDenied", err)
+	}
+
+	// MFA challenges are only accepted by VerifyMFA
+	user, _ := auth.createUser(t, "challenged", entity.RoleUser)
+	challenge, err := helpers.GenerateScopedToken(user.ID, user.Email, user.Role, user.TokenVersion, helpers.ScopeMFAChallenge, 0)
+	if err != nil {
+		t.Fatalf("GenerateScopedToken: %v", err)
+	}
+	if _, err := auth.callUnary(withMetadata("authorization", "Bearer "+challenge), testUserMethod, tokenRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("MFA challenge as an access token = %v, want Unauthenticated", err)
+	}
+}
+
+// testStream is a server stream with a fixed context
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamInterceptorReadsMetadataOnly(t *testing.T) {
+	auth := newTestAuth(t)
+	user, token := auth.createUser(t, "reader", entity.RoleUser)
+	interceptor := auth.StreamServerInterceptor(testPolicy)
+	info := &grpc.StreamServerInfo{FullMethod: testUserMethod}
+
+	var caller *entity.User
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		caller, _ = UserFromContext(ss.Context())
+		return nil
+	}
+	if err := interceptor(nil, &testStream{ctx: withMetadata("authorization", "Bearer "+token)}, info, handler); err != nil {
+		t.Fatalf("stream with a metadata token: %v", err)
+	}
+	if caller == nil || caller.ID != user.ID {
+		t.Errorf("handler saw user %v, want %d", caller, user.ID)
+	}
+
+	if err := interceptor(nil, &testStream{ctx: context.Background()}, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("stream without a token = %v, want Unauthenticated", err)
+	}
+}
+
+// createAPIKey stores an API key of owner with scopes and returns the key.
+// modify, if not nil, adjusts the key before it is stored.
+func (a *testAuth) createAPIKey(t *testing.T, owner *entity.User, name, scopes string, perMinute int, modify func(*entity.APIKey)) string {
+	t.Helper()
+
+	key := "bks_" + name
+	apiKey := &entity.APIKey{
+		Name:               name,
+		Prefix:             key,
+		KeyHash:            helpers.HashToken(key),
+		Scopes:             scopes,
+		RateLimitPerMinute: perMinute,
+		CreatedByID:        owner.ID,
+	}
+	if modify != nil {
+		modify(apiKey)
+	}
+	if err := a.apiKeys.Create(apiKey); err != nil {
+		t.Fatalf("create API key %s: %v", name, err)
+	}
+	return key
+}
+
+// callWithAPIKey runs the unary interceptor for method with key and returns the
+// context the handler saw
+func (a *testAuth) callWithAPIKey(method, key string) (context.Context, error) {
+	var seen context.Context
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = ctx
+		return nil, nil
+	}
+	_, err := a.UnaryServerInterceptor(testPolicy)(withMetadata("x-api-key", key), struct{}{}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return seen, err
+}
+
+func TestAPIKeyScopesIntersectRolePermissions(t *testing.T) {
+	auth := newTestAuth(t)
+	owner, _ := auth.createUser(t, "finance", entity.RoleFinance)
+	key := auth.createAPIKey(t, owner, "reports", entity.PermissionReportsRead+","+entity.PermissionBooksWrite, 0, nil)
+
+	ctx, err := auth.callWithAPIKey(testAPIKeyMethod, key)
+	if err != nil {
+		t.Fatalf("interceptor returned %v", err)
+	}
+	if user, _ := UserFromContext(ctx); user == nil || user.ID != owner.ID {
+		t.Errorf("handler saw user %v, want the key owner %d", user, owner.ID)
+	}
+	for permission, want := range map[string]bool{
+		// Scoped and held by the owner
+		entity.PermissionReportsRead: true,
+		// Scoped but not held by the owner
+		entity.PermissionBooksWrite: false,
+		// Held by the owner but not scoped
+		entity.PermissionOrdersRead: false,
+	} {
+		if got := HasPermission(ctx, permission); got != want {
+			t.Errorf("HasPermission(%s) = %v, want %v", permission, got, want)
+		}
+	}
+
+	if _, err := auth.callWithAPIKey(testUserMethod, key); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("API key on a method for users only = %v, want PermissionDenied", err)
+	}
+}
+
+func TestAPIKeyRejectsUnusableKeys(t *testing.T) {
+	auth := newTestAuth(t)
+	owner, _ := auth.createUser(t, "finance", entity.RoleFinance)
+	disabled, _ := auth.createUser(t, "disabled", entity.RoleFinance)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	if err := auth.db.Model(disabled).Update("disabled_at", past).Error; err != nil {
+		t.Fatalf("disable user: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		owner  *entity.User
+		modify func(*entity.APIKey)
+		code   codes.Code
+	}{
+		{name: "active", owner: owner, code: codes.OK},
+		{name: "not yet expired", owner: owner, modify: func(k *entity.APIKey) { k.ExpiresAt = &future }, code: codes.OK},
+		{name: "expired", owner: owner, modify: func(k *entity.APIKey) { k.ExpiresAt = &past }, code: codes.Unauthenticated},
+		{name: "revoked", owner: owner, modify: func(k *entity.APIKey) { k.RevokedAt = &past }, code: codes.Unauthenticated},
+		{name: "owner disabled", owner: disabled, code: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := auth.createAPIKey(t, tt.owner, strings.ReplaceAll(tt.name, " ", "-"), entity.PermissionReportsRead, 0, tt.modify)
+			if _, err := auth.callWithAPIKey(testAPIKeyMethod, key); status.Code(err) != tt.code {
+				t.Errorf("interceptor returned %v, want %v", err, tt.code)
+			}
+		})
+	}
+
+	if _, err := auth.callWithAPIKey(testAPIKeyMethod, "bks_unknown"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("unknown API key = %v, want Unauthenticated", err)
+	}
+}
+
+func TestAPIKeyRateLimit(t *testing.T) {
+	auth := newTestAuth(t)
+	owner, _ := auth.createUser(t, "finance", entity.RoleFinance)
+	limited := auth.createAPIKey(t, owner, "limited", entity.PermissionReportsRead, 2, nil)
+	other := auth.createAPIKey(t, owner, "other", entity.PermissionReportsRead, 2, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := auth.callWithAPIKey(testAPIKeyMethod, limited); err != nil {
+			t.Fatalf("request %d within the limit: %v", i+1, err)
+		}
+	}
+	if _, err := auth.callWithAPIKey(testAPIKeyMethod, limited); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("request past the limit = %v, want ResourceExhausted", err)
+	}
+	if _, err := auth.callWithAPIKey(testAPIKeyMethod, other); err != nil {
+		t.Errorf("another key of the same owner was limited: %v", err)
+	}
+}
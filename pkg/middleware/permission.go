@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPermissionDenied is returned by RequirePermission when the caller lacks a permission
+var ErrPermissionDenied = errors.New("permission denied")
+
+// HasPermission reports whether the authenticated caller's role grants permission
+func HasPermission(ctx context.Context, permission string) bool {
+	info, ok := ctx.Value(authInfoKey).(*authInfo)
+	if !ok {
+		return false
+	}
+	return info.permissions[permission]
+}
+
+// RequirePermission returns an error wrapping ErrPermissionDenied unless the
+// authenticated caller's role grants permission
+func RequirePermission(ctx context.Context, permission string) error {
+	if !HasPermission(ctx, permission) {
+		return fmt.Errorf("%w: %s required", ErrPermissionDenied, permission)
+	}
+	return nil
+}
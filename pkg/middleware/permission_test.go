@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nabil/book-store-system/internal/entity"
+)
+
+func TestRequirePermission(t *testing.T) {
+	user := &entity.User{ID: 1}
+	ctx := ContextWithUser(context.Background(), user, nil, []string{entity.PermissionBooksWrite, entity.PermissionOrdersRead})
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		permission string
+		want       bool
+	}{
+		{"granted", ctx, entity.PermissionBooksWrite, true},
+		{"another granted", ctx, entity.PermissionOrdersRead, true},
+		{"not granted", ctx, entity.PermissionUsersWrite, false},
+		{"empty permission", ctx, "", false},
+		{"unauthenticated", context.Background(), entity.PermissionBooksWrite, false},
+		{"user without permissions", ContextWithUser(context.Background(), user, nil, nil), entity.PermissionBooksWrite, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasPermission(tt.ctx, tt.permission); got != tt.want {
+				t.Errorf("HasPermission(%q) = %v, want %v", tt.permission, got, tt.want)
+			}
+
+			err := RequirePermission(tt.ctx, tt.permission)
+			if tt.want && err != nil {
+				t.Errorf("RequirePermission(%q) = %v, want nil", tt.permission, err)
+			}
+			if !tt.want && !errors.Is(err, ErrPermissionDenied) {
+				t.Errorf("RequirePermission(%q) = %v, want ErrPermissionDenied", tt.permission, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// bucketIdleTTL is how long a bucket goes unused before it is dropped. Any
+// bucket refills completely within a minute, so a dropped bucket is recreated
+// in the same state and keys that stopped working, such as revoked or expired
+// ones, do not pile up.
+const bucketIdleTTL = time.Minute
+
+// rateLimiter is an in-memory token bucket per API key. Every key can burst up
+// to its per-minute limit and refills continuously at that rate.
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[uint]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: make(map[uint]*tokenBucket)}
+}
+
+// allow takes one token from the bucket of id. When the bucket is empty it
+// returns false and how long until the next token is available.
+func (l *rateLimiter) allow(id uint, perMinute int, now time.Time) (bool, time.Duration) {
+	if perMinute <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	capacity := float64(perMinute)
+	ratePerSecond := capacity / 60
+
+	bucket, ok := l.buckets[id]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, lastRefill: now}
+		l.buckets[id] = bucket
+	}
+
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	bucket.tokens += elapsed * ratePerSecond
+	if bucket.tokens > capacity {
+		bucket.tokens = capacity
+	}
+	bucket.lastRefill = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / ratePerSecond * float64(time.Second))
+		return false, wait
+	}
+
+	bucket.tokens--
+	return true, 0
+}
+
+// sweep drops the buckets idle for bucketIdleTTL, at most once per bucketIdleTTL
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketIdleTTL {
+		return
+	}
+	l.lastSweep = now
+
+	for id, bucket := range l.buckets {
+		if now.Sub(bucket.lastRefill) >= bucketIdleTTL {
+			delete(l.buckets, id)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	limiter := newRateLimiter()
+	start := time.Now()
+
+	// A new key can burst up to its limit
+	for i := 0; i < 3; i++ {
+		if allowed, _ := limiter.allow(1, 3, start); !allowed {
+			t.Fatalf("request %d of the burst was denied", i+1)
+		}
+	}
+	allowed, wait := limiter.allow(1, 3, start)
+	if allowed {
+		t.Fatal("request past the burst was allowed")
+	}
+	if wait != 20*time.Second {
+		t.Errorf("wait = %v, want %v", wait, 20*time.Second)
+	}
+
+	// Other keys have their own bucket
+	if allowed, _ := limiter.allow(2, 3, start); !allowed {
+		t.Error("another key was limited")
+	}
+
+	// Tokens refill at the per-minute rate
+	if allowed, _ := limiter.allow(1, 3, start.Add(10*time.Second)); allowed {
+		t.Error("request allowed before a token refilled")
+	}
+	if allowed, _ := limiter.allow(1, 3, start.Add(20*time.Second)); !allowed {
+		t.Error("request denied after a token refilled")
+	}
+	if allowed, _ := limiter.allow(1, 3, start.Add(20*time.Second)); allowed {
+		t.Error("refilled bucket allowed two requests")
+	}
+
+	// A key without a limit is never limited
+	for i := 0; i < 100; i++ {
+		if allowed, _ := limiter.allow(3, 0, start); !allowed {
+			t.Fatal("key without a limit was denied")
+		}
+	}
+}
+
+func TestRateLimiterDropsIdleBuckets(t *testing.T) {
+	limiter := newRateLimiter()
+	start := time.Now()
+
+	limiter.allow(1, 2, start)
+	limiter.allow(1, 2, start)
+	limiter.allow(2, 2, start.Add(30*time.Second))
+
+	limiter.allow(3, 2, start.Add(bucketIdleTTL))
+	if _, ok := limiter.buckets[1]; ok {
+		t.Error("idle bucket was kept")
+	}
+	if _, ok := limiter.buckets[2]; !ok {
+		t.Error("recently used bucket was dropped")
+	}
+
+	// A dropped bucket comes back full, as it would have refilled anyway
+	for i := 0; i < 2; i++ {
+		if allowed, _ := limiter.allow(1, 2, start.Add(bucketIdleTTL)); !allowed {
+			t.Errorf("request %d after the bucket was dropped was denied", i+1)
+		}
+	}
+	if allowed, _ := limiter.allow(1, 2, start.Add(bucketIdleTTL)); allowed {
+		t.Error("recreated bucket allowed more than its limit")
+	}
+
+	// Sweeps run at most once per bucketIdleTTL
+	limiter.allow(4, 2, start.Add(bucketIdleTTL+45*time.Second))
+	if _, ok := limiter.buckets[2]; !ok {
+		t.Error("bucket dropped before the next sweep was due")
+	}
+	limiter.allow(4, 2, start.Add(2*bucketIdleTTL))
+	if _, ok := limiter.buckets[2]; ok {
+		t.Error("idle bucket was kept by the next sweep")
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDHeader is the metadata key carrying the request ID in both directions
+const requestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds request IDs supplied by clients
+const maxRequestIDLength = 128
+
+const requestIDKey contextKey = "request_id"
+
+// RequestIDFromContext returns the ID of the current request, or "" outside of a request
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// UnaryRequestIDInterceptor takes the request ID from the x-request-id metadata
+// header, or generates one, and echoes it back in the response header
+func UnaryRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = withRequestID(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestIDInterceptor is the streaming counterpart of UnaryRequestIDInterceptor
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestID(ss.Context())
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withRequestID stores the request ID in ctx and sends it as a response header
+func withRequestID(ctx context.Context) context.Context {
+	id := requestIDFromMetadata(ctx)
+	if id == "" {
+		id = newRequestID()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, id))
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func requestIDFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(requestIDHeader); len(values) > 0 {
+		id := strings.TrimSpace(values[0])
+		if len(id) <= maxRequestIDLength {
+			return id
+		}
+	}
+	return ""
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"gorm.io/gorm"
+)
+
+// UserCache is a bounded in-memory cache of the users resolved by the auth
+// interceptor. Entries expire after the TTL and the least recently used entry
+// is evicted when the cache is full. Writes through the repository returned by
+// CachingUserRepository invalidate the affected user as soon as they are
+// committed; other server instances see the change once their entry expires.
+type UserCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	entries    map[uint]*list.Element
+	lru        *list.List // front is the most recently used entry
+	// version counts invalidations, so that users loaded before one are not cached
+	version uint64
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+type userCacheEntry struct {
+	user      entity.User
+	expiresAt time.Time
+}
+
+// UserCacheStats is a snapshot of the cache metrics
+type UserCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+// NewUserCache creates a cache holding at most maxEntries users for ttl.
+// A cache with maxEntries or ttl of zero stores nothing.
+func NewUserCache(maxEntries int, ttl time.Duration) *UserCache {
+	return &UserCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    make(map[uint]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func (c *UserCache) enabled() bool {
+	return c != nil && c.maxEntries > 0 && c.ttl > 0
+}
+
+// Get returns a copy of the cached user, so callers may modify it freely
+func (c *UserCache) Get(id uint) (*entity.User, bool) {
+	if !c.enabled() {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[id]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	entry := element.Value.(*userCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.lru.Remove(element)
+		delete(c.entries, id)
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(element)
+	c.hits.Add(1)
+	user := entry.user
+	return &user, true
+}
+
+// Version returns the current cache version. Take it before loading a user
+// from the database and pass it to Set.
+func (c *UserCache) Version() uint64 {
+	if !c.enabled() {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version
+}
+
+// Set stores a copy of user, loaded from the database at cache version. The
+// user is not stored if any user was invalidated since, as the row may have
+// been read before that write committed.
+func (c *UserCache) Set(user *entity.User, version uint64) {
+	if !c.enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != c.version {
+		return
+	}
+
+	entry := &userCacheEntry{user: *user, expiresAt: time.Now().Add(c.ttl)}
+	if element, ok := c.entries[user.ID]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[user.ID] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*userCacheEntry).user.ID)
+		c.evictions.Add(1)
+	}
+}
+
+// Invalidate removes a user from the cache
+func (c *UserCache) Invalidate(id uint) {
+	if !c.enabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	if element, ok := c.entries[id]; ok {
+		c.lru.Remove(element)
+		delete(c.entries, id)
+	}
+}
+
+// Stats returns the current cache metrics
+func (c *UserCache) Stats() UserCacheStats {
+	if c == nil {
+		return UserCacheStats{}
+	}
+
+	c.mu.Lock()
+	entries := c.lru.Len()
+	c.mu.Unlock()
+
+	return UserCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   entries,
+	}
+}
+
+// cachingUserRepository invalidates cached users whenever they are written,
+// which covers profile updates, role changes, disabling and deletion. Writes in
+// a transaction invalidate again after the commit, as a concurrent request may
+// cache the old row until then.
+type cachingUserRepository struct {
+	repository.UserRepository
+	cache *UserCache
+}
+
+// CachingUserRepository wraps repo so that every update or delete invalidates
+// the user in cache. Reads are not cached; only the auth interceptor reads
+// through the cache.
+func CachingUserRepository(repo repository.UserRepository, cache *UserCache) repository.UserRepository {
+	return &cachingUserRepository{UserRepository: repo, cache: cache}
+}
+
+func (r *cachingUserRepository) Update(user *entity.User) error {
+	err := r.UserRepository.Update(user)
+	r.cache.Invalidate(user.ID)
+	return err
+}
+
+func (r *cachingUserRepository) IncrementTokenVersion(id uint) error {
+	err := r.UserRepository.IncrementTokenVersion(id)
+	r.cache.Invalidate(id)
+	return err
+}
+
+func (r *cachingUserRepository) UpdateMFA(user *entity.User) error {
+	err := r.UserRepository.UpdateMFA(user)
+	r.cache.Invalidate(user.ID)
+	return err
+}
+
+func (r *cachingUserRepository) UseTOTPStep(id uint, step int64) (bool, error) {
+	used, err := r.UserRepository.UseTOTPStep(id, step)
+	r.cache.Invalidate(id)
+	return used, err
+}
+
+func (r *cachingUserRepository) Delete(id uint) error {
+	err := r.UserRepository.Delete(id)
+	r.cache.Invalidate(id)
+	return err
+}
+
+func (r *cachingUserRepository) UpdateTx(tx *gorm.DB, user *entity.User) error {
+	err := r.UserRepository.UpdateTx(tx, user)
+	r.invalidateTx(tx, user.ID)
+	return err
+}
+
+func (r *cachingUserRepository) DeleteTx(tx *gorm.DB, id uint) error {
+	err := r.UserRepository.DeleteTx(tx, id)
+	r.invalidateTx(tx, id)
+	return err
+}
+
+// invalidateTx invalidates a user now and once tx has committed
+func (r *cachingUserRepository) invalidateTx(tx *gorm.DB, id uint) {
+	r.cache.Invalidate(id)
+	repository.AfterCommit(tx, func() { r.cache.Invalidate(id) })
+}
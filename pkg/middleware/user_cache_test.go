@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/internal/entity"
+	"github.com/nabil/book-store-system/internal/repository"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestUserCacheGetSet(t *testing.T) {
+	cache := NewUserCache(2, time.Minute)
+
+	cache.Set(&entity.User{ID: 1, Name: "one"}, cache.Version())
+	user, ok := cache.Get(1)
+	if !ok || user.Name != "one" {
+		t.Fatalf("Get(1) = %v, %v, want the cached user", user, ok)
+	}
+
+	// Callers get a copy
+	user.Name = "changed"
+	if user, _ := cache.Get(1); user.Name != "one" {
+		t.Errorf("modifying a returned user changed the cache: %q", user.Name)
+	}
+
+	// The least recently used entry is evicted
+	cache.Set(&entity.User{ID: 2}, cache.Version())
+	cache.Get(1)
+	cache.Set(&entity.User{ID: 3}, cache.Version())
+	if _, ok := cache.Get(2); ok {
+		t.Error("Get(2) hit, want the least recently used user evicted")
+	}
+	if _, ok := cache.Get(1); !ok {
+		t.Error("Get(1) missed, want the recently used user kept")
+	}
+
+	stats := cache.Stats()
+	if stats.Evictions != 1 || stats.Entries != 2 {
+		t.Errorf("Stats() = %+v, want 1 eviction and 2 entries", stats)
+	}
+}
+
+func TestUserCacheExpires(t *testing.T) {
+	cache := NewUserCache(10, time.Millisecond)
+	cache.Set(&entity.User{ID: 1}, cache.Version())
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := cache.Get(1); ok {
+		t.Error("Get(1) hit after the TTL")
+	}
+}
+
+func TestUserCacheSkipsUsersLoadedBeforeInvalidation(t *testing.T) {
+	cache := NewUserCache(10, time.Minute)
+
+	// A request loads the user while another one changes it
+	version := cache.Version()
+	cache.Invalidate(1)
+	cache.Set(&entity.User{ID: 1}, version)
+
+	if _, ok := cache.Get(1); ok {
+		t.Error("Get(1) hit, want the user loaded before the invalidation not cached")
+	}
+}
+
+// noopUserRepository pretends to write users
+type noopUserRepository struct {
+	repository.UserRepository
+}
+
+func (noopUserRepository) UpdateTx(tx *gorm.DB, user *entity.User) error { return nil }
+
+func (noopUserRepository) DeleteTx(tx *gorm.DB, id uint) error { return nil }
+
+func newTestTransactionRepository(t *testing.T) repository.TransactionRepository {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	return repository.NewTransactionRepository(db)
+}
+
+func TestCachingUserRepositoryInvalidatesAfterCommit(t *testing.T) {
+	cache := NewUserCache(10, time.Minute)
+	userRepo := CachingUserRepository(noopUserRepository{}, cache)
+	txRepo := newTestTransactionRepository(t)
+
+	for _, write := range []struct {
+		name string
+		fn   func(tx *gorm.DB) error
+	}{
+		{"update", func(tx *gorm.DB) error { return userRepo.UpdateTx(tx, &entity.User{ID: 1}) }},
+		{"delete", func(tx *gorm.DB) error { return userRepo.DeleteTx(tx, 1) }},
+	} {
+		t.Run(write.name, func(t *testing.T) {
+			err := txRepo.WithTransaction(func(tx *gorm.DB) error {
+				if err := write.fn(tx); err != nil {
+					return err
+				}
+
+				// A concurrent request still reads the old row until the commit
+				cache.Set(&entity.User{ID: 1, Name: "stale"}, cache.Version())
+				if _, ok := cache.Get(1); !ok {
+					t.Fatal("Get(1) missed, want the concurrently loaded user cached")
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("WithTransaction: %v", err)
+			}
+
+			if _, ok := cache.Get(1); ok {
+				t.Error("Get(1) hit after the commit, want the stale user invalidated")
+			}
+		})
+	}
+}
+
+func TestAfterCommitSkippedOnRollback(t *testing.T) {
+	txRepo := newTestTransactionRepository(t)
+
+	ran := false
+	failure := errors.New("failure")
+	err := txRepo.WithTransaction(func(tx *gorm.DB) error {
+		repository.AfterCommit(tx, func() { ran = true })
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithTransaction() = %v, want %v", err, failure)
+	}
+	if ran {
+		t.Error("AfterCommit function ran after a rollback")
+	}
+}
@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/nabil/book-store-system/config"
+	"golang.org/x/oauth2"
+)
+
+// httpTimeout bounds every request made to a provider
+const httpTimeout = 10 * time.Second
+
+// Identity is a user as asserted by the verified ID token of a provider
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider is an OpenID Connect provider used with the authorization code flow
+// and PKCE. The provider is discovered from its issuer URL on first use, so any
+// compliant issuer works, including a local mock IdP.
+type Provider struct {
+	cfg    config.OIDCProviderConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	provider *gooidc.Provider
+}
+
+// NewProviders returns the configured providers by name
+func NewProviders(cfg config.OIDCConfig) map[string]*Provider {
+	providers := make(map[string]*Provider, len(cfg.Providers))
+	for _, providerCfg := range cfg.Providers {
+		providers[providerCfg.Name] = &Provider{
+			cfg:    providerCfg,
+			client: &http.Client{Timeout: httpTimeout},
+		}
+	}
+	return providers
+}
+
+// Name returns the name the provider is configured under
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// discover fetches the discovery document of the issuer. A failed discovery is
+// retried on the next call instead of disabling the provider until a restart.
+func (p *Provider) discover() (*gooidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider != nil {
+		return p.provider, nil
+	}
+
+	// The context is kept by go-oidc to refresh the signing keys later, so it
+	// must outlive the request that triggered the discovery
+	provider, err := gooidc.NewProvider(gooidc.ClientContext(context.Background(), p.client), p.cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery of %s failed: %w", p.cfg.Name, err)
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+func (p *Provider) oauth2Config(provider *gooidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       p.cfg.Scopes,
+	}
+}
+
+// AuthCodeURL returns the URL the user is sent to for signing in. The S256
+// challenge of verifier and nonce are bound to the request.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) (string, error) {
+	provider, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(provider).AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange redeems an authorization code and returns the identity asserted by
+// the ID token after checking its signature, issuer, audience, expiry and nonce
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	provider, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := p.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response did not contain an id_token")
+	}
+
+	idToken, err := provider.Verifier(&gooidc.Config{ClientID: p.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("invalid id_token: nonce mismatch")
+	}
+
+	var claims struct {
+		Email         string    `json:"email"`
+		EmailVerified claimBool `json:"email_verified"`
+		Name          string    `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("invalid id_token claims: %w", err)
+	}
+
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// claimBool accepts both true and "true", since some providers send
+// email_verified as a string
+type claimBool bool
+
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch typed := value.(type) {
+	case bool:
+		*b = claimBool(typed)
+	case string:
+		*b = claimBool(typed == "true")
+	case nil:
+		*b = false
+	default:
+		return fmt.Errorf("unexpected value %s for a boolean claim", data)
+	}
+	return nil
+}
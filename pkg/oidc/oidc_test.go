@@ -0,0 +1,182 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nabil/book-store-system/config"
+	"github.com/nabil/book-store-system/pkg/oidc/oidctest"
+	"golang.org/x/oauth2"
+)
+
+const testRedirectURL = "http://localhost:3000/callback"
+
+// newTestProvider starts a mock identity provider and configures a provider for it
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Server) {
+	t.Helper()
+
+	idp, err := oidctest.NewServer()
+	if err != nil {
+		t.Fatalf("start mock identity provider: %v", err)
+	}
+	t.Cleanup(idp.Close)
+
+	providers := NewProviders(config.OIDCConfig{Providers: []config.OIDCProviderConfig{{
+		Name:         "mock",
+		IssuerURL:    idp.URL,
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+	}}})
+	return providers["mock"], idp
+}
+
+// login runs the authorization request and returns the code
+func login(t *testing.T, provider *Provider, idp *oidctest.Server, nonce, verifier string, user oidctest.User) string {
+	t.Helper()
+
+	authURL, err := provider.AuthCodeURL("state-1", nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	code, state, err := idp.Login(authURL, user)
+	if err != nil {
+		t.Fatalf("sign in at the identity provider: %v", err)
+	}
+	if state != "state-1" {
+		t.Fatalf("provider redirected back with state %q, want state-1", state)
+	}
+	return code
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	provider, idp := newTestProvider(t)
+
+	authURL, err := provider.AuthCodeURL("the-state", "the-nonce", oauth2.GenerateVerifier())
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse %q: %v", authURL, err)
+	}
+
+	if got, want := parsed.Scheme+"://"+parsed.Host+parsed.Path, idp.URL+"/authorize"; got != want {
+		t.Errorf("authorization endpoint = %q, want %q", got, want)
+	}
+	query := parsed.Query()
+	for name, want := range map[string]string{
+		"client_id":             oidctest.ClientID,
+		"redirect_uri":          testRedirectURL,
+		"response_type":         "code",
+		"state":                 "the-state",
+		"nonce":                 "the-nonce",
+		"scope":                 "openid email profile",
+		"code_challenge_method": "S256",
+	} {
+		if got := query.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if query.Get("code_challenge") == "" {
+		t.Error("code_challenge missing")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	provider, idp := newTestProvider(t)
+	verifier := oauth2.GenerateVerifier()
+
+	code := login(t, provider, idp, "nonce-1", verifier, oidctest.User{
+		Subject:       "subject-1",
+		Email:         "reader@example.com",
+		EmailVerified: true,
+		Name:          "Reader",
+	})
+
+	identity, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	want := Identity{Subject: "subject-1", Email: "reader@example.com", EmailVerified: true, Name: "Reader"}
+	if *identity != want {
+		t.Errorf("Exchange() = %+v, want %+v", *identity, want)
+	}
+
+	// Authorization codes are single use
+	if _, err := provider.Exchange(context.Background(), code, verifier, "nonce-1"); err == nil {
+		t.Error("Exchange accepted a code that was already redeemed")
+	}
+}
+
+func TestExchangeRejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name          string
+		claims        map[string]interface{}
+		wrongVerifier bool
+		wrongNonce    bool
+	}{
+		{name: "PKCE verifier mismatch", wrongVerifier: true},
+		{name: "nonce mismatch", wrongNonce: true},
+		{name: "other audience", claims: map[string]interface{}{"aud": "other-client"}},
+		{name: "other issuer", claims: map[string]interface{}{"iss": "https://evil.example.com"}},
+		{name: "expired", claims: map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, idp := newTestProvider(t)
+			verifier := oauth2.GenerateVerifier()
+
+			code := login(t, provider, idp, "nonce-1", verifier, oidctest.User{
+				Subject:       "subject-1",
+				Email:         "reader@example.com",
+				EmailVerified: true,
+				Claims:        tt.claims,
+			})
+
+			if tt.wrongVerifier {
+				verifier = oauth2.GenerateVerifier()
+			}
+			nonce := "nonce-1"
+			if tt.wrongNonce {
+				nonce = "nonce-2"
+			}
+
+			if identity, err := provider.Exchange(context.Background(), code, verifier, nonce); err == nil {
+				t.Errorf("Exchange() = %+v, want an error", identity)
+			}
+		})
+	}
+}
+
+func TestClaimBool(t *testing.T) {
+	tests := []struct {
+		json string
+		want bool
+	}{
+		{`true`, true},
+		{`false`, false},
+		{`"true"`, true},
+		{`"false"`, false},
+		{`null`, false},
+	}
+	for _, tt := range tests {
+		var got claimBool
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+			t.Errorf("unmarshal %s: %v", tt.json, err)
+			continue
+		}
+		if bool(got) != tt.want {
+			t.Errorf("unmarshal %s = %v, want %v", tt.json, got, tt.want)
+		}
+	}
+
+	var got claimBool
+	if err := json.Unmarshal([]byte(`1`), &got); err == nil {
+		t.Error("unmarshal 1 succeeded, want an error")
+	}
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nabil/book-store-system/config"
+)
+
+// ErrNotFound is returned by Get for keys that hold no blob
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore keeps binary objects such as book covers outside the database.
+// Keys are slash-separated relative paths like "covers/ab12.jpg".
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the blob under key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL the blob under key is served from
+	URL(key string) string
+}
+
+// New returns the blob store selected by STORAGE_DRIVER: "local" to keep blobs
+// in STORAGE_LOCAL_DIR or "s3" for an S3-compatible bucket
+func New(cfg config.StorageConfig) (BlobStore, error) {
+	switch cfg.Driver {
+	case "local", "":
+		return NewLocalStore(cfg.LocalDir, cfg.PublicURL)
+	case "s3":
+		return NewS3Store(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// validateKey rejects keys that could escape the store's root
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
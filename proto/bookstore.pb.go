@@ -23,15 +23,23 @@ const (
 
 // User messages
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email           string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role            string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Phone           string                 `protobuf:"bytes,7,opt,name=phone,proto3" json:"phone,omitempty"`
+	Address         string                 `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
+	Disabled        bool                   `protobuf:"varint,9,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	DisabledAt      string                 `protobuf:"bytes,10,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	MfaEnabled      bool                   `protobuf:"varint,11,opt,name=mfa_enabled,json=mfaEnabled,proto3" json:"mfa_enabled,omitempty"`
+	EmailVerified   bool                   `protobuf:"varint,12,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	EmailVerifiedAt string                 `protobuf:"bytes,13,opt,name=email_verified_at,json=emailVerifiedAt,proto3" json:"email_verified_at,omitempty"`
+	AnonymizedAt    string                 `protobuf:"bytes,14,opt,name=anonymized_at,json=anonymizedAt,proto3" json:"anonymized_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -106,6 +114,62 @@ func (x *User) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *User) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *User) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *User) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *User) GetDisabledAt() string {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return ""
+}
+
+func (x *User) GetMfaEnabled() bool {
+	if x != nil {
+		return x.MfaEnabled
+	}
+	return false
+}
+
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetEmailVerifiedAt() string {
+	if x != nil {
+		return x.EmailVerifiedAt
+	}
+	return ""
+}
+
+func (x *User) GetAnonymizedAt() string {
+	if x != nil {
+		return x.AnonymizedAt
+	}
+	return ""
+}
+
 type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -279,13 +343,18 @@ func (x *LoginRequest) GetPassword() string {
 }
 
 type LoginResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
-	User          *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Success               bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message               string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token                 string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	User                  *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	RefreshToken          string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn             int64                  `protobuf:"varint,6,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`       // access token lifetime in seconds
+	MfaRequired           bool                   `protobuf:"varint,7,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"` // complete the login with VerifyMFA using mfa_token
+	MfaToken              string                 `protobuf:"bytes,8,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	MfaEnrollmentRequired bool                   `protobuf:"varint,9,opt,name=mfa_enrollment_required,json=mfaEnrollmentRequired,proto3" json:"mfa_enrollment_required,omitempty"` // token only allows EnrollMFA, ConfirmMFA and GetProfile
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
@@ -346,9 +415,45 @@ func (x *LoginResponse) GetUser() *User {
 	return nil
 }
 
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetMfaEnrollmentRequired() bool {
+	if x != nil {
+		return x.MfaEnrollmentRequired
+	}
+	return false
+}
+
 type GetProfileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -383,6 +488,7 @@ func (*GetProfileRequest) Descriptor() ([]byte, []int) {
 	return file_proto_bookstore_proto_rawDescGZIP(), []int{5}
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *GetProfileRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -450,31 +556,32 @@ func (x *GetProfileResponse) GetUser() *User {
 	return nil
 }
 
-// Category messages
-type Category struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+type UpdateProfileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Address       string `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Category) Reset() {
-	*x = Category{}
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
 	mi := &file_proto_bookstore_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Category) String() string {
+func (x *UpdateProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Category) ProtoMessage() {}
+func (*UpdateProfileRequest) ProtoMessage() {}
 
-func (x *Category) ProtoReflect() protoreflect.Message {
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_bookstore_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -486,61 +593,70 @@ func (x *Category) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Category.ProtoReflect.Descriptor instead.
-func (*Category) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
 	return file_proto_bookstore_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Category) GetId() uint32 {
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
+func (x *UpdateProfileRequest) GetToken() string {
 	if x != nil {
-		return x.Id
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-func (x *Category) GetName() string {
+func (x *UpdateProfileRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Category) GetCreatedAt() string {
+func (x *UpdateProfileRequest) GetEmail() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Email
 	}
 	return ""
 }
 
-func (x *Category) GetUpdatedAt() string {
+func (x *UpdateProfileRequest) GetPhone() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Phone
 	}
 	return ""
 }
 
-type CreateCategoryRequest struct {
+func (x *UpdateProfileRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type UpdateProfileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCategoryRequest) Reset() {
-	*x = CreateCategoryRequest{}
+func (x *UpdateProfileResponse) Reset() {
+	*x = UpdateProfileResponse{}
 	mi := &file_proto_bookstore_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCategoryRequest) String() string {
+func (x *UpdateProfileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCategoryRequest) ProtoMessage() {}
+func (*UpdateProfileResponse) ProtoMessage() {}
 
-func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateProfileResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_bookstore_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -552,48 +668,56 @@ func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
-func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateProfileResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProfileResponse) Descriptor() ([]byte, []int) {
 	return file_proto_bookstore_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CreateCategoryRequest) GetName() string {
+func (x *UpdateProfileResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Name
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *CreateCategoryRequest) GetToken() string {
+func (x *UpdateProfileResponse) GetMessage() string {
 	if x != nil {
-		return x.Token
+		return x.Message
 	}
 	return ""
 }
 
-type CreateCategoryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Category      *Category              `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+func (x *UpdateProfileResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ChangePasswordRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	OldPassword   string `protobuf:"bytes,2,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	NewPassword   string `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCategoryResponse) Reset() {
-	*x = CreateCategoryResponse{}
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
 	mi := &file_proto_bookstore_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCategoryResponse) String() string {
+func (x *ChangePasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCategoryResponse) ProtoMessage() {}
+func (*ChangePasswordRequest) ProtoMessage() {}
 
-func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_bookstore_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -605,54 +729,60 @@ func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
-func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
 	return file_proto_bookstore_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateCategoryResponse) GetSuccess() bool {
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
+func (x *ChangePasswordRequest) GetToken() string {
 	if x != nil {
-		return x.Success
+		return x.Token
 	}
-	return false
+	return ""
 }
 
-func (x *CreateCategoryResponse) GetMessage() string {
+func (x *ChangePasswordRequest) GetOldPassword() string {
 	if x != nil {
-		return x.Message
+		return x.OldPassword
 	}
 	return ""
 }
 
-func (x *CreateCategoryResponse) GetCategory() *Category {
+func (x *ChangePasswordRequest) GetNewPassword() string {
 	if x != nil {
-		return x.Category
+		return x.NewPassword
 	}
-	return nil
+	return ""
 }
 
-type GetCategoriesRequest struct {
+// ChangePasswordResponse carries a fresh token pair for the caller; every token
+// issued before the change is rejected from now on.
+type ChangePasswordResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,5,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCategoriesRequest) Reset() {
-	*x = GetCategoriesRequest{}
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
 	mi := &file_proto_bookstore_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCategoriesRequest) String() string {
+func (x *ChangePasswordResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCategoriesRequest) ProtoMessage() {}
+func (*ChangePasswordResponse) ProtoMessage() {}
 
-func (x *GetCategoriesRequest) ProtoReflect() protoreflect.Message {
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_bookstore_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -664,53 +794,67 @@ func (x *GetCategoriesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCategoriesRequest.ProtoReflect.Descriptor instead.
-func (*GetCategoriesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
 	return file_proto_bookstore_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetCategoriesRequest) GetPage() int32 {
+func (x *ChangePasswordResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Page
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *GetCategoriesRequest) GetLimit() int32 {
+func (x *ChangePasswordResponse) GetMessage() string {
 	if x != nil {
-		return x.Limit
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ChangePasswordResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ChangePasswordResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *ChangePasswordResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
 	}
 	return 0
 }
 
-type GetCategoriesResponse struct {
+type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Categories    []*Category            `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
-	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
-	CurrentPage   int32                  `protobuf:"varint,5,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
-	TotalPages    int32                  `protobuf:"varint,6,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
-	HasNext       bool                   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
-	HasPrevious   bool                   `protobuf:"varint,8,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCategoriesResponse) Reset() {
-	*x = GetCategoriesResponse{}
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
 	mi := &file_proto_bookstore_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCategoriesResponse) String() string {
+func (x *RefreshTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCategoriesResponse) ProtoMessage() {}
+func (*RefreshTokenRequest) ProtoMessage() {}
 
-func (x *GetCategoriesResponse) ProtoReflect() protoreflect.Message {
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_bookstore_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -722,33 +866,5078 @@ func (x *GetCategoriesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCategoriesResponse.ProtoReflect.Descriptor instead.
-func (*GetCategoriesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
 	return file_proto_bookstore_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetCategoriesResponse) GetSuccess() bool {
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,5,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RefreshTokenResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *GetCategoriesResponse) GetMessage() string {
+func (x *RefreshTokenResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetCategoriesResponse) GetCategories() []*Category {
+func (x *RefreshTokenResponse) GetToken() string {
 	if x != nil {
-		return x.Categories
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-func (x *GetCategoriesResponse) GetTotal() int32 {
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+type LogoutRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	RefreshToken  string `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{13}
+}
+
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
+func (x *LogoutRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LogoutResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LogoutResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LogoutAllDevicesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllDevicesRequest) Reset() {
+	*x = LogoutAllDevicesRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllDevicesRequest) ProtoMessage() {}
+
+func (x *LogoutAllDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllDevicesRequest.ProtoReflect.Descriptor instead.
+func (*LogoutAllDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{15}
+}
+
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
+func (x *LogoutAllDevicesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type LogoutAllDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutAllDevicesResponse) Reset() {
+	*x = LogoutAllDevicesResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutAllDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutAllDevicesResponse) ProtoMessage() {}
+
+func (x *LogoutAllDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutAllDevicesResponse.ProtoReflect.Descriptor instead.
+func (*LogoutAllDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LogoutAllDevicesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LogoutAllDevicesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type EnrollMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollMFARequest) Reset() {
+	*x = EnrollMFARequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollMFARequest) ProtoMessage() {}
+
+func (x *EnrollMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollMFARequest.ProtoReflect.Descriptor instead.
+func (*EnrollMFARequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{17}
+}
+
+type EnrollMFAResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Secret          string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	ProvisioningUri string                 `protobuf:"bytes,4,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"` // otpauth:// URI, usually shown as a QR code
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EnrollMFAResponse) Reset() {
+	*x = EnrollMFAResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollMFAResponse) ProtoMessage() {}
+
+func (x *EnrollMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollMFAResponse.ProtoReflect.Descriptor instead.
+func (*EnrollMFAResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EnrollMFAResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnrollMFAResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EnrollMFAResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollMFAResponse) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+type ConfirmMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmMFARequest) Reset() {
+	*x = ConfirmMFARequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMFARequest) ProtoMessage() {}
+
+func (x *ConfirmMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMFARequest.ProtoReflect.Descriptor instead.
+func (*ConfirmMFARequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConfirmMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,3,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"` // shown only once
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,6,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmMFAResponse) Reset() {
+	*x = ConfirmMFAResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmMFAResponse) ProtoMessage() {}
+
+func (x *ConfirmMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmMFAResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmMFAResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConfirmMFAResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmMFAResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmMFAResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+func (x *ConfirmMFAResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConfirmMFAResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *ConfirmMFAResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+type VerifyMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken      string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // TOTP code or recovery code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerifyMFARequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyMFAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	User          *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,6,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFAResponse) Reset() {
+	*x = VerifyMFAResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFAResponse) ProtoMessage() {}
+
+func (x *VerifyMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFAResponse.ProtoReflect.Descriptor instead.
+func (*VerifyMFAResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerifyMFAResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *VerifyMFAResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *VerifyMFAResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RequestPasswordResetResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RequestPasswordResetResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ConfirmPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // reset code from the email
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetRequest) Reset() {
+	*x = ConfirmPasswordResetRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetRequest) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConfirmPasswordResetRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConfirmPasswordResetRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ConfirmPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetResponse) Reset() {
+	*x = ConfirmPasswordResetResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetResponse) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConfirmPasswordResetResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmPasswordResetResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // verification code from the email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type VerifyEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailResponse) Reset() {
+	*x = VerifyEmailResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailResponse) ProtoMessage() {}
+
+func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VerifyEmailResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *VerifyEmailResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VerifyEmailResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ResendVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationRequest) Reset() {
+	*x = ResendVerificationRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationRequest) ProtoMessage() {}
+
+func (x *ResendVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationRequest.ProtoReflect.Descriptor instead.
+func (*ResendVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{29}
+}
+
+type ResendVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationResponse) Reset() {
+	*x = ResendVerificationResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationResponse) ProtoMessage() {}
+
+func (x *ResendVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationResponse.ProtoReflect.Descriptor instead.
+func (*ResendVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ResendVerificationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResendVerificationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BeginOIDCLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // name from OIDC_PROVIDERS, e.g. google
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginOIDCLoginRequest) Reset() {
+	*x = BeginOIDCLoginRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginOIDCLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginOIDCLoginRequest) ProtoMessage() {}
+
+func (x *BeginOIDCLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginOIDCLoginRequest.ProtoReflect.Descriptor instead.
+func (*BeginOIDCLoginRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BeginOIDCLoginRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type BeginOIDCLoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AuthUrl       string                 `protobuf:"bytes,3,opt,name=auth_url,json=authUrl,proto3" json:"auth_url,omitempty"` // send the user here
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`                    // returned by the provider on the redirect, pass it to CompleteOIDCLogin
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginOIDCLoginResponse) Reset() {
+	*x = BeginOIDCLoginResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginOIDCLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginOIDCLoginResponse) ProtoMessage() {}
+
+func (x *BeginOIDCLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginOIDCLoginResponse.ProtoReflect.Descriptor instead.
+func (*BeginOIDCLoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BeginOIDCLoginResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BeginOIDCLoginResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BeginOIDCLoginResponse) GetAuthUrl() string {
+	if x != nil {
+		return x.AuthUrl
+	}
+	return ""
+}
+
+func (x *BeginOIDCLoginResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type CompleteOIDCLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteOIDCLoginRequest) Reset() {
+	*x = CompleteOIDCLoginRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteOIDCLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteOIDCLoginRequest) ProtoMessage() {}
+
+func (x *CompleteOIDCLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteOIDCLoginRequest.ProtoReflect.Descriptor instead.
+func (*CompleteOIDCLoginRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CompleteOIDCLoginRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CompleteOIDCLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Session messages
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	DeviceName    string                 `protobuf:"bytes,3,opt,name=device_name,json=deviceName,proto3" json:"device_name,omitempty"` // from the x-device-name metadata sent at login
+	IpAddress     string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeenAt    string                 `protobuf:"bytes,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Current       bool                   `protobuf:"varint,8,opt,name=current,proto3" json:"current,omitempty"` // the session making this request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_proto_bookstore_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Session) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Session) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *Session) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListMySessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMySessionsRequest) Reset() {
+	*x = ListMySessionsRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMySessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMySessionsRequest) ProtoMessage() {}
+
+func (x *ListMySessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMySessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMySessionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{35}
+}
+
+type ListMySessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Sessions      []*Session             `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMySessionsResponse) Reset() {
+	*x = ListMySessionsResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMySessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMySessionsResponse) ProtoMessage() {}
+
+func (x *ListMySessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMySessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMySessionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListMySessionsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListMySessionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListMySessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RevokeSessionRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RevokeSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeSessionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Personal data messages
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{39}
+}
+
+// DataExportChunk is one piece of a JSON archive; concatenate the chunks in order
+type DataExportChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataExportChunk) Reset() {
+	*x = DataExportChunk{}
+	mi := &file_proto_bookstore_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExportChunk) ProtoMessage() {}
+
+func (x *DataExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExportChunk.ProtoReflect.Descriptor instead.
+func (*DataExportChunk) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DataExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeleteMyAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Current password, to confirm the deletion. Accounts without a password
+	// (created by an OIDC sign in) leave it empty and must have signed in within
+	// the last 10 minutes.
+	Password      string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMyAccountRequest) Reset() {
+	*x = DeleteMyAccountRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMyAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMyAccountRequest) ProtoMessage() {}
+
+func (x *DeleteMyAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMyAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMyAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteMyAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type DeleteMyAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMyAccountResponse) Reset() {
+	*x = DeleteMyAccountResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMyAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMyAccountResponse) ProtoMessage() {}
+
+func (x *DeleteMyAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMyAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMyAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteMyAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteMyAccountResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Admin user management messages
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Search        string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"` // matches name or email
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Users         []*User                `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	CurrentPage   int32                  `protobuf:"varint,5,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,6,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrevious   bool                   `protobuf:"varint,8,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListUsersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListUsersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *ListUsersResponse) GetHasPrevious() bool {
+	if x != nil {
+		return x.HasPrevious
+	}
+	return false
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type SetUserRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleRequest) Reset() {
+	*x = SetUserRoleRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleRequest) ProtoMessage() {}
+
+func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetUserRoleRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetUserRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type SetUserRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleResponse) Reset() {
+	*x = SetUserRoleResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleResponse) ProtoMessage() {}
+
+func (x *SetUserRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleResponse.ProtoReflect.Descriptor instead.
+func (*SetUserRoleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SetUserRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetUserRoleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetUserRoleResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DisableUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserRequest) Reset() {
+	*x = DisableUserRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserRequest) ProtoMessage() {}
+
+func (x *DisableUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserRequest.ProtoReflect.Descriptor instead.
+func (*DisableUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DisableUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DisableUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserResponse) Reset() {
+	*x = DisableUserResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserResponse) ProtoMessage() {}
+
+func (x *DisableUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserResponse.ProtoReflect.Descriptor instead.
+func (*DisableUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DisableUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DisableUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DisableUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type EnableUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableUserRequest) Reset() {
+	*x = EnableUserRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableUserRequest) ProtoMessage() {}
+
+func (x *EnableUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableUserRequest.ProtoReflect.Descriptor instead.
+func (*EnableUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *EnableUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type EnableUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableUserResponse) Reset() {
+	*x = EnableUserResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableUserResponse) ProtoMessage() {}
+
+func (x *EnableUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableUserResponse.ProtoReflect.Descriptor instead.
+func (*EnableUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *EnableUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnableUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EnableUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// UnlockAccountRequest clears the login lockout of the user's email
+type UnlockAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountRequest) Reset() {
+	*x = UnlockAccountRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountRequest) ProtoMessage() {}
+
+func (x *UnlockAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountRequest.ProtoReflect.Descriptor instead.
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *UnlockAccountRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UnlockAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountResponse) Reset() {
+	*x = UnlockAccountResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountResponse) ProtoMessage() {}
+
+func (x *UnlockAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountResponse.ProtoReflect.Descriptor instead.
+func (*UnlockAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *UnlockAccountResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnlockAccountResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Permission struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Permission) Reset() {
+	*x = Permission{}
+	mi := &file_proto_bookstore_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Permission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Permission) ProtoMessage() {}
+
+func (x *Permission) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Permission.ProtoReflect.Descriptor instead.
+func (*Permission) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Permission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Permission) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Role struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Permissions   []string               `protobuf:"bytes,4,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Role) Reset() {
+	*x = Role{}
+	mi := &file_proto_bookstore_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Role) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Role) ProtoMessage() {}
+
+func (x *Role) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Role.ProtoReflect.Descriptor instead.
+func (*Role) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *Role) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Role) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Role) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Role) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type ListRolesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesRequest) Reset() {
+	*x = ListRolesRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesRequest) ProtoMessage() {}
+
+func (x *ListRolesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesRequest.ProtoReflect.Descriptor instead.
+func (*ListRolesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{59}
+}
+
+type ListRolesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Roles         []*Role                `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRolesResponse) Reset() {
+	*x = ListRolesResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRolesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRolesResponse) ProtoMessage() {}
+
+func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRolesResponse.ProtoReflect.Descriptor instead.
+func (*ListRolesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListRolesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListRolesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListRolesResponse) GetRoles() []*Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type ListPermissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPermissionsRequest) Reset() {
+	*x = ListPermissionsRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPermissionsRequest) ProtoMessage() {}
+
+func (x *ListPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{61}
+}
+
+type ListPermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Permissions   []*Permission          `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPermissionsResponse) Reset() {
+	*x = ListPermissionsResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPermissionsResponse) ProtoMessage() {}
+
+func (x *ListPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPermissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListPermissionsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListPermissionsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListPermissionsResponse) GetPermissions() []*Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// UpsertRoleRequest creates the role or replaces the description and
+// permissions of an existing one
+type UpsertRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Permissions   []string               `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertRoleRequest) Reset() {
+	*x = UpsertRoleRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertRoleRequest) ProtoMessage() {}
+
+func (x *UpsertRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpsertRoleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *UpsertRoleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpsertRoleRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpsertRoleRequest) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type UpsertRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Role          *Role                  `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertRoleResponse) Reset() {
+	*x = UpsertRoleResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertRoleResponse) ProtoMessage() {}
+
+func (x *UpsertRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRoleResponse.ProtoReflect.Descriptor instead.
+func (*UpsertRoleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *UpsertRoleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpsertRoleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpsertRoleResponse) GetRole() *Role {
+	if x != nil {
+		return x.Role
+	}
+	return nil
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ExportUserDataRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AnonymizeUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserRequest) Reset() {
+	*x = AnonymizeUserRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserRequest) ProtoMessage() {}
+
+func (x *AnonymizeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserRequest.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *AnonymizeUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AnonymizeUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserResponse) Reset() {
+	*x = AnonymizeUserResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserResponse) ProtoMessage() {}
+
+func (x *AnonymizeUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserResponse.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *AnonymizeUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AnonymizeUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AnonymizeUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ImpersonateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserRequest) Reset() {
+	*x = ImpersonateUserRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserRequest) ProtoMessage() {}
+
+func (x *ImpersonateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ImpersonateUserRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// The token acts as the user but records the caller in its act claim. It has
+// no refresh token and cannot change credentials or pay for orders.
+type ImpersonateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` // token lifetime in seconds
+	User          *User                  `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserResponse) Reset() {
+	*x = ImpersonateUserResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserResponse) ProtoMessage() {}
+
+func (x *ImpersonateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ImpersonateUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImpersonateUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImpersonateUserResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImpersonateUserResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *ImpersonateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// API key messages
+type APIKey struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prefix             string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"` // public part of the key, for identification
+	Scopes             []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	RateLimitPerMinute int32                  `protobuf:"varint,5,opt,name=rate_limit_per_minute,json=rateLimitPerMinute,proto3" json:"rate_limit_per_minute,omitempty"`
+	CreatedById        uint32                 `protobuf:"varint,6,opt,name=created_by_id,json=createdById,proto3" json:"created_by_id,omitempty"`
+	ExpiresAt          string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt         string                 `protobuf:"bytes,8,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt          string                 `protobuf:"bytes,9,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_proto_bookstore_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *APIKey) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetRateLimitPerMinute() int32 {
+	if x != nil {
+		return x.RateLimitPerMinute
+	}
+	return 0
+}
+
+func (x *APIKey) GetCreatedById() uint32 {
+	if x != nil {
+		return x.CreatedById
+	}
+	return 0
+}
+
+func (x *APIKey) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetLastUsedAt() string {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateAPIKeyRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes             []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`                                                        // permission names, e.g. "books:write"
+	RateLimitPerMinute int32                  `protobuf:"varint,3,opt,name=rate_limit_per_minute,json=rateLimitPerMinute,proto3" json:"rate_limit_per_minute,omitempty"` // 0 uses the server default
+	ExpiresAt          string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                 // RFC3339, empty for no expiry
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetRateLimitPerMinute() int32 {
+	if x != nil {
+		return x.RateLimitPerMinute
+	}
+	return 0
+}
+
+func (x *CreateAPIKeyRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type CreateAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ApiKey        *APIKey                `protobuf:"bytes,3,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Key           string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"` // send as "x-api-key" metadata; shown only once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateAPIKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateAPIKeyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeRevoked bool                   `protobuf:"varint,3,opt,name=include_revoked,json=includeRevoked,proto3" json:"include_revoked,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListAPIKeysRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAPIKeysRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAPIKeysRequest) GetIncludeRevoked() bool {
+	if x != nil {
+		return x.IncludeRevoked
+	}
+	return false
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,3,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	CurrentPage   int32                  `protobuf:"varint,5,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,6,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrevious   bool                   `protobuf:"varint,8,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListAPIKeysResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListAPIKeysResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+func (x *ListAPIKeysResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListAPIKeysResponse) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *ListAPIKeysResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListAPIKeysResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *ListAPIKeysResponse) GetHasPrevious() bool {
+	if x != nil {
+		return x.HasPrevious
+	}
+	return false
+}
+
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RevokeAPIKeyRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RevokeAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyResponse) Reset() {
+	*x = RevokeAPIKeyResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyResponse) ProtoMessage() {}
+
+func (x *RevokeAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RevokeAPIKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RevokeAPIKeyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Audit messages
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId       uint32                 `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ActorEmail    string                 `protobuf:"bytes,3,opt,name=actor_email,json=actorEmail,proto3" json:"actor_email,omitempty"`
+	ApiKeyId      uint32                 `protobuf:"varint,4,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"` // 0 unless the actor used an API key
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`                        // e.g. book.update, order.update_status, user.disable
+	EntityType    string                 `protobuf:"bytes,6,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId      uint32                 `protobuf:"varint,7,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Before        string                 `protobuf:"bytes,8,opt,name=before,proto3" json:"before,omitempty"` // JSON of the changed fields before the change, empty for creations
+	After         string                 `protobuf:"bytes,9,opt,name=after,proto3" json:"after,omitempty"`   // JSON of the changed fields after the change, empty for deletions
+	RequestId     string                 `protobuf:"bytes,10,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_proto_bookstore_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *AuditEvent) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActorId() uint32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActorEmail() string {
+	if x != nil {
+		return x.ActorEmail
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetApiKeyId() uint32 {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() uint32 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	ActorId       uint32                 `protobuf:"varint,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	EntityType    string                 `protobuf:"bytes,5,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId      uint32                 `protobuf:"varint,6,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	From          string                 `protobuf:"bytes,8,opt,name=from,proto3" json:"from,omitempty"` // RFC3339, inclusive
+	To            string                 `protobuf:"bytes,9,opt,name=to,proto3" json:"to,omitempty"`     // RFC3339, exclusive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListAuditEventsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetActorId() uint32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityId() uint32 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Events        []*AuditEvent          `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	CurrentPage   int32                  `protobuf:"varint,5,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,6,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrevious   bool                   `protobuf:"varint,8,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListAuditEventsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListAuditEventsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListAuditEventsResponse) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *ListAuditEventsResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListAuditEventsResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+func (x *ListAuditEventsResponse) GetHasPrevious() bool {
+	if x != nil {
+		return x.HasPrevious
+	}
+	return false
+}
+
+// Address messages
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RecipientName string                 `protobuf:"bytes,2,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Street        string                 `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`
+	District      string                 `protobuf:"bytes,5,opt,name=district,proto3" json:"district,omitempty"`
+	City          string                 `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	Province      string                 `protobuf:"bytes,7,opt,name=province,proto3" json:"province,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,8,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,9,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_proto_bookstore_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *Address) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Address) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *Address) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *Address) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Address) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientName string                 `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	District      string                 `protobuf:"bytes,4,opt,name=district,proto3" json:"district,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	Province      string                 `protobuf:"bytes,6,opt,name=province,proto3" json:"province,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,8,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"` // the first address is always the default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAddressRequest) Reset() {
+	*x = CreateAddressRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAddressRequest) ProtoMessage() {}
+
+func (x *CreateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAddressRequest.ProtoReflect.Descriptor instead.
+func (*CreateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *CreateAddressRequest) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+type CreateAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Address       *Address               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAddressResponse) Reset() {
+	*x = CreateAddressResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAddressResponse) ProtoMessage() {}
+
+func (x *CreateAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAddressResponse.ProtoReflect.Descriptor instead.
+func (*CreateAddressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *CreateAddressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateAddressResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type ListAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesRequest) Reset() {
+	*x = ListAddressesRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesRequest) ProtoMessage() {}
+
+func (x *ListAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{83}
+}
+
+type ListAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Addresses     []*Address             `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"` // default address first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesResponse) Reset() {
+	*x = ListAddressesResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesResponse) ProtoMessage() {}
+
+func (x *ListAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ListAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ListAddressesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListAddressesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListAddressesResponse) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type GetAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressRequest) Reset() {
+	*x = GetAddressRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressRequest) ProtoMessage() {}
+
+func (x *GetAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetAddressRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Address       *Address               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressResponse) Reset() {
+	*x = GetAddressResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressResponse) ProtoMessage() {}
+
+func (x *GetAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressResponse.ProtoReflect.Descriptor instead.
+func (*GetAddressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GetAddressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetAddressResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type UpdateAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RecipientName string                 `protobuf:"bytes,2,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Street        string                 `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`
+	District      string                 `protobuf:"bytes,5,opt,name=district,proto3" json:"district,omitempty"`
+	City          string                 `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	Province      string                 `protobuf:"bytes,7,opt,name=province,proto3" json:"province,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,8,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,9,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"` // false keeps the current default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAddressRequest) Reset() {
+	*x = UpdateAddressRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAddressRequest) ProtoMessage() {}
+
+func (x *UpdateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAddressRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *UpdateAddressRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateAddressRequest) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+type UpdateAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Address       *Address               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAddressResponse) Reset() {
+	*x = UpdateAddressResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAddressResponse) ProtoMessage() {}
+
+func (x *UpdateAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAddressResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAddressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *UpdateAddressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateAddressResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type DeleteAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAddressRequest) Reset() {
+	*x = DeleteAddressRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressRequest) ProtoMessage() {}
+
+func (x *DeleteAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *DeleteAddressRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAddressResponse) Reset() {
+	*x = DeleteAddressResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressResponse) ProtoMessage() {}
+
+func (x *DeleteAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAddressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *DeleteAddressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteAddressResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Category messages
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_proto_bookstore_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *Category) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Category) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type CreateCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
+func (x *CreateCategoryRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CreateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Category      *Category              `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryResponse) Reset() {
+	*x = CreateCategoryResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *CreateCategoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateCategoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type GetCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoriesRequest) Reset() {
+	*x = GetCategoriesRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoriesRequest) ProtoMessage() {}
+
+func (x *GetCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *GetCategoriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetCategoriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Categories    []*Category            `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	CurrentPage   int32                  `protobuf:"varint,5,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,6,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrevious   bool                   `protobuf:"varint,8,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoriesResponse) Reset() {
+	*x = GetCategoriesResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoriesResponse) ProtoMessage() {}
+
+func (x *GetCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetCategoriesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetCategoriesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *GetCategoriesResponse) GetTotal() int32 {
 	if x != nil {
 		return x.Total
 	}
@@ -792,7 +5981,7 @@ type GetCategoryRequest struct {
 
 func (x *GetCategoryRequest) Reset() {
 	*x = GetCategoryRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[12]
+	mi := &file_proto_bookstore_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -804,7 +5993,7 @@ func (x *GetCategoryRequest) String() string {
 func (*GetCategoryRequest) ProtoMessage() {}
 
 func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[12]
+	mi := &file_proto_bookstore_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -817,7 +6006,7 @@ func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
 func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{12}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *GetCategoryRequest) GetId() uint32 {
@@ -838,7 +6027,7 @@ type GetCategoryResponse struct {
 
 func (x *GetCategoryResponse) Reset() {
 	*x = GetCategoryResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[13]
+	mi := &file_proto_bookstore_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -850,7 +6039,7 @@ func (x *GetCategoryResponse) String() string {
 func (*GetCategoryResponse) ProtoMessage() {}
 
 func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[13]
+	mi := &file_proto_bookstore_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -863,7 +6052,7 @@ func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCategoryResponse.ProtoReflect.Descriptor instead.
 func (*GetCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{13}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *GetCategoryResponse) GetSuccess() bool {
@@ -888,17 +6077,18 @@ func (x *GetCategoryResponse) GetCategory() *Category {
 }
 
 type UpdateCategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateCategoryRequest) Reset() {
 	*x = UpdateCategoryRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[14]
+	mi := &file_proto_bookstore_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -910,7 +6100,7 @@ func (x *UpdateCategoryRequest) String() string {
 func (*UpdateCategoryRequest) ProtoMessage() {}
 
 func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[14]
+	mi := &file_proto_bookstore_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -923,7 +6113,7 @@ func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{14}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *UpdateCategoryRequest) GetId() uint32 {
@@ -940,6 +6130,7 @@ func (x *UpdateCategoryRequest) GetName() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *UpdateCategoryRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -958,7 +6149,7 @@ type UpdateCategoryResponse struct {
 
 func (x *UpdateCategoryResponse) Reset() {
 	*x = UpdateCategoryResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[15]
+	mi := &file_proto_bookstore_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -970,7 +6161,7 @@ func (x *UpdateCategoryResponse) String() string {
 func (*UpdateCategoryResponse) ProtoMessage() {}
 
 func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[15]
+	mi := &file_proto_bookstore_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -983,7 +6174,7 @@ func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{15}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *UpdateCategoryResponse) GetSuccess() bool {
@@ -1008,16 +6199,17 @@ func (x *UpdateCategoryResponse) GetCategory() *Category {
 }
 
 type DeleteCategoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteCategoryRequest) Reset() {
 	*x = DeleteCategoryRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[16]
+	mi := &file_proto_bookstore_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1029,7 +6221,7 @@ func (x *DeleteCategoryRequest) String() string {
 func (*DeleteCategoryRequest) ProtoMessage() {}
 
 func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[16]
+	mi := &file_proto_bookstore_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1042,7 +6234,7 @@ func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{16}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{100}
 }
 
 func (x *DeleteCategoryRequest) GetId() uint32 {
@@ -1052,6 +6244,7 @@ func (x *DeleteCategoryRequest) GetId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *DeleteCategoryRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -1069,7 +6262,7 @@ type DeleteCategoryResponse struct {
 
 func (x *DeleteCategoryResponse) Reset() {
 	*x = DeleteCategoryResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[17]
+	mi := &file_proto_bookstore_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1081,7 +6274,7 @@ func (x *DeleteCategoryResponse) String() string {
 func (*DeleteCategoryResponse) ProtoMessage() {}
 
 func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[17]
+	mi := &file_proto_bookstore_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1094,7 +6287,7 @@ func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
 func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{17}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *DeleteCategoryResponse) GetSuccess() bool {
@@ -1113,25 +6306,33 @@ func (x *DeleteCategoryResponse) GetMessage() string {
 
 // Book messages
 type Book struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
-	Year          int32                  `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
-	CategoryId    uint32                 `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	ImageBase64   string                 `protobuf:"bytes,8,opt,name=image_base64,json=imageBase64,proto3" json:"image_base64,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Category      *Category              `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title      string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Author     string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Price      float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Stock      int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	Year       int32                  `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
+	CategoryId uint32                 `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	ImageBase64  string    `protobuf:"bytes,8,opt,name=image_base64,json=imageBase64,proto3" json:"image_base64,omitempty"` // no longer populated, use cover_url
+	CreatedAt    string    `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    string    `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Category     *Category `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
+	Isbn         string    `protobuf:"bytes,12,opt,name=isbn,proto3" json:"isbn,omitempty"`                                     // normalized ISBN-13, empty if unknown
+	CoverUrl     string    `protobuf:"bytes,13,opt,name=cover_url,json=coverUrl,proto3" json:"cover_url,omitempty"`             // empty if the book has no cover
+	ThumbnailUrl string    `protobuf:"bytes,14,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"` // small JPEG version of the cover for lists
+	Description  string    `protobuf:"bytes,15,opt,name=description,proto3" json:"description,omitempty"`
+	// Matching parts of the book with matches wrapped in <mark> tags, HTML-escaped
+	// otherwise; only set by GetBooks in fulltext search mode
+	SearchHighlight string `protobuf:"bytes,16,opt,name=search_highlight,json=searchHighlight,proto3" json:"search_highlight,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Book) Reset() {
 	*x = Book{}
-	mi := &file_proto_bookstore_proto_msgTypes[18]
+	mi := &file_proto_bookstore_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1143,7 +6344,7 @@ func (x *Book) String() string {
 func (*Book) ProtoMessage() {}
 
 func (x *Book) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[18]
+	mi := &file_proto_bookstore_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1156,7 +6357,7 @@ func (x *Book) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Book.ProtoReflect.Descriptor instead.
 func (*Book) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{18}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{102}
 }
 
 func (x *Book) GetId() uint32 {
@@ -1205,54 +6406,94 @@ func (x *Book) GetCategoryId() uint32 {
 	if x != nil {
 		return x.CategoryId
 	}
-	return 0
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
+func (x *Book) GetImageBase64() string {
+	if x != nil {
+		return x.ImageBase64
+	}
+	return ""
+}
+
+func (x *Book) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Book) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Book) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
 }
 
-func (x *Book) GetImageBase64() string {
+func (x *Book) GetCoverUrl() string {
 	if x != nil {
-		return x.ImageBase64
+		return x.CoverUrl
 	}
 	return ""
 }
 
-func (x *Book) GetCreatedAt() string {
+func (x *Book) GetThumbnailUrl() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.ThumbnailUrl
 	}
 	return ""
 }
 
-func (x *Book) GetUpdatedAt() string {
+func (x *Book) GetDescription() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Description
 	}
 	return ""
 }
 
-func (x *Book) GetCategory() *Category {
+func (x *Book) GetSearchHighlight() string {
 	if x != nil {
-		return x.Category
+		return x.SearchHighlight
 	}
-	return nil
+	return ""
 }
 
 type CreateBookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
-	Year          int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
-	CategoryId    uint32                 `protobuf:"varint,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	ImageBase64   string                 `protobuf:"bytes,7,opt,name=image_base64,json=imageBase64,proto3" json:"image_base64,omitempty"`
-	Token         string                 `protobuf:"bytes,8,opt,name=token,proto3" json:"token,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Title      string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Author     string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Price      float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Stock      int32                  `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	Year       int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
+	CategoryId uint32                 `protobuf:"varint,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	ImageBase64 string `protobuf:"bytes,7,opt,name=image_base64,json=imageBase64,proto3" json:"image_base64,omitempty"` // use UploadBookCover instead
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,8,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	Isbn          string `protobuf:"bytes,9,opt,name=isbn,proto3" json:"isbn,omitempty"`   // ISBN-10 or ISBN-13, hyphens allowed; stored as ISBN-13
+	Description   string `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateBookRequest) Reset() {
 	*x = CreateBookRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[19]
+	mi := &file_proto_bookstore_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1264,7 +6505,7 @@ func (x *CreateBookRequest) String() string {
 func (*CreateBookRequest) ProtoMessage() {}
 
 func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[19]
+	mi := &file_proto_bookstore_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1277,7 +6518,7 @@ func (x *CreateBookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBookRequest.ProtoReflect.Descriptor instead.
 func (*CreateBookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{19}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{103}
 }
 
 func (x *CreateBookRequest) GetTitle() string {
@@ -1322,6 +6563,7 @@ func (x *CreateBookRequest) GetCategoryId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *CreateBookRequest) GetImageBase64() string {
 	if x != nil {
 		return x.ImageBase64
@@ -1329,6 +6571,7 @@ func (x *CreateBookRequest) GetImageBase64() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *CreateBookRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -1336,6 +6579,20 @@ func (x *CreateBookRequest) GetToken() string {
 	return ""
 }
 
+func (x *CreateBookRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *CreateBookRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
 type CreateBookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -1347,7 +6604,7 @@ type CreateBookResponse struct {
 
 func (x *CreateBookResponse) Reset() {
 	*x = CreateBookResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[20]
+	mi := &file_proto_bookstore_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1359,7 +6616,7 @@ func (x *CreateBookResponse) String() string {
 func (*CreateBookResponse) ProtoMessage() {}
 
 func (x *CreateBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[20]
+	mi := &file_proto_bookstore_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1372,7 +6629,7 @@ func (x *CreateBookResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBookResponse.ProtoReflect.Descriptor instead.
 func (*CreateBookResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{20}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{104}
 }
 
 func (x *CreateBookResponse) GetSuccess() bool {
@@ -1397,17 +6654,21 @@ func (x *CreateBookResponse) GetBook() *Book {
 }
 
 type GetBooksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Search        string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Page   int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Search string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	// "fulltext" (default): words or word prefixes in the title, author, category
+	// or description, most relevant first. "substring": any part of the title or
+	// author, case-insensitive.
+	SearchMode    string `protobuf:"bytes,4,opt,name=search_mode,json=searchMode,proto3" json:"search_mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBooksRequest) Reset() {
 	*x = GetBooksRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[21]
+	mi := &file_proto_bookstore_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1419,7 +6680,7 @@ func (x *GetBooksRequest) String() string {
 func (*GetBooksRequest) ProtoMessage() {}
 
 func (x *GetBooksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[21]
+	mi := &file_proto_bookstore_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1432,7 +6693,7 @@ func (x *GetBooksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBooksRequest.ProtoReflect.Descriptor instead.
 func (*GetBooksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{21}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{105}
 }
 
 func (x *GetBooksRequest) GetPage() int32 {
@@ -1456,23 +6717,33 @@ func (x *GetBooksRequest) GetSearch() string {
 	return ""
 }
 
+func (x *GetBooksRequest) GetSearchMode() string {
+	if x != nil {
+		return x.SearchMode
+	}
+	return ""
+}
+
 type GetBooksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Books         []*Book                `protobuf:"bytes,3,rep,name=books,proto3" json:"books,omitempty"`
-	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
-	CurrentPage   int32                  `protobuf:"varint,5,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
-	TotalPages    int32                  `protobuf:"varint,6,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
-	HasNext       bool                   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
-	HasPrevious   bool                   `protobuf:"varint,8,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Success     bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message     string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Books       []*Book                `protobuf:"bytes,3,rep,name=books,proto3" json:"books,omitempty"`
+	Total       int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	CurrentPage int32                  `protobuf:"varint,5,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages  int32                  `protobuf:"varint,6,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext     bool                   `protobuf:"varint,7,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	HasPrevious bool                   `protobuf:"varint,8,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
+	// Set when nothing matched the search and books with a similar title or
+	// author are returned instead: the closest title or author
+	DidYouMean    string `protobuf:"bytes,9,opt,name=did_you_mean,json=didYouMean,proto3" json:"did_you_mean,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBooksResponse) Reset() {
 	*x = GetBooksResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[22]
+	mi := &file_proto_bookstore_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1484,7 +6755,7 @@ func (x *GetBooksResponse) String() string {
 func (*GetBooksResponse) ProtoMessage() {}
 
 func (x *GetBooksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[22]
+	mi := &file_proto_bookstore_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1497,7 +6768,7 @@ func (x *GetBooksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBooksResponse.ProtoReflect.Descriptor instead.
 func (*GetBooksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{22}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{106}
 }
 
 func (x *GetBooksResponse) GetSuccess() bool {
@@ -1556,6 +6827,13 @@ func (x *GetBooksResponse) GetHasPrevious() bool {
 	return false
 }
 
+func (x *GetBooksResponse) GetDidYouMean() string {
+	if x != nil {
+		return x.DidYouMean
+	}
+	return ""
+}
+
 type GetBookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1565,7 +6843,7 @@ type GetBookRequest struct {
 
 func (x *GetBookRequest) Reset() {
 	*x = GetBookRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[23]
+	mi := &file_proto_bookstore_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1577,7 +6855,7 @@ func (x *GetBookRequest) String() string {
 func (*GetBookRequest) ProtoMessage() {}
 
 func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[23]
+	mi := &file_proto_bookstore_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1590,7 +6868,7 @@ func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
 func (*GetBookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{23}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{107}
 }
 
 func (x *GetBookRequest) GetId() uint32 {
@@ -1611,7 +6889,7 @@ type GetBookResponse struct {
 
 func (x *GetBookResponse) Reset() {
 	*x = GetBookResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[24]
+	mi := &file_proto_bookstore_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1623,7 +6901,7 @@ func (x *GetBookResponse) String() string {
 func (*GetBookResponse) ProtoMessage() {}
 
 func (x *GetBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[24]
+	mi := &file_proto_bookstore_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,7 +6914,7 @@ func (x *GetBookResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBookResponse.ProtoReflect.Descriptor instead.
 func (*GetBookResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{24}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{108}
 }
 
 func (x *GetBookResponse) GetSuccess() bool {
@@ -1660,24 +6938,132 @@ func (x *GetBookResponse) GetBook() *Book {
 	return nil
 }
 
-type UpdateBookRequest struct {
+type GetBookByISBNRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
-	Year          int32                  `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
-	CategoryId    uint32                 `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	ImageBase64   string                 `protobuf:"bytes,8,opt,name=image_base64,json=imageBase64,proto3" json:"image_base64,omitempty"`
-	Token         string                 `protobuf:"bytes,9,opt,name=token,proto3" json:"token,omitempty"`
+	Isbn          string                 `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"` // ISBN-10 or ISBN-13, hyphens allowed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookByISBNRequest) Reset() {
+	*x = GetBookByISBNRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookByISBNRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookByISBNRequest) ProtoMessage() {}
+
+func (x *GetBookByISBNRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookByISBNRequest.ProtoReflect.Descriptor instead.
+func (*GetBookByISBNRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *GetBookByISBNRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+type GetBookByISBNResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Book          *Book                  `protobuf:"bytes,3,opt,name=book,proto3" json:"book,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookByISBNResponse) Reset() {
+	*x = GetBookByISBNResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookByISBNResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookByISBNResponse) ProtoMessage() {}
+
+func (x *GetBookByISBNResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookByISBNResponse.ProtoReflect.Descriptor instead.
+func (*GetBookByISBNResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *GetBookByISBNResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetBookByISBNResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetBookByISBNResponse) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+type UpdateBookRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title      string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Author     string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Price      float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Stock      int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	Year       int32                  `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
+	CategoryId uint32                 `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	ImageBase64 string `protobuf:"bytes,8,opt,name=image_base64,json=imageBase64,proto3" json:"image_base64,omitempty"` // use UploadBookCover instead; empty keeps the cover
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,9,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	Isbn          string `protobuf:"bytes,10,opt,name=isbn,proto3" json:"isbn,omitempty"`  // ISBN-10 or ISBN-13, hyphens allowed; empty removes the ISBN
+	Description   string `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateBookRequest) Reset() {
 	*x = UpdateBookRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[25]
+	mi := &file_proto_bookstore_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1689,7 +7075,7 @@ func (x *UpdateBookRequest) String() string {
 func (*UpdateBookRequest) ProtoMessage() {}
 
 func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[25]
+	mi := &file_proto_bookstore_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1702,7 +7088,7 @@ func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
 func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{25}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{111}
 }
 
 func (x *UpdateBookRequest) GetId() uint32 {
@@ -1754,6 +7140,7 @@ func (x *UpdateBookRequest) GetCategoryId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *UpdateBookRequest) GetImageBase64() string {
 	if x != nil {
 		return x.ImageBase64
@@ -1761,6 +7148,7 @@ func (x *UpdateBookRequest) GetImageBase64() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *UpdateBookRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -1768,7 +7156,165 @@ func (x *UpdateBookRequest) GetToken() string {
 	return ""
 }
 
-type UpdateBookResponse struct {
+func (x *UpdateBookRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *UpdateBookRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type UpdateBookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Book          *Book                  `protobuf:"bytes,3,opt,name=book,proto3" json:"book,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookResponse) Reset() {
+	*x = UpdateBookResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookResponse) ProtoMessage() {}
+
+func (x *UpdateBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *UpdateBookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateBookResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateBookResponse) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+// UploadBookCoverRequest is streamed by the client: the first message carries
+// the book ID, the following ones the image (JPEG, PNG or WebP) in chunks
+type UploadBookCoverRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadBookCoverRequest_BookId
+	//	*UploadBookCoverRequest_Chunk
+	Data          isUploadBookCoverRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadBookCoverRequest) Reset() {
+	*x = UploadBookCoverRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadBookCoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadBookCoverRequest) ProtoMessage() {}
+
+func (x *UploadBookCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadBookCoverRequest.ProtoReflect.Descriptor instead.
+func (*UploadBookCoverRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *UploadBookCoverRequest) GetData() isUploadBookCoverRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadBookCoverRequest) GetBookId() uint32 {
+	if x != nil {
+		if x, ok := x.Data.(*UploadBookCoverRequest_BookId); ok {
+			return x.BookId
+		}
+	}
+	return 0
+}
+
+func (x *UploadBookCoverRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadBookCoverRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadBookCoverRequest_Data interface {
+	isUploadBookCoverRequest_Data()
+}
+
+type UploadBookCoverRequest_BookId struct {
+	BookId uint32 `protobuf:"varint,1,opt,name=book_id,json=bookId,proto3,oneof"`
+}
+
+type UploadBookCoverRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadBookCoverRequest_BookId) isUploadBookCoverRequest_Data() {}
+
+func (*UploadBookCoverRequest_Chunk) isUploadBookCoverRequest_Data() {}
+
+type UploadBookCoverResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -1777,21 +7323,21 @@ type UpdateBookResponse struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateBookResponse) Reset() {
-	*x = UpdateBookResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[26]
+func (x *UploadBookCoverResponse) Reset() {
+	*x = UploadBookCoverResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateBookResponse) String() string {
+func (x *UploadBookCoverResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBookResponse) ProtoMessage() {}
+func (*UploadBookCoverResponse) ProtoMessage() {}
 
-func (x *UpdateBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[26]
+func (x *UploadBookCoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1802,26 +7348,26 @@ func (x *UpdateBookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBookResponse.ProtoReflect.Descriptor instead.
-func (*UpdateBookResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use UploadBookCoverResponse.ProtoReflect.Descriptor instead.
+func (*UploadBookCoverResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *UpdateBookResponse) GetSuccess() bool {
+func (x *UploadBookCoverResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *UpdateBookResponse) GetMessage() string {
+func (x *UploadBookCoverResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *UpdateBookResponse) GetBook() *Book {
+func (x *UploadBookCoverResponse) GetBook() *Book {
 	if x != nil {
 		return x.Book
 	}
@@ -1829,16 +7375,17 @@ func (x *UpdateBookResponse) GetBook() *Book {
 }
 
 type DeleteBookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteBookRequest) Reset() {
 	*x = DeleteBookRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[27]
+	mi := &file_proto_bookstore_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1850,7 +7397,7 @@ func (x *DeleteBookRequest) String() string {
 func (*DeleteBookRequest) ProtoMessage() {}
 
 func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[27]
+	mi := &file_proto_bookstore_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1863,7 +7410,7 @@ func (x *DeleteBookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBookRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBookRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{27}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{115}
 }
 
 func (x *DeleteBookRequest) GetId() uint32 {
@@ -1873,6 +7420,7 @@ func (x *DeleteBookRequest) GetId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *DeleteBookRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -1890,7 +7438,7 @@ type DeleteBookResponse struct {
 
 func (x *DeleteBookResponse) Reset() {
 	*x = DeleteBookResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[28]
+	mi := &file_proto_bookstore_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1902,7 +7450,7 @@ func (x *DeleteBookResponse) String() string {
 func (*DeleteBookResponse) ProtoMessage() {}
 
 func (x *DeleteBookResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[28]
+	mi := &file_proto_bookstore_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,7 +7463,7 @@ func (x *DeleteBookResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBookResponse.ProtoReflect.Descriptor instead.
 func (*DeleteBookResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{28}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{116}
 }
 
 func (x *DeleteBookResponse) GetSuccess() bool {
@@ -1943,7 +7491,7 @@ type GetBooksByCategoryRequest struct {
 
 func (x *GetBooksByCategoryRequest) Reset() {
 	*x = GetBooksByCategoryRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[29]
+	mi := &file_proto_bookstore_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1955,7 +7503,7 @@ func (x *GetBooksByCategoryRequest) String() string {
 func (*GetBooksByCategoryRequest) ProtoMessage() {}
 
 func (x *GetBooksByCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[29]
+	mi := &file_proto_bookstore_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1968,7 +7516,7 @@ func (x *GetBooksByCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBooksByCategoryRequest.ProtoReflect.Descriptor instead.
 func (*GetBooksByCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{29}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{117}
 }
 
 func (x *GetBooksByCategoryRequest) GetCategoryId() uint32 {
@@ -2008,7 +7556,7 @@ type GetBooksByCategoryResponse struct {
 
 func (x *GetBooksByCategoryResponse) Reset() {
 	*x = GetBooksByCategoryResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[30]
+	mi := &file_proto_bookstore_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2020,7 +7568,7 @@ func (x *GetBooksByCategoryResponse) String() string {
 func (*GetBooksByCategoryResponse) ProtoMessage() {}
 
 func (x *GetBooksByCategoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[30]
+	mi := &file_proto_bookstore_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2033,7 +7581,7 @@ func (x *GetBooksByCategoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBooksByCategoryResponse.ProtoReflect.Descriptor instead.
 func (*GetBooksByCategoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{30}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{118}
 }
 
 func (x *GetBooksByCategoryResponse) GetSuccess() bool {
@@ -2092,6 +7640,190 @@ func (x *GetBooksByCategoryResponse) GetHasPrevious() bool {
 	return false
 }
 
+type SuggestBooksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Default 5, at most 10
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestBooksRequest) Reset() {
+	*x = SuggestBooksRequest{}
+	mi := &file_proto_bookstore_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestBooksRequest) ProtoMessage() {}
+
+func (x *SuggestBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestBooksRequest.ProtoReflect.Descriptor instead.
+func (*SuggestBooksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *SuggestBooksRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestBooksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type BookSuggestion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// "title" or "author"
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	// The book with this title, 0 for authors
+	BookId        uint32 `protobuf:"varint,3,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookSuggestion) Reset() {
+	*x = BookSuggestion{}
+	mi := &file_proto_bookstore_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookSuggestion) ProtoMessage() {}
+
+func (x *BookSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookSuggestion.ProtoReflect.Descriptor instead.
+func (*BookSuggestion) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *BookSuggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *BookSuggestion) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *BookSuggestion) GetBookId() uint32 {
+	if x != nil {
+		return x.BookId
+	}
+	return 0
+}
+
+type SuggestBooksResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Success     bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message     string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Suggestions []*BookSuggestion      `protobuf:"bytes,3,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	// Set when there are no suggestions: the title or author closest to prefix
+	DidYouMean    string `protobuf:"bytes,4,opt,name=did_you_mean,json=didYouMean,proto3" json:"did_you_mean,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestBooksResponse) Reset() {
+	*x = SuggestBooksResponse{}
+	mi := &file_proto_bookstore_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestBooksResponse) ProtoMessage() {}
+
+func (x *SuggestBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestBooksResponse.ProtoReflect.Descriptor instead.
+func (*SuggestBooksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *SuggestBooksResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SuggestBooksResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SuggestBooksResponse) GetSuggestions() []*BookSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *SuggestBooksResponse) GetDidYouMean() string {
+	if x != nil {
+		return x.DidYouMean
+	}
+	return ""
+}
+
 // Order messages
 type OrderItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2106,7 +7838,7 @@ type OrderItem struct {
 
 func (x *OrderItem) Reset() {
 	*x = OrderItem{}
-	mi := &file_proto_bookstore_proto_msgTypes[31]
+	mi := &file_proto_bookstore_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2118,7 +7850,7 @@ func (x *OrderItem) String() string {
 func (*OrderItem) ProtoMessage() {}
 
 func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[31]
+	mi := &file_proto_bookstore_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2131,7 +7863,7 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
 func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{31}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{122}
 }
 
 func (x *OrderItem) GetId() uint32 {
@@ -2159,46 +7891,160 @@ func (x *OrderItem) GetPrice() float64 {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return 0
+}
+
+func (x *OrderItem) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+type Order struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TotalPrice      float64                `protobuf:"fixed64,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	PaymentUrl      string                 `protobuf:"bytes,5,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	User            *User                  `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+	ShippingAddress *ShippingAddress       `protobuf:"bytes,10,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"` // copy of the address taken when the order was placed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_proto_bookstore_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *Order) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Order) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Order) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetPaymentUrl() string {
+	if x != nil {
+		return x.PaymentUrl
+	}
+	return ""
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
 }
 
-func (x *OrderItem) GetBook() *Book {
+func (x *Order) GetShippingAddress() *ShippingAddress {
 	if x != nil {
-		return x.Book
+		return x.ShippingAddress
 	}
 	return nil
 }
 
-type Order struct {
+type ShippingAddress struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,3,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	PaymentUrl    string                 `protobuf:"bytes,5,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	User          *User                  `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+	RecipientName string                 `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	District      string                 `protobuf:"bytes,4,opt,name=district,proto3" json:"district,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	Province      string                 `protobuf:"bytes,6,opt,name=province,proto3" json:"province,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_proto_bookstore_proto_msgTypes[32]
+func (x *ShippingAddress) Reset() {
+	*x = ShippingAddress{}
+	mi := &file_proto_bookstore_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
+func (x *ShippingAddress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order) ProtoMessage() {}
+func (*ShippingAddress) ProtoMessage() {}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[32]
+func (x *ShippingAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bookstore_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2209,85 +8055,73 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *Order) GetId() uint32 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Order) GetUserId() uint32 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
+// Deprecated: Use ShippingAddress.ProtoReflect.Descriptor instead.
+func (*ShippingAddress) Descriptor() ([]byte, []int) {
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *Order) GetTotalPrice() float64 {
+func (x *ShippingAddress) GetRecipientName() string {
 	if x != nil {
-		return x.TotalPrice
+		return x.RecipientName
 	}
-	return 0
+	return ""
 }
 
-func (x *Order) GetStatus() string {
+func (x *ShippingAddress) GetPhone() string {
 	if x != nil {
-		return x.Status
+		return x.Phone
 	}
 	return ""
 }
 
-func (x *Order) GetPaymentUrl() string {
+func (x *ShippingAddress) GetStreet() string {
 	if x != nil {
-		return x.PaymentUrl
+		return x.Street
 	}
 	return ""
 }
 
-func (x *Order) GetCreatedAt() string {
+func (x *ShippingAddress) GetDistrict() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.District
 	}
 	return ""
 }
 
-func (x *Order) GetUpdatedAt() string {
+func (x *ShippingAddress) GetCity() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.City
 	}
 	return ""
 }
 
-func (x *Order) GetUser() *User {
+func (x *ShippingAddress) GetProvince() string {
 	if x != nil {
-		return x.User
+		return x.Province
 	}
-	return nil
+	return ""
 }
 
-func (x *Order) GetItems() []*OrderItem {
+func (x *ShippingAddress) GetPostalCode() string {
 	if x != nil {
-		return x.Items
+		return x.PostalCode
 	}
-	return nil
+	return ""
 }
 
 type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*OrderItemRequest    `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*OrderItemRequest    `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                           // send "authorization: Bearer <token>" metadata instead
+	AddressId     uint32 `protobuf:"varint,3,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"` // 0 uses the default address
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
 	*x = CreateOrderRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[33]
+	mi := &file_proto_bookstore_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2299,7 +8133,7 @@ func (x *CreateOrderRequest) String() string {
 func (*CreateOrderRequest) ProtoMessage() {}
 
 func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[33]
+	mi := &file_proto_bookstore_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2312,7 +8146,7 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{33}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{125}
 }
 
 func (x *CreateOrderRequest) GetItems() []*OrderItemRequest {
@@ -2322,6 +8156,7 @@ func (x *CreateOrderRequest) GetItems() []*OrderItemRequest {
 	return nil
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *CreateOrderRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -2329,6 +8164,13 @@ func (x *CreateOrderRequest) GetToken() string {
 	return ""
 }
 
+func (x *CreateOrderRequest) GetAddressId() uint32 {
+	if x != nil {
+		return x.AddressId
+	}
+	return 0
+}
+
 type OrderItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BookId        uint32                 `protobuf:"varint,1,opt,name=book_id,json=bookId,proto3" json:"book_id,omitempty"`
@@ -2339,7 +8181,7 @@ type OrderItemRequest struct {
 
 func (x *OrderItemRequest) Reset() {
 	*x = OrderItemRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[34]
+	mi := &file_proto_bookstore_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2351,7 +8193,7 @@ func (x *OrderItemRequest) String() string {
 func (*OrderItemRequest) ProtoMessage() {}
 
 func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[34]
+	mi := &file_proto_bookstore_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2364,7 +8206,7 @@ func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderItemRequest.ProtoReflect.Descriptor instead.
 func (*OrderItemRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{34}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{126}
 }
 
 func (x *OrderItemRequest) GetBookId() uint32 {
@@ -2392,7 +8234,7 @@ type CreateOrderResponse struct {
 
 func (x *CreateOrderResponse) Reset() {
 	*x = CreateOrderResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[35]
+	mi := &file_proto_bookstore_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2404,7 +8246,7 @@ func (x *CreateOrderResponse) String() string {
 func (*CreateOrderResponse) ProtoMessage() {}
 
 func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[35]
+	mi := &file_proto_bookstore_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2417,7 +8259,7 @@ func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
 func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{35}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{127}
 }
 
 func (x *CreateOrderResponse) GetSuccess() bool {
@@ -2442,17 +8284,18 @@ func (x *CreateOrderResponse) GetOrder() *Order {
 }
 
 type GetOrdersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
+	Page          int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetOrdersRequest) Reset() {
 	*x = GetOrdersRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[36]
+	mi := &file_proto_bookstore_proto_msgTypes[128]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2464,7 +8307,7 @@ func (x *GetOrdersRequest) String() string {
 func (*GetOrdersRequest) ProtoMessage() {}
 
 func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[36]
+	mi := &file_proto_bookstore_proto_msgTypes[128]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2477,9 +8320,10 @@ func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
 func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{36}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{128}
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *GetOrdersRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -2517,7 +8361,7 @@ type GetOrdersResponse struct {
 
 func (x *GetOrdersResponse) Reset() {
 	*x = GetOrdersResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[37]
+	mi := &file_proto_bookstore_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2529,7 +8373,7 @@ func (x *GetOrdersResponse) String() string {
 func (*GetOrdersResponse) ProtoMessage() {}
 
 func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[37]
+	mi := &file_proto_bookstore_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2542,7 +8386,7 @@ func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
 func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{37}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{129}
 }
 
 func (x *GetOrdersResponse) GetSuccess() bool {
@@ -2602,16 +8446,17 @@ func (x *GetOrdersResponse) GetHasPrevious() bool {
 }
 
 type GetOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[38]
+	mi := &file_proto_bookstore_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2623,7 +8468,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[38]
+	mi := &file_proto_bookstore_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2636,7 +8481,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{38}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{130}
 }
 
 func (x *GetOrderRequest) GetId() uint32 {
@@ -2646,6 +8491,7 @@ func (x *GetOrderRequest) GetId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *GetOrderRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -2664,7 +8510,7 @@ type GetOrderResponse struct {
 
 func (x *GetOrderResponse) Reset() {
 	*x = GetOrderResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[39]
+	mi := &file_proto_bookstore_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2676,7 +8522,7 @@ func (x *GetOrderResponse) String() string {
 func (*GetOrderResponse) ProtoMessage() {}
 
 func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[39]
+	mi := &file_proto_bookstore_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2689,7 +8535,7 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
 func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{39}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{131}
 }
 
 func (x *GetOrderResponse) GetSuccess() bool {
@@ -2714,17 +8560,18 @@ func (x *GetOrderResponse) GetOrder() *Order {
 }
 
 type UpdateOrderStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateOrderStatusRequest) Reset() {
 	*x = UpdateOrderStatusRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[40]
+	mi := &file_proto_bookstore_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2736,7 +8583,7 @@ func (x *UpdateOrderStatusRequest) String() string {
 func (*UpdateOrderStatusRequest) ProtoMessage() {}
 
 func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[40]
+	mi := &file_proto_bookstore_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2749,7 +8596,7 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{40}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{132}
 }
 
 func (x *UpdateOrderStatusRequest) GetId() uint32 {
@@ -2766,6 +8613,7 @@ func (x *UpdateOrderStatusRequest) GetStatus() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *UpdateOrderStatusRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -2784,7 +8632,7 @@ type UpdateOrderStatusResponse struct {
 
 func (x *UpdateOrderStatusResponse) Reset() {
 	*x = UpdateOrderStatusResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[41]
+	mi := &file_proto_bookstore_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2796,7 +8644,7 @@ func (x *UpdateOrderStatusResponse) String() string {
 func (*UpdateOrderStatusResponse) ProtoMessage() {}
 
 func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[41]
+	mi := &file_proto_bookstore_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2809,7 +8657,7 @@ func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{41}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{133}
 }
 
 func (x *UpdateOrderStatusResponse) GetSuccess() bool {
@@ -2834,16 +8682,17 @@ func (x *UpdateOrderStatusResponse) GetOrder() *Order {
 }
 
 type ProcessPaymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       uint32                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId uint32                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProcessPaymentRequest) Reset() {
 	*x = ProcessPaymentRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[42]
+	mi := &file_proto_bookstore_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2855,7 +8704,7 @@ func (x *ProcessPaymentRequest) String() string {
 func (*ProcessPaymentRequest) ProtoMessage() {}
 
 func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[42]
+	mi := &file_proto_bookstore_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2868,7 +8717,7 @@ func (x *ProcessPaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentRequest.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{42}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{134}
 }
 
 func (x *ProcessPaymentRequest) GetOrderId() uint32 {
@@ -2878,6 +8727,7 @@ func (x *ProcessPaymentRequest) GetOrderId() uint32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *ProcessPaymentRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -2896,7 +8746,7 @@ type ProcessPaymentResponse struct {
 
 func (x *ProcessPaymentResponse) Reset() {
 	*x = ProcessPaymentResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[43]
+	mi := &file_proto_bookstore_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2908,7 +8758,7 @@ func (x *ProcessPaymentResponse) String() string {
 func (*ProcessPaymentResponse) ProtoMessage() {}
 
 func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[43]
+	mi := &file_proto_bookstore_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2921,7 +8771,7 @@ func (x *ProcessPaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessPaymentResponse.ProtoReflect.Descriptor instead.
 func (*ProcessPaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{43}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{135}
 }
 
 func (x *ProcessPaymentResponse) GetSuccess() bool {
@@ -2957,7 +8807,7 @@ type SalesReportItem struct {
 
 func (x *SalesReportItem) Reset() {
 	*x = SalesReportItem{}
-	mi := &file_proto_bookstore_proto_msgTypes[44]
+	mi := &file_proto_bookstore_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2969,7 +8819,7 @@ func (x *SalesReportItem) String() string {
 func (*SalesReportItem) ProtoMessage() {}
 
 func (x *SalesReportItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[44]
+	mi := &file_proto_bookstore_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2982,7 +8832,7 @@ func (x *SalesReportItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SalesReportItem.ProtoReflect.Descriptor instead.
 func (*SalesReportItem) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{44}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{136}
 }
 
 func (x *SalesReportItem) GetDate() string {
@@ -3007,17 +8857,18 @@ func (x *SalesReportItem) GetTotalOrders() int32 {
 }
 
 type GetSalesReportRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartDate string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetSalesReportRequest) Reset() {
 	*x = GetSalesReportRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[45]
+	mi := &file_proto_bookstore_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3029,7 +8880,7 @@ func (x *GetSalesReportRequest) String() string {
 func (*GetSalesReportRequest) ProtoMessage() {}
 
 func (x *GetSalesReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[45]
+	mi := &file_proto_bookstore_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3042,7 +8893,7 @@ func (x *GetSalesReportRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSalesReportRequest.ProtoReflect.Descriptor instead.
 func (*GetSalesReportRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{45}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{137}
 }
 
 func (x *GetSalesReportRequest) GetStartDate() string {
@@ -3059,6 +8910,7 @@ func (x *GetSalesReportRequest) GetEndDate() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *GetSalesReportRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -3078,7 +8930,7 @@ type GetSalesReportResponse struct {
 
 func (x *GetSalesReportResponse) Reset() {
 	*x = GetSalesReportResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[46]
+	mi := &file_proto_bookstore_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3090,7 +8942,7 @@ func (x *GetSalesReportResponse) String() string {
 func (*GetSalesReportResponse) ProtoMessage() {}
 
 func (x *GetSalesReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[46]
+	mi := &file_proto_bookstore_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3103,7 +8955,7 @@ func (x *GetSalesReportResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSalesReportResponse.ProtoReflect.Descriptor instead.
 func (*GetSalesReportResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{46}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{138}
 }
 
 func (x *GetSalesReportResponse) GetSuccess() bool {
@@ -3144,7 +8996,7 @@ type TopBookItem struct {
 
 func (x *TopBookItem) Reset() {
 	*x = TopBookItem{}
-	mi := &file_proto_bookstore_proto_msgTypes[47]
+	mi := &file_proto_bookstore_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3156,7 +9008,7 @@ func (x *TopBookItem) String() string {
 func (*TopBookItem) ProtoMessage() {}
 
 func (x *TopBookItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[47]
+	mi := &file_proto_bookstore_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3169,7 +9021,7 @@ func (x *TopBookItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopBookItem.ProtoReflect.Descriptor instead.
 func (*TopBookItem) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{47}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{139}
 }
 
 func (x *TopBookItem) GetBook() *Book {
@@ -3187,16 +9039,17 @@ func (x *TopBookItem) GetTotalSold() int32 {
 }
 
 type GetTopBooksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTopBooksRequest) Reset() {
 	*x = GetTopBooksRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[48]
+	mi := &file_proto_bookstore_proto_msgTypes[140]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3208,7 +9061,7 @@ func (x *GetTopBooksRequest) String() string {
 func (*GetTopBooksRequest) ProtoMessage() {}
 
 func (x *GetTopBooksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[48]
+	mi := &file_proto_bookstore_proto_msgTypes[140]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3221,7 +9074,7 @@ func (x *GetTopBooksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopBooksRequest.ProtoReflect.Descriptor instead.
 func (*GetTopBooksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{48}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{140}
 }
 
 func (x *GetTopBooksRequest) GetLimit() int32 {
@@ -3231,6 +9084,7 @@ func (x *GetTopBooksRequest) GetLimit() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *GetTopBooksRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -3249,7 +9103,7 @@ type GetTopBooksResponse struct {
 
 func (x *GetTopBooksResponse) Reset() {
 	*x = GetTopBooksResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[49]
+	mi := &file_proto_bookstore_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3261,7 +9115,7 @@ func (x *GetTopBooksResponse) String() string {
 func (*GetTopBooksResponse) ProtoMessage() {}
 
 func (x *GetTopBooksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[49]
+	mi := &file_proto_bookstore_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3274,7 +9128,7 @@ func (x *GetTopBooksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTopBooksResponse.ProtoReflect.Descriptor instead.
 func (*GetTopBooksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{49}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{141}
 }
 
 func (x *GetTopBooksResponse) GetSuccess() bool {
@@ -3299,15 +9153,16 @@ func (x *GetTopBooksResponse) GetBooks() []*TopBookItem {
 }
 
 type GetBookPriceStatisticsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: Marked as deprecated in proto/bookstore.proto.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // send "authorization: Bearer <token>" metadata instead
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBookPriceStatisticsRequest) Reset() {
 	*x = GetBookPriceStatisticsRequest{}
-	mi := &file_proto_bookstore_proto_msgTypes[50]
+	mi := &file_proto_bookstore_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3319,7 +9174,7 @@ func (x *GetBookPriceStatisticsRequest) String() string {
 func (*GetBookPriceStatisticsRequest) ProtoMessage() {}
 
 func (x *GetBookPriceStatisticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[50]
+	mi := &file_proto_bookstore_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3332,9 +9187,10 @@ func (x *GetBookPriceStatisticsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBookPriceStatisticsRequest.ProtoReflect.Descriptor instead.
 func (*GetBookPriceStatisticsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{50}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{142}
 }
 
+// Deprecated: Marked as deprecated in proto/bookstore.proto.
 func (x *GetBookPriceStatisticsRequest) GetToken() string {
 	if x != nil {
 		return x.Token
@@ -3356,7 +9212,7 @@ type GetBookPriceStatisticsResponse struct {
 
 func (x *GetBookPriceStatisticsResponse) Reset() {
 	*x = GetBookPriceStatisticsResponse{}
-	mi := &file_proto_bookstore_proto_msgTypes[51]
+	mi := &file_proto_bookstore_proto_msgTypes[143]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3368,7 +9224,7 @@ func (x *GetBookPriceStatisticsResponse) String() string {
 func (*GetBookPriceStatisticsResponse) ProtoMessage() {}
 
 func (x *GetBookPriceStatisticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bookstore_proto_msgTypes[51]
+	mi := &file_proto_bookstore_proto_msgTypes[143]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3381,7 +9237,7 @@ func (x *GetBookPriceStatisticsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBookPriceStatisticsResponse.ProtoReflect.Descriptor instead.
 func (*GetBookPriceStatisticsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bookstore_proto_rawDescGZIP(), []int{51}
+	return file_proto_bookstore_proto_rawDescGZIP(), []int{143}
 }
 
 func (x *GetBookPriceStatisticsResponse) GetSuccess() bool {
@@ -3430,7 +9286,7 @@ var File_proto_bookstore_proto protoreflect.FileDescriptor
 
 const file_proto_bookstore_proto_rawDesc = "" +
 	"\n" +
-	"\x15proto/bookstore.proto\x12\tbookstore\"\x92\x01\n" +
+	"\x15proto/bookstore.proto\x12\tbookstore\"\x98\x03\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -3439,7 +9295,18 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"W\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x14\n" +
+	"\x05phone\x18\a \x01(\tR\x05phone\x12\x18\n" +
+	"\aaddress\x18\b \x01(\tR\aaddress\x12\x1a\n" +
+	"\bdisabled\x18\t \x01(\bR\bdisabled\x12\x1f\n" +
+	"\vdisabled_at\x18\n" +
+	" \x01(\tR\n" +
+	"disabledAt\x12\x1f\n" +
+	"\vmfa_enabled\x18\v \x01(\bR\n" +
+	"mfaEnabled\x12%\n" +
+	"\x0eemail_verified\x18\f \x01(\bR\remailVerified\x12*\n" +
+	"\x11email_verified_at\x18\r \x01(\tR\x0femailVerifiedAt\x12#\n" +
+	"\ranonymized_at\x18\x0e \x01(\tR\fanonymizedAt\"W\n" +
 	"\x0fRegisterRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
@@ -3450,28 +9317,411 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"@\n" +
 	"\fLoginRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"~\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xba\x02\n" +
 	"\rLoginResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
 	"\x05token\x18\x03 \x01(\tR\x05token\x12#\n" +
-	"\x04user\x18\x04 \x01(\v2\x0f.bookstore.UserR\x04user\")\n" +
-	"\x11GetProfileRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"m\n" +
+	"\x04user\x18\x04 \x01(\v2\x0f.bookstore.UserR\x04user\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x06 \x01(\x03R\texpiresIn\x12!\n" +
+	"\fmfa_required\x18\a \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\b \x01(\tR\bmfaToken\x126\n" +
+	"\x17mfa_enrollment_required\x18\t \x01(\bR\x15mfaEnrollmentRequired\"-\n" +
+	"\x11GetProfileRequest\x12\x18\n" +
+	"\x05token\x18\x01 \x01(\tB\x02\x18\x01R\x05token\"m\n" +
 	"\x12GetProfileResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
-	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"l\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"\x8a\x01\n" +
+	"\x14UpdateProfileRequest\x12\x18\n" +
+	"\x05token\x18\x01 \x01(\tB\x02\x18\x01R\x05token\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\"p\n" +
+	"\x15UpdateProfileResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"w\n" +
+	"\x15ChangePasswordRequest\x12\x18\n" +
+	"\x05token\x18\x01 \x01(\tB\x02\x18\x01R\x05token\x12!\n" +
+	"\fold_password\x18\x02 \x01(\tR\voldPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"\xa6\x01\n" +
+	"\x16ChangePasswordResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x05 \x01(\x03R\texpiresIn\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xa4\x01\n" +
+	"\x14RefreshTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x05 \x01(\x03R\texpiresIn\"N\n" +
+	"\rLogoutRequest\x12\x18\n" +
+	"\x05token\x18\x01 \x01(\tB\x02\x18\x01R\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"D\n" +
+	"\x0eLogoutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
+	"\x17LogoutAllDevicesRequest\x12\x18\n" +
+	"\x05token\x18\x01 \x01(\tB\x02\x18\x01R\x05token\"N\n" +
+	"\x18LogoutAllDevicesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x12\n" +
+	"\x10EnrollMFARequest\"\x8a\x01\n" +
+	"\x11EnrollMFAResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x04 \x01(\tR\x0fprovisioningUri\"'\n" +
+	"\x11ConfirmMFARequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\xc9\x01\n" +
+	"\x12ConfirmMFAResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x0erecovery_codes\x18\x03 \x03(\tR\rrecoveryCodes\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x06 \x01(\x03R\texpiresIn\"C\n" +
+	"\x10VerifyMFARequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xc6\x01\n" +
+	"\x11VerifyMFAResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12#\n" +
+	"\x04user\x18\x04 \x01(\v2\x0f.bookstore.UserR\x04user\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x06 \x01(\x03R\texpiresIn\"3\n" +
+	"\x1bRequestPasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"R\n" +
+	"\x1cRequestPasswordResetResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"V\n" +
+	"\x1bConfirmPasswordResetRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"R\n" +
+	"\x1cConfirmPasswordResetResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"n\n" +
+	"\x13VerifyEmailResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"\x1b\n" +
+	"\x19ResendVerificationRequest\"P\n" +
+	"\x1aResendVerificationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
+	"\x15BeginOIDCLoginRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\"}\n" +
+	"\x16BeginOIDCLoginResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bauth_url\x18\x03 \x01(\tR\aauthUrl\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\"D\n" +
+	"\x18CompleteOIDCLoginRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xf2\x01\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tR\tuserAgent\x12\x1f\n" +
+	"\vdevice_name\x18\x03 \x01(\tR\n" +
+	"deviceName\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x04 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12 \n" +
+	"\flast_seen_at\x18\x06 \x01(\tR\n" +
+	"lastSeenAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\x12\x18\n" +
+	"\acurrent\x18\b \x01(\bR\acurrent\"\x17\n" +
+	"\x15ListMySessionsRequest\"|\n" +
+	"\x16ListMySessionsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\bsessions\x18\x03 \x03(\v2\x12.bookstore.SessionR\bsessions\"&\n" +
+	"\x14RevokeSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"K\n" +
+	"\x15RevokeSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x15\n" +
+	"\x13ExportMyDataRequest\"%\n" +
+	"\x0fDataExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"4\n" +
+	"\x16DeleteMyAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\"M\n" +
+	"\x17DeleteMyAccountResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"h\n" +
+	"\x10ListUsersRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"\x86\x02\n" +
+	"\x11ListUsersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x05users\x18\x03 \x03(\v2\x0f.bookstore.UserR\x05users\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12!\n" +
+	"\fcurrent_page\x18\x05 \x01(\x05R\vcurrentPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x06 \x01(\x05R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_next\x18\a \x01(\bR\ahasNext\x12!\n" +
+	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"j\n" +
+	"\x0fGetUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"8\n" +
+	"\x12SetUserRoleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"n\n" +
+	"\x13SetUserRoleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"$\n" +
+	"\x12DisableUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"n\n" +
+	"\x13DisableUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"#\n" +
+	"\x11EnableUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"m\n" +
+	"\x12EnableUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"H\n" +
+	"\x12DeleteUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"&\n" +
+	"\x14UnlockAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"K\n" +
+	"\x15UnlockAccountResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"B\n" +
+	"\n" +
+	"Permission\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"n\n" +
+	"\x04Role\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12 \n" +
+	"\vpermissions\x18\x04 \x03(\tR\vpermissions\"\x12\n" +
+	"\x10ListRolesRequest\"n\n" +
+	"\x11ListRolesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x05roles\x18\x03 \x03(\v2\x0f.bookstore.RoleR\x05roles\"\x18\n" +
+	"\x16ListPermissionsRequest\"\x86\x01\n" +
+	"\x17ListPermissionsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x127\n" +
+	"\vpermissions\x18\x03 \x03(\v2\x15.bookstore.PermissionR\vpermissions\"k\n" +
+	"\x11UpsertRoleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions\"m\n" +
+	"\x12UpsertRoleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04role\x18\x03 \x01(\v2\x0f.bookstore.RoleR\x04role\"'\n" +
+	"\x15ExportUserDataRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"&\n" +
+	"\x14AnonymizeUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"p\n" +
+	"\x15AnonymizeUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04user\x18\x03 \x01(\v2\x0f.bookstore.UserR\x04user\"(\n" +
+	"\x16ImpersonateUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\xa7\x01\n" +
+	"\x17ImpersonateUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x04 \x01(\x03R\texpiresIn\x12#\n" +
+	"\x04user\x18\x05 \x01(\v2\x0f.bookstore.UserR\x04user\"\xb2\x02\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x121\n" +
+	"\x15rate_limit_per_minute\x18\x05 \x01(\x05R\x12rateLimitPerMinute\x12\"\n" +
+	"\rcreated_by_id\x18\x06 \x01(\rR\vcreatedById\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\x12 \n" +
+	"\flast_used_at\x18\b \x01(\tR\n" +
+	"lastUsedAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\t \x01(\tR\trevokedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\"\x93\x01\n" +
+	"\x13CreateAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x121\n" +
+	"\x15rate_limit_per_minute\x18\x03 \x01(\x05R\x12rateLimitPerMinute\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\"\x88\x01\n" +
+	"\x14CreateAPIKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\aapi_key\x18\x03 \x01(\v2\x11.bookstore.APIKeyR\x06apiKey\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\"g\n" +
+	"\x12ListAPIKeysRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12'\n" +
+	"\x0finclude_revoked\x18\x03 \x01(\bR\x0eincludeRevoked\"\x8f\x02\n" +
+	"\x13ListAPIKeysResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\bapi_keys\x18\x03 \x03(\v2\x11.bookstore.APIKeyR\aapiKeys\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12!\n" +
+	"\fcurrent_page\x18\x05 \x01(\x05R\vcurrentPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x06 \x01(\x05R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_next\x18\a \x01(\bR\ahasNext\x12!\n" +
+	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\"%\n" +
+	"\x13RevokeAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"J\n" +
+	"\x14RevokeAPIKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb8\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\rR\aactorId\x12\x1f\n" +
+	"\vactor_email\x18\x03 \x01(\tR\n" +
+	"actorEmail\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\x04 \x01(\rR\bapiKeyId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x1f\n" +
+	"\ventity_type\x18\x06 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\a \x01(\rR\bentityId\x12\x16\n" +
+	"\x06before\x18\b \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\t \x01(\tR\x05after\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\n" +
+	" \x01(\tR\trequestId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\"\xf6\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\rR\aactorId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1f\n" +
+	"\ventity_type\x18\x05 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x06 \x01(\rR\bentityId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x12\n" +
+	"\x04from\x18\b \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\t \x01(\tR\x02to\"\x94\x02\n" +
+	"\x17ListAuditEventsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
+	"\x06events\x18\x03 \x03(\v2\x15.bookstore.AuditEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12!\n" +
+	"\fcurrent_page\x18\x05 \x01(\x05R\vcurrentPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x06 \x01(\x05R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_next\x18\a \x01(\bR\ahasNext\x12!\n" +
+	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\"\xb8\x02\n" +
+	"\aAddress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12%\n" +
+	"\x0erecipient_name\x18\x02 \x01(\tR\rrecipientName\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06street\x18\x04 \x01(\tR\x06street\x12\x1a\n" +
+	"\bdistrict\x18\x05 \x01(\tR\bdistrict\x12\x12\n" +
+	"\x04city\x18\x06 \x01(\tR\x04city\x12\x1a\n" +
+	"\bprovince\x18\a \x01(\tR\bprovince\x12\x1f\n" +
+	"\vpostal_code\x18\b \x01(\tR\n" +
+	"postalCode\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\t \x01(\bR\tisDefault\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\"\xf7\x01\n" +
+	"\x14CreateAddressRequest\x12%\n" +
+	"\x0erecipient_name\x18\x01 \x01(\tR\rrecipientName\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x1a\n" +
+	"\bdistrict\x18\x04 \x01(\tR\bdistrict\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x1a\n" +
+	"\bprovince\x18\x06 \x01(\tR\bprovince\x12\x1f\n" +
+	"\vpostal_code\x18\a \x01(\tR\n" +
+	"postalCode\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\b \x01(\bR\tisDefault\"y\n" +
+	"\x15CreateAddressResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\aaddress\x18\x03 \x01(\v2\x12.bookstore.AddressR\aaddress\"\x16\n" +
+	"\x14ListAddressesRequest\"}\n" +
+	"\x15ListAddressesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\taddresses\x18\x03 \x03(\v2\x12.bookstore.AddressR\taddresses\"#\n" +
+	"\x11GetAddressRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"v\n" +
+	"\x12GetAddressResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\aaddress\x18\x03 \x01(\v2\x12.bookstore.AddressR\aaddress\"\x87\x02\n" +
+	"\x14UpdateAddressRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12%\n" +
+	"\x0erecipient_name\x18\x02 \x01(\tR\rrecipientName\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06street\x18\x04 \x01(\tR\x06street\x12\x1a\n" +
+	"\bdistrict\x18\x05 \x01(\tR\bdistrict\x12\x12\n" +
+	"\x04city\x18\x06 \x01(\tR\x04city\x12\x1a\n" +
+	"\bprovince\x18\a \x01(\tR\bprovince\x12\x1f\n" +
+	"\vpostal_code\x18\b \x01(\tR\n" +
+	"postalCode\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\t \x01(\bR\tisDefault\"y\n" +
+	"\x15UpdateAddressResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\aaddress\x18\x03 \x01(\v2\x12.bookstore.AddressR\aaddress\"&\n" +
+	"\x14DeleteAddressRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"K\n" +
+	"\x15DeleteAddressResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"l\n" +
 	"\bCategory\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x04 \x01(\tR\tupdatedAt\"A\n" +
+	"updated_at\x18\x04 \x01(\tR\tupdatedAt\"E\n" +
 	"\x15CreateCategoryRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05token\x18\x02 \x01(\tR\x05token\"}\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\x05token\x18\x02 \x01(\tB\x02\x18\x01R\x05token\"}\n" +
 	"\x16CreateCategoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
@@ -3496,21 +9746,21 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\x13GetCategoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
-	"\bcategory\x18\x03 \x01(\v2\x13.bookstore.CategoryR\bcategory\"Q\n" +
+	"\bcategory\x18\x03 \x01(\v2\x13.bookstore.CategoryR\bcategory\"U\n" +
 	"\x15UpdateCategoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05token\x18\x03 \x01(\tR\x05token\"}\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\x05token\x18\x03 \x01(\tB\x02\x18\x01R\x05token\"}\n" +
 	"\x16UpdateCategoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
-	"\bcategory\x18\x03 \x01(\v2\x13.bookstore.CategoryR\bcategory\"=\n" +
+	"\bcategory\x18\x03 \x01(\v2\x13.bookstore.CategoryR\bcategory\"A\n" +
 	"\x15DeleteCategoryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
-	"\x05token\x18\x02 \x01(\tR\x05token\"L\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\x05token\x18\x02 \x01(\tB\x02\x18\x01R\x05token\"L\n" +
 	"\x16DeleteCategoryResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xb7\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xde\x03\n" +
 	"\x04Book\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
@@ -3519,14 +9769,19 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\x05stock\x18\x05 \x01(\x05R\x05stock\x12\x12\n" +
 	"\x04year\x18\x06 \x01(\x05R\x04year\x12\x1f\n" +
 	"\vcategory_id\x18\a \x01(\rR\n" +
-	"categoryId\x12!\n" +
-	"\fimage_base64\x18\b \x01(\tR\vimageBase64\x12\x1d\n" +
+	"categoryId\x12%\n" +
+	"\fimage_base64\x18\b \x01(\tB\x02\x18\x01R\vimageBase64\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\n" +
 	" \x01(\tR\tupdatedAt\x12/\n" +
-	"\bcategory\x18\v \x01(\v2\x13.bookstore.CategoryR\bcategory\"\xdb\x01\n" +
+	"\bcategory\x18\v \x01(\v2\x13.bookstore.CategoryR\bcategory\x12\x12\n" +
+	"\x04isbn\x18\f \x01(\tR\x04isbn\x12\x1b\n" +
+	"\tcover_url\x18\r \x01(\tR\bcoverUrl\x12#\n" +
+	"\rthumbnail_url\x18\x0e \x01(\tR\fthumbnailUrl\x12 \n" +
+	"\vdescription\x18\x0f \x01(\tR\vdescription\x12)\n" +
+	"\x10search_highlight\x18\x10 \x01(\tR\x0fsearchHighlight\"\x99\x02\n" +
 	"\x11CreateBookRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
 	"\x06author\x18\x02 \x01(\tR\x06author\x12\x14\n" +
@@ -3534,17 +9789,22 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\x05stock\x18\x04 \x01(\x05R\x05stock\x12\x12\n" +
 	"\x04year\x18\x05 \x01(\x05R\x04year\x12\x1f\n" +
 	"\vcategory_id\x18\x06 \x01(\rR\n" +
-	"categoryId\x12!\n" +
-	"\fimage_base64\x18\a \x01(\tR\vimageBase64\x12\x14\n" +
-	"\x05token\x18\b \x01(\tR\x05token\"m\n" +
+	"categoryId\x12%\n" +
+	"\fimage_base64\x18\a \x01(\tB\x02\x18\x01R\vimageBase64\x12\x18\n" +
+	"\x05token\x18\b \x01(\tB\x02\x18\x01R\x05token\x12\x12\n" +
+	"\x04isbn\x18\t \x01(\tR\x04isbn\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\"m\n" +
 	"\x12CreateBookResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
-	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"S\n" +
+	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"t\n" +
 	"\x0fGetBooksRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06search\x18\x03 \x01(\tR\x06search\"\x85\x02\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12\x1f\n" +
+	"\vsearch_mode\x18\x04 \x01(\tR\n" +
+	"searchMode\"\xa7\x02\n" +
 	"\x10GetBooksResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
@@ -3554,13 +9814,21 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\vtotal_pages\x18\x06 \x01(\x05R\n" +
 	"totalPages\x12\x19\n" +
 	"\bhas_next\x18\a \x01(\bR\ahasNext\x12!\n" +
-	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\" \n" +
+	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\x12 \n" +
+	"\fdid_you_mean\x18\t \x01(\tR\n" +
+	"didYouMean\" \n" +
 	"\x0eGetBookRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\"j\n" +
 	"\x0fGetBookResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
-	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"\xeb\x01\n" +
+	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"*\n" +
+	"\x14GetBookByISBNRequest\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\"p\n" +
+	"\x15GetBookByISBNResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"\xa9\x02\n" +
 	"\x11UpdateBookRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
@@ -3569,16 +9837,27 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\x05stock\x18\x05 \x01(\x05R\x05stock\x12\x12\n" +
 	"\x04year\x18\x06 \x01(\x05R\x04year\x12\x1f\n" +
 	"\vcategory_id\x18\a \x01(\rR\n" +
-	"categoryId\x12!\n" +
-	"\fimage_base64\x18\b \x01(\tR\vimageBase64\x12\x14\n" +
-	"\x05token\x18\t \x01(\tR\x05token\"m\n" +
+	"categoryId\x12%\n" +
+	"\fimage_base64\x18\b \x01(\tB\x02\x18\x01R\vimageBase64\x12\x18\n" +
+	"\x05token\x18\t \x01(\tB\x02\x18\x01R\x05token\x12\x12\n" +
+	"\x04isbn\x18\n" +
+	" \x01(\tR\x04isbn\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\"m\n" +
 	"\x12UpdateBookResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
-	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"9\n" +
+	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"S\n" +
+	"\x16UploadBookCoverRequest\x12\x19\n" +
+	"\abook_id\x18\x01 \x01(\rH\x00R\x06bookId\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"r\n" +
+	"\x17UploadBookCoverResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x04book\x18\x03 \x01(\v2\x0f.bookstore.BookR\x04book\"=\n" +
 	"\x11DeleteBookRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
-	"\x05token\x18\x02 \x01(\tR\x05token\"H\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\x05token\x18\x02 \x01(\tB\x02\x18\x01R\x05token\"H\n" +
 	"\x12DeleteBookResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"f\n" +
@@ -3596,13 +9875,26 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\vtotal_pages\x18\x06 \x01(\x05R\n" +
 	"totalPages\x12\x19\n" +
 	"\bhas_next\x18\a \x01(\bR\ahasNext\x12!\n" +
-	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\"\x8b\x01\n" +
+	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\"C\n" +
+	"\x13SuggestBooksRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"S\n" +
+	"\x0eBookSuggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x17\n" +
+	"\abook_id\x18\x03 \x01(\rR\x06bookId\"\xa9\x01\n" +
+	"\x14SuggestBooksResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\vsuggestions\x18\x03 \x03(\v2\x19.bookstore.BookSuggestionR\vsuggestions\x12 \n" +
+	"\fdid_you_mean\x18\x04 \x01(\tR\n" +
+	"didYouMean\"\x8b\x01\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
 	"\abook_id\x18\x02 \x01(\rR\x06bookId\x12\x1a\n" +
 	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
 	"\x05price\x18\x04 \x01(\x01R\x05price\x12#\n" +
-	"\x04book\x18\x05 \x01(\v2\x0f.bookstore.BookR\x04book\"\x99\x02\n" +
+	"\x04book\x18\x05 \x01(\v2\x0f.bookstore.BookR\x04book\"\xe0\x02\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x1f\n" +
@@ -3616,19 +9908,32 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\a \x01(\tR\tupdatedAt\x12#\n" +
 	"\x04user\x18\b \x01(\v2\x0f.bookstore.UserR\x04user\x12*\n" +
-	"\x05items\x18\t \x03(\v2\x14.bookstore.OrderItemR\x05items\"]\n" +
+	"\x05items\x18\t \x03(\v2\x14.bookstore.OrderItemR\x05items\x12E\n" +
+	"\x10shipping_address\x18\n" +
+	" \x01(\v2\x1a.bookstore.ShippingAddressR\x0fshippingAddress\"\xd3\x01\n" +
+	"\x0fShippingAddress\x12%\n" +
+	"\x0erecipient_name\x18\x01 \x01(\tR\rrecipientName\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x1a\n" +
+	"\bdistrict\x18\x04 \x01(\tR\bdistrict\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x1a\n" +
+	"\bprovince\x18\x06 \x01(\tR\bprovince\x12\x1f\n" +
+	"\vpostal_code\x18\a \x01(\tR\n" +
+	"postalCode\"\x80\x01\n" +
 	"\x12CreateOrderRequest\x121\n" +
-	"\x05items\x18\x01 \x03(\v2\x1b.bookstore.OrderItemRequestR\x05items\x12\x14\n" +
-	"\x05token\x18\x02 \x01(\tR\x05token\"G\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.bookstore.OrderItemRequestR\x05items\x12\x18\n" +
+	"\x05token\x18\x02 \x01(\tB\x02\x18\x01R\x05token\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x03 \x01(\rR\taddressId\"G\n" +
 	"\x10OrderItemRequest\x12\x17\n" +
 	"\abook_id\x18\x01 \x01(\rR\x06bookId\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\"q\n" +
 	"\x13CreateOrderResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
-	"\x05order\x18\x03 \x01(\v2\x10.bookstore.OrderR\x05order\"R\n" +
-	"\x10GetOrdersRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\x12\x12\n" +
+	"\x05order\x18\x03 \x01(\v2\x10.bookstore.OrderR\x05order\"V\n" +
+	"\x10GetOrdersRequest\x12\x18\n" +
+	"\x05token\x18\x01 \x01(\tB\x02\x18\x01R\x05token\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x89\x02\n" +
 	"\x11GetOrdersResponse\x12\x18\n" +
@@ -3640,25 +9945,25 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\vtotal_pages\x18\x06 \x01(\x05R\n" +
 	"totalPages\x12\x19\n" +
 	"\bhas_next\x18\a \x01(\bR\ahasNext\x12!\n" +
-	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\"7\n" +
+	"\fhas_previous\x18\b \x01(\bR\vhasPrevious\";\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
-	"\x05token\x18\x02 \x01(\tR\x05token\"n\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x18\n" +
+	"\x05token\x18\x02 \x01(\tB\x02\x18\x01R\x05token\"n\n" +
 	"\x10GetOrderResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
-	"\x05order\x18\x03 \x01(\v2\x10.bookstore.OrderR\x05order\"X\n" +
+	"\x05order\x18\x03 \x01(\v2\x10.bookstore.OrderR\x05order\"\\\n" +
 	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
-	"\x05token\x18\x03 \x01(\tR\x05token\"w\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\x05token\x18\x03 \x01(\tB\x02\x18\x01R\x05token\"w\n" +
 	"\x19UpdateOrderStatusResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
-	"\x05order\x18\x03 \x01(\v2\x10.bookstore.OrderR\x05order\"H\n" +
+	"\x05order\x18\x03 \x01(\v2\x10.bookstore.OrderR\x05order\"L\n" +
 	"\x15ProcessPaymentRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\rR\aorderId\x12\x14\n" +
-	"\x05token\x18\x02 \x01(\tR\x05token\"m\n" +
+	"\border_id\x18\x01 \x01(\rR\aorderId\x12\x18\n" +
+	"\x05token\x18\x02 \x01(\tB\x02\x18\x01R\x05token\"m\n" +
 	"\x16ProcessPaymentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
@@ -3668,12 +9973,12 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1f\n" +
 	"\vtotal_sales\x18\x02 \x01(\x01R\n" +
 	"totalSales\x12!\n" +
-	"\ftotal_orders\x18\x03 \x01(\x05R\vtotalOrders\"g\n" +
+	"\ftotal_orders\x18\x03 \x01(\x05R\vtotalOrders\"k\n" +
 	"\x15GetSalesReportRequest\x12\x1d\n" +
 	"\n" +
 	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
-	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x14\n" +
-	"\x05token\x18\x03 \x01(\tR\x05token\"\xa5\x01\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\x12\x18\n" +
+	"\x05token\x18\x03 \x01(\tB\x02\x18\x01R\x05token\"\xa5\x01\n" +
 	"\x16GetSalesReportResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
@@ -3682,16 +9987,16 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\vTopBookItem\x12#\n" +
 	"\x04book\x18\x01 \x01(\v2\x0f.bookstore.BookR\x04book\x12\x1d\n" +
 	"\n" +
-	"total_sold\x18\x02 \x01(\x05R\ttotalSold\"@\n" +
+	"total_sold\x18\x02 \x01(\x05R\ttotalSold\"D\n" +
 	"\x12GetTopBooksRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x14\n" +
-	"\x05token\x18\x02 \x01(\tR\x05token\"w\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x18\n" +
+	"\x05token\x18\x02 \x01(\tB\x02\x18\x01R\x05token\"w\n" +
 	"\x13GetTopBooksResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
-	"\x05books\x18\x03 \x03(\v2\x16.bookstore.TopBookItemR\x05books\"5\n" +
-	"\x1dGetBookPriceStatisticsRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\xcc\x01\n" +
+	"\x05books\x18\x03 \x03(\v2\x16.bookstore.TopBookItemR\x05books\"9\n" +
+	"\x1dGetBookPriceStatisticsRequest\x12\x18\n" +
+	"\x05token\x18\x01 \x01(\tB\x02\x18\x01R\x05token\"\xcc\x01\n" +
 	"\x1eGetBookPriceStatisticsResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
@@ -3699,28 +10004,80 @@ const file_proto_bookstore_proto_rawDesc = "" +
 	"\tmin_price\x18\x04 \x01(\x01R\bminPrice\x12\x1b\n" +
 	"\tavg_price\x18\x05 \x01(\x01R\bavgPrice\x12\x1f\n" +
 	"\vtotal_books\x18\x06 \x01(\x05R\n" +
-	"totalBooks2\xd9\x01\n" +
+	"totalBooks2\xcd\r\n" +
 	"\vUserService\x12C\n" +
 	"\bRegister\x12\x1a.bookstore.RegisterRequest\x1a\x1b.bookstore.RegisterResponse\x12:\n" +
 	"\x05Login\x12\x17.bookstore.LoginRequest\x1a\x18.bookstore.LoginResponse\x12I\n" +
 	"\n" +
-	"GetProfile\x12\x1c.bookstore.GetProfileRequest\x1a\x1d.bookstore.GetProfileResponse2\xb8\x03\n" +
+	"GetProfile\x12\x1c.bookstore.GetProfileRequest\x1a\x1d.bookstore.GetProfileResponse\x12R\n" +
+	"\rUpdateProfile\x12\x1f.bookstore.UpdateProfileRequest\x1a .bookstore.UpdateProfileResponse\x12U\n" +
+	"\x0eChangePassword\x12 .bookstore.ChangePasswordRequest\x1a!.bookstore.ChangePasswordResponse\x12O\n" +
+	"\fRefreshToken\x12\x1e.bookstore.RefreshTokenRequest\x1a\x1f.bookstore.RefreshTokenResponse\x12=\n" +
+	"\x06Logout\x12\x18.bookstore.LogoutRequest\x1a\x19.bookstore.LogoutResponse\x12[\n" +
+	"\x10LogoutAllDevices\x12\".bookstore.LogoutAllDevicesRequest\x1a#.bookstore.LogoutAllDevicesResponse\x12F\n" +
+	"\tEnrollMFA\x12\x1b.bookstore.EnrollMFARequest\x1a\x1c.bookstore.EnrollMFAResponse\x12I\n" +
+	"\n" +
+	"ConfirmMFA\x12\x1c.bookstore.ConfirmMFARequest\x1a\x1d.bookstore.ConfirmMFAResponse\x12F\n" +
+	"\tVerifyMFA\x12\x1b.bookstore.VerifyMFARequest\x1a\x1c.bookstore.VerifyMFAResponse\x12g\n" +
+	"\x14RequestPasswordReset\x12&.bookstore.RequestPasswordResetRequest\x1a'.bookstore.RequestPasswordResetResponse\x12g\n" +
+	"\x14ConfirmPasswordReset\x12&.bookstore.ConfirmPasswordResetRequest\x1a'.bookstore.ConfirmPasswordResetResponse\x12L\n" +
+	"\vVerifyEmail\x12\x1d.bookstore.VerifyEmailRequest\x1a\x1e.bookstore.VerifyEmailResponse\x12a\n" +
+	"\x12ResendVerification\x12$.bookstore.ResendVerificationRequest\x1a%.bookstore.ResendVerificationResponse\x12L\n" +
+	"\fExportMyData\x12\x1e.bookstore.ExportMyDataRequest\x1a\x1a.bookstore.DataExportChunk0\x01\x12X\n" +
+	"\x0fDeleteMyAccount\x12!.bookstore.DeleteMyAccountRequest\x1a\".bookstore.DeleteMyAccountResponse\x12U\n" +
+	"\x0eBeginOIDCLogin\x12 .bookstore.BeginOIDCLoginRequest\x1a!.bookstore.BeginOIDCLoginResponse\x12R\n" +
+	"\x11CompleteOIDCLogin\x12#.bookstore.CompleteOIDCLoginRequest\x1a\x18.bookstore.LoginResponse\x12U\n" +
+	"\x0eListMySessions\x12 .bookstore.ListMySessionsRequest\x1a!.bookstore.ListMySessionsResponse\x12R\n" +
+	"\rRevokeSession\x12\x1f.bookstore.RevokeSessionRequest\x1a .bookstore.RevokeSessionResponse2\x8f\b\n" +
+	"\x10AdminUserService\x12F\n" +
+	"\tListUsers\x12\x1b.bookstore.ListUsersRequest\x1a\x1c.bookstore.ListUsersResponse\x12@\n" +
+	"\aGetUser\x12\x19.bookstore.GetUserRequest\x1a\x1a.bookstore.GetUserResponse\x12L\n" +
+	"\vSetUserRole\x12\x1d.bookstore.SetUserRoleRequest\x1a\x1e.bookstore.SetUserRoleResponse\x12L\n" +
+	"\vDisableUser\x12\x1d.bookstore.DisableUserRequest\x1a\x1e.bookstore.DisableUserResponse\x12I\n" +
+	"\n" +
+	"EnableUser\x12\x1c.bookstore.EnableUserRequest\x1a\x1d.bookstore.EnableUserResponse\x12I\n" +
+	"\n" +
+	"DeleteUser\x12\x1c.bookstore.DeleteUserRequest\x1a\x1d.bookstore.DeleteUserResponse\x12R\n" +
+	"\rUnlockAccount\x12\x1f.bookstore.UnlockAccountRequest\x1a .bookstore.UnlockAccountResponse\x12F\n" +
+	"\tListRoles\x12\x1b.bookstore.ListRolesRequest\x1a\x1c.bookstore.ListRolesResponse\x12X\n" +
+	"\x0fListPermissions\x12!.bookstore.ListPermissionsRequest\x1a\".bookstore.ListPermissionsResponse\x12I\n" +
+	"\n" +
+	"UpsertRole\x12\x1c.bookstore.UpsertRoleRequest\x1a\x1d.bookstore.UpsertRoleResponse\x12P\n" +
+	"\x0eExportUserData\x12 .bookstore.ExportUserDataRequest\x1a\x1a.bookstore.DataExportChunk0\x01\x12R\n" +
+	"\rAnonymizeUser\x12\x1f.bookstore.AnonymizeUserRequest\x1a .bookstore.AnonymizeUserResponse\x12X\n" +
+	"\x0fImpersonateUser\x12!.bookstore.ImpersonateUserRequest\x1a\".bookstore.ImpersonateUserResponse2\xff\x01\n" +
+	"\rAPIKeyService\x12O\n" +
+	"\fCreateAPIKey\x12\x1e.bookstore.CreateAPIKeyRequest\x1a\x1f.bookstore.CreateAPIKeyResponse\x12L\n" +
+	"\vListAPIKeys\x12\x1d.bookstore.ListAPIKeysRequest\x1a\x1e.bookstore.ListAPIKeysResponse\x12O\n" +
+	"\fRevokeAPIKey\x12\x1e.bookstore.RevokeAPIKeyRequest\x1a\x1f.bookstore.RevokeAPIKeyResponse2h\n" +
+	"\fAuditService\x12X\n" +
+	"\x0fListAuditEvents\x12!.bookstore.ListAuditEventsRequest\x1a\".bookstore.ListAuditEventsResponse2\xab\x03\n" +
+	"\x0eAddressService\x12R\n" +
+	"\rCreateAddress\x12\x1f.bookstore.CreateAddressRequest\x1a .bookstore.CreateAddressResponse\x12R\n" +
+	"\rListAddresses\x12\x1f.bookstore.ListAddressesRequest\x1a .bookstore.ListAddressesResponse\x12I\n" +
+	"\n" +
+	"GetAddress\x12\x1c.bookstore.GetAddressRequest\x1a\x1d.bookstore.GetAddressResponse\x12R\n" +
+	"\rUpdateAddress\x12\x1f.bookstore.UpdateAddressRequest\x1a .bookstore.UpdateAddressResponse\x12R\n" +
+	"\rDeleteAddress\x12\x1f.bookstore.DeleteAddressRequest\x1a .bookstore.DeleteAddressResponse2\xb8\x03\n" +
 	"\x0fCategoryService\x12U\n" +
 	"\x0eCreateCategory\x12 .bookstore.CreateCategoryRequest\x1a!.bookstore.CreateCategoryResponse\x12R\n" +
 	"\rGetCategories\x12\x1f.bookstore.GetCategoriesRequest\x1a .bookstore.GetCategoriesResponse\x12L\n" +
 	"\vGetCategory\x12\x1d.bookstore.GetCategoryRequest\x1a\x1e.bookstore.GetCategoryResponse\x12U\n" +
 	"\x0eUpdateCategory\x12 .bookstore.UpdateCategoryRequest\x1a!.bookstore.UpdateCategoryResponse\x12U\n" +
-	"\x0eDeleteCategory\x12 .bookstore.DeleteCategoryRequest\x1a!.bookstore.DeleteCategoryResponse2\xd8\x03\n" +
+	"\x0eDeleteCategory\x12 .bookstore.DeleteCategoryRequest\x1a!.bookstore.DeleteCategoryResponse2\xd9\x05\n" +
 	"\vBookService\x12I\n" +
 	"\n" +
 	"CreateBook\x12\x1c.bookstore.CreateBookRequest\x1a\x1d.bookstore.CreateBookResponse\x12C\n" +
 	"\bGetBooks\x12\x1a.bookstore.GetBooksRequest\x1a\x1b.bookstore.GetBooksResponse\x12@\n" +
-	"\aGetBook\x12\x19.bookstore.GetBookRequest\x1a\x1a.bookstore.GetBookResponse\x12I\n" +
+	"\aGetBook\x12\x19.bookstore.GetBookRequest\x1a\x1a.bookstore.GetBookResponse\x12R\n" +
+	"\rGetBookByISBN\x12\x1f.bookstore.GetBookByISBNRequest\x1a .bookstore.GetBookByISBNResponse\x12I\n" +
 	"\n" +
-	"UpdateBook\x12\x1c.bookstore.UpdateBookRequest\x1a\x1d.bookstore.UpdateBookResponse\x12I\n" +
+	"UpdateBook\x12\x1c.bookstore.UpdateBookRequest\x1a\x1d.bookstore.UpdateBookResponse\x12Z\n" +
+	"\x0fUploadBookCover\x12!.bookstore.UploadBookCoverRequest\x1a\".bookstore.UploadBookCoverResponse(\x01\x12I\n" +
 	"\n" +
 	"DeleteBook\x12\x1c.bookstore.DeleteBookRequest\x1a\x1d.bookstore.DeleteBookResponse\x12a\n" +
-	"\x12GetBooksByCategory\x12$.bookstore.GetBooksByCategoryRequest\x1a%.bookstore.GetBooksByCategoryResponse2\xa0\x03\n" +
+	"\x12GetBooksByCategory\x12$.bookstore.GetBooksByCategoryRequest\x1a%.bookstore.GetBooksByCategoryResponse\x12O\n" +
+	"\fSuggestBooks\x12\x1e.bookstore.SuggestBooksRequest\x1a\x1f.bookstore.SuggestBooksResponse2\xa0\x03\n" +
 	"\fOrderService\x12L\n" +
 	"\vCreateOrder\x12\x1d.bookstore.CreateOrderRequest\x1a\x1e.bookstore.CreateOrderResponse\x12F\n" +
 	"\tGetOrders\x12\x1b.bookstore.GetOrdersRequest\x1a\x1c.bookstore.GetOrdersResponse\x12C\n" +
@@ -3744,7 +10101,7 @@ func file_proto_bookstore_proto_rawDescGZIP() []byte {
 	return file_proto_bookstore_proto_rawDescData
 }
 
-var file_proto_bookstore_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_proto_bookstore_proto_msgTypes = make([]protoimpl.MessageInfo, 144)
 var file_proto_bookstore_proto_goTypes = []any{
 	(*User)(nil),                           // 0: bookstore.User
 	(*RegisterRequest)(nil),                // 1: bookstore.RegisterRequest
@@ -3753,126 +10110,329 @@ var file_proto_bookstore_proto_goTypes = []any{
 	(*LoginResponse)(nil),                  // 4: bookstore.LoginResponse
 	(*GetProfileRequest)(nil),              // 5: bookstore.GetProfileRequest
 	(*GetProfileResponse)(nil),             // 6: bookstore.GetProfileResponse
-	(*Category)(nil),                       // 7: bookstore.Category
-	(*CreateCategoryRequest)(nil),          // 8: bookstore.CreateCategoryRequest
-	(*CreateCategoryResponse)(nil),         // 9: bookstore.CreateCategoryResponse
-	(*GetCategoriesRequest)(nil),           // 10: bookstore.GetCategoriesRequest
-	(*GetCategoriesResponse)(nil),          // 11: bookstore.GetCategoriesResponse
-	(*GetCategoryRequest)(nil),             // 12: bookstore.GetCategoryRequest
-	(*GetCategoryResponse)(nil),            // 13: bookstore.GetCategoryResponse
-	(*UpdateCategoryRequest)(nil),          // 14: bookstore.UpdateCategoryRequest
-	(*UpdateCategoryResponse)(nil),         // 15: bookstore.UpdateCategoryResponse
-	(*DeleteCategoryRequest)(nil),          // 16: bookstore.DeleteCategoryRequest
-	(*DeleteCategoryResponse)(nil),         // 17: bookstore.DeleteCategoryResponse
-	(*Book)(nil),                           // 18: bookstore.Book
-	(*CreateBookRequest)(nil),              // 19: bookstore.CreateBookRequest
-	(*CreateBookResponse)(nil),             // 20: bookstore.CreateBookResponse
-	(*GetBooksRequest)(nil),                // 21: bookstore.GetBooksRequest
-	(*GetBooksResponse)(nil),               // 22: bookstore.GetBooksResponse
-	(*GetBookRequest)(nil),                 // 23: bookstore.GetBookRequest
-	(*GetBookResponse)(nil),                // 24: bookstore.GetBookResponse
-	(*UpdateBookRequest)(nil),              // 25: bookstore.UpdateBookRequest
-	(*UpdateBookResponse)(nil),             // 26: bookstore.UpdateBookResponse
-	(*DeleteBookRequest)(nil),              // 27: bookstore.DeleteBookRequest
-	(*DeleteBookResponse)(nil),             // 28: bookstore.DeleteBookResponse
-	(*GetBooksByCategoryRequest)(nil),      // 29: bookstore.GetBooksByCategoryRequest
-	(*GetBooksByCategoryResponse)(nil),     // 30: bookstore.GetBooksByCategoryResponse
-	(*OrderItem)(nil),                      // 31: bookstore.OrderItem
-	(*Order)(nil),                          // 32: bookstore.Order
-	(*CreateOrderRequest)(nil),             // 33: bookstore.CreateOrderRequest
-	(*OrderItemRequest)(nil),               // 34: bookstore.OrderItemRequest
-	(*CreateOrderResponse)(nil),            // 35: bookstore.CreateOrderResponse
-	(*GetOrdersRequest)(nil),               // 36: bookstore.GetOrdersRequest
-	(*GetOrdersResponse)(nil),              // 37: bookstore.GetOrdersResponse
-	(*GetOrderRequest)(nil),                // 38: bookstore.GetOrderRequest
-	(*GetOrderResponse)(nil),               // 39: bookstore.GetOrderResponse
-	(*UpdateOrderStatusRequest)(nil),       // 40: bookstore.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil),      // 41: bookstore.UpdateOrderStatusResponse
-	(*ProcessPaymentRequest)(nil),          // 42: bookstore.ProcessPaymentRequest
-	(*ProcessPaymentResponse)(nil),         // 43: bookstore.ProcessPaymentResponse
-	(*SalesReportItem)(nil),                // 44: bookstore.SalesReportItem
-	(*GetSalesReportRequest)(nil),          // 45: bookstore.GetSalesReportRequest
-	(*GetSalesReportResponse)(nil),         // 46: bookstore.GetSalesReportResponse
-	(*TopBookItem)(nil),                    // 47: bookstore.TopBookItem
-	(*GetTopBooksRequest)(nil),             // 48: bookstore.GetTopBooksRequest
-	(*GetTopBooksResponse)(nil),            // 49: bookstore.GetTopBooksResponse
-	(*GetBookPriceStatisticsRequest)(nil),  // 50: bookstore.GetBookPriceStatisticsRequest
-	(*GetBookPriceStatisticsResponse)(nil), // 51: bookstore.GetBookPriceStatisticsResponse
+	(*UpdateProfileRequest)(nil),           // 7: bookstore.UpdateProfileRequest
+	(*UpdateProfileResponse)(nil),          // 8: bookstore.UpdateProfileResponse
+	(*ChangePasswordRequest)(nil),          // 9: bookstore.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),         // 10: bookstore.ChangePasswordResponse
+	(*RefreshTokenRequest)(nil),            // 11: bookstore.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),           // 12: bookstore.RefreshTokenResponse
+	(*LogoutRequest)(nil),                  // 13: bookstore.LogoutRequest
+	(*LogoutResponse)(nil),                 // 14: bookstore.LogoutResponse
+	(*LogoutAllDevicesRequest)(nil),        // 15: bookstore.LogoutAllDevicesRequest
+	(*LogoutAllDevicesResponse)(nil),       // 16: bookstore.LogoutAllDevicesResponse
+	(*EnrollMFARequest)(nil),               // 17: bookstore.EnrollMFARequest
+	(*EnrollMFAResponse)(nil),              // 18: bookstore.EnrollMFAResponse
+	(*ConfirmMFARequest)(nil),              // 19: bookstore.ConfirmMFARequest
+	(*ConfirmMFAResponse)(nil),             // 20: bookstore.ConfirmMFAResponse
+	(*VerifyMFARequest)(nil),               // 21: bookstore.VerifyMFARequest
+	(*VerifyMFAResponse)(nil),              // 22: bookstore.VerifyMFAResponse
+	(*RequestPasswordResetRequest)(nil),    // 23: bookstore.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil),   // 24: bookstore.RequestPasswordResetResponse
+	(*ConfirmPasswordResetRequest)(nil),    // 25: bookstore.ConfirmPasswordResetRequest
+	(*ConfirmPasswordResetResponse)(nil),   // 26: bookstore.ConfirmPasswordResetResponse
+	(*VerifyEmailRequest)(nil),             // 27: bookstore.VerifyEmailRequest
+	(*VerifyEmailResponse)(nil),            // 28: bookstore.VerifyEmailResponse
+	(*ResendVerificationRequest)(nil),      // 29: bookstore.ResendVerificationRequest
+	(*ResendVerificationResponse)(nil),     // 30: bookstore.ResendVerificationResponse
+	(*BeginOIDCLoginRequest)(nil),          // 31: bookstore.BeginOIDCLoginRequest
+	(*BeginOIDCLoginResponse)(nil),         // 32: bookstore.BeginOIDCLoginResponse
+	(*CompleteOIDCLoginRequest)(nil),       // 33: bookstore.CompleteOIDCLoginRequest
+	(*Session)(nil),                        // 34: bookstore.Session
+	(*ListMySessionsRequest)(nil),          // 35: bookstore.ListMySessionsRequest
+	(*ListMySessionsResponse)(nil),         // 36: bookstore.ListMySessionsResponse
+	(*RevokeSessionRequest)(nil),           // 37: bookstore.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),          // 38: bookstore.RevokeSessionResponse
+	(*ExportMyDataRequest)(nil),            // 39: bookstore.ExportMyDataRequest
+	(*DataExportChunk)(nil),                // 40: bookstore.DataExportChunk
+	(*DeleteMyAccountRequest)(nil),         // 41: bookstore.DeleteMyAccountRequest
+	(*DeleteMyAccountResponse)(nil),        // 42: bookstore.DeleteMyAccountResponse
+	(*ListUsersRequest)(nil),               // 43: bookstore.ListUsersRequest
+	(*ListUsersResponse)(nil),              // 44: bookstore.ListUsersResponse
+	(*GetUserRequest)(nil),                 // 45: bookstore.GetUserRequest
+	(*GetUserResponse)(nil),                // 46: bookstore.GetUserResponse
+	(*SetUserRoleRequest)(nil),             // 47: bookstore.SetUserRoleRequest
+	(*SetUserRoleResponse)(nil),            // 48: bookstore.SetUserRoleResponse
+	(*DisableUserRequest)(nil),             // 49: bookstore.DisableUserRequest
+	(*DisableUserResponse)(nil),            // 50: bookstore.DisableUserResponse
+	(*EnableUserRequest)(nil),              // 51: bookstore.EnableUserRequest
+	(*EnableUserResponse)(nil),             // 52: bookstore.EnableUserResponse
+	(*DeleteUserRequest)(nil),              // 53: bookstore.DeleteUserRequest
+	(*DeleteUserResponse)(nil),             // 54: bookstore.DeleteUserResponse
+	(*UnlockAccountRequest)(nil),           // 55: bookstore.UnlockAccountRequest
+	(*UnlockAccountResponse)(nil),          // 56: bookstore.UnlockAccountResponse
+	(*Permission)(nil),                     // 57: bookstore.Permission
+	(*Role)(nil),                           // 58: bookstore.Role
+	(*ListRolesRequest)(nil),               // 59: bookstore.ListRolesRequest
+	(*ListRolesResponse)(nil),              // 60: bookstore.ListRolesResponse
+	(*ListPermissionsRequest)(nil),         // 61: bookstore.ListPermissionsRequest
+	(*ListPermissionsResponse)(nil),        // 62: bookstore.ListPermissionsResponse
+	(*UpsertRoleRequest)(nil),              // 63: bookstore.UpsertRoleRequest
+	(*UpsertRoleResponse)(nil),             // 64: bookstore.UpsertRoleResponse
+	(*ExportUserDataRequest)(nil),          // 65: bookstore.ExportUserDataRequest
+	(*AnonymizeUserRequest)(nil),           // 66: bookstore.AnonymizeUserRequest
+	(*AnonymizeUserResponse)(nil),          // 67: bookstore.AnonymizeUserResponse
+	(*ImpersonateUserRequest)(nil),         // 68: bookstore.ImpersonateUserRequest
+	(*ImpersonateUserResponse)(nil),        // 69: bookstore.ImpersonateUserResponse
+	(*APIKey)(nil),                         // 70: bookstore.APIKey
+	(*CreateAPIKeyRequest)(nil),            // 71: bookstore.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),           // 72: bookstore.CreateAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),             // 73: bookstore.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),            // 74: bookstore.ListAPIKeysResponse
+	(*RevokeAPIKeyRequest)(nil),            // 75: bookstore.RevokeAPIKeyRequest
+	(*RevokeAPIKeyResponse)(nil),           // 76: bookstore.RevokeAPIKeyResponse
+	(*AuditEvent)(nil),                     // 77: bookstore.AuditEvent
+	(*ListAuditEventsRequest)(nil),         // 78: bookstore.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),        // 79: bookstore.ListAuditEventsResponse
+	(*Address)(nil),                        // 80: bookstore.Address
+	(*CreateAddressRequest)(nil),           // 81: bookstore.CreateAddressRequest
+	(*CreateAddressResponse)(nil),          // 82: bookstore.CreateAddressResponse
+	(*ListAddressesRequest)(nil),           // 83: bookstore.ListAddressesRequest
+	(*ListAddressesResponse)(nil),          // 84: bookstore.ListAddressesResponse
+	(*GetAddressRequest)(nil),              // 85: bookstore.GetAddressRequest
+	(*GetAddressResponse)(nil),             // 86: bookstore.GetAddressResponse
+	(*UpdateAddressRequest)(nil),           // 87: bookstore.UpdateAddressRequest
+	(*UpdateAddressResponse)(nil),          // 88: bookstore.UpdateAddressResponse
+	(*DeleteAddressRequest)(nil),           // 89: bookstore.DeleteAddressRequest
+	(*DeleteAddressResponse)(nil),          // 90: bookstore.DeleteAddressResponse
+	(*Category)(nil),                       // 91: bookstore.Category
+	(*CreateCategoryRequest)(nil),          // 92: bookstore.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),         // 93: bookstore.CreateCategoryResponse
+	(*GetCategoriesRequest)(nil),           // 94: bookstore.GetCategoriesRequest
+	(*GetCategoriesResponse)(nil),          // 95: bookstore.GetCategoriesResponse
+	(*GetCategoryRequest)(nil),             // 96: bookstore.GetCategoryRequest
+	(*GetCategoryResponse)(nil),            // 97: bookstore.GetCategoryResponse
+	(*UpdateCategoryRequest)(nil),          // 98: bookstore.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),         // 99: bookstore.UpdateCategoryResponse
+	(*DeleteCategoryRequest)(nil),          // 100: bookstore.DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),         // 101: bookstore.DeleteCategoryResponse
+	(*Book)(nil),                           // 102: bookstore.Book
+	(*CreateBookRequest)(nil),              // 103: bookstore.CreateBookRequest
+	(*CreateBookResponse)(nil),             // 104: bookstore.CreateBookResponse
+	(*GetBooksRequest)(nil),                // 105: bookstore.GetBooksRequest
+	(*GetBooksResponse)(nil),               // 106: bookstore.GetBooksResponse
+	(*GetBookRequest)(nil),                 // 107: bookstore.GetBookRequest
+	(*GetBookResponse)(nil),                // 108: bookstore.GetBookResponse
+	(*GetBookByISBNRequest)(nil),           // 109: bookstore.GetBookByISBNRequest
+	(*GetBookByISBNResponse)(nil),          // 110: bookstore.GetBookByISBNResponse
+	(*UpdateBookRequest)(nil),              // 111: bookstore.UpdateBookRequest
+	(*UpdateBookResponse)(nil),             // 112: bookstore.UpdateBookResponse
+	(*UploadBookCoverRequest)(nil),         // 113: bookstore.UploadBookCoverRequest
+	(*UploadBookCoverResponse)(nil),        // 114: bookstore.UploadBookCoverResponse
+	(*DeleteBookRequest)(nil),              // 115: bookstore.DeleteBookRequest
+	(*DeleteBookResponse)(nil),             // 116: bookstore.DeleteBookResponse
+	(*GetBooksByCategoryRequest)(nil),      // 117: bookstore.GetBooksByCategoryRequest
+	(*GetBooksByCategoryResponse)(nil),     // 118: bookstore.GetBooksByCategoryResponse
+	(*SuggestBooksRequest)(nil),            // 119: bookstore.SuggestBooksRequest
+	(*BookSuggestion)(nil),                 // 120: bookstore.BookSuggestion
+	(*SuggestBooksResponse)(nil),           // 121: bookstore.SuggestBooksResponse
+	(*OrderItem)(nil),                      // 122: bookstore.OrderItem
+	(*Order)(nil),                          // 123: bookstore.Order
+	(*ShippingAddress)(nil),                // 124: bookstore.ShippingAddress
+	(*CreateOrderRequest)(nil),             // 125: bookstore.CreateOrderRequest
+	(*OrderItemRequest)(nil),               // 126: bookstore.OrderItemRequest
+	(*CreateOrderResponse)(nil),            // 127: bookstore.CreateOrderResponse
+	(*GetOrdersRequest)(nil),               // 128: bookstore.GetOrdersRequest
+	(*GetOrdersResponse)(nil),              // 129: bookstore.GetOrdersResponse
+	(*GetOrderRequest)(nil),                // 130: bookstore.GetOrderRequest
+	(*GetOrderResponse)(nil),               // 131: bookstore.GetOrderResponse
+	(*UpdateOrderStatusRequest)(nil),       // 132: bookstore.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),      // 133: bookstore.UpdateOrderStatusResponse
+	(*ProcessPaymentRequest)(nil),          // 134: bookstore.ProcessPaymentRequest
+	(*ProcessPaymentResponse)(nil),         // 135: bookstore.ProcessPaymentResponse
+	(*SalesReportItem)(nil),                // 136: bookstore.SalesReportItem
+	(*GetSalesReportRequest)(nil),          // 137: bookstore.GetSalesReportRequest
+	(*GetSalesReportResponse)(nil),         // 138: bookstore.GetSalesReportResponse
+	(*TopBookItem)(nil),                    // 139: bookstore.TopBookItem
+	(*GetTopBooksRequest)(nil),             // 140: bookstore.GetTopBooksRequest
+	(*GetTopBooksResponse)(nil),            // 141: bookstore.GetTopBooksResponse
+	(*GetBookPriceStatisticsRequest)(nil),  // 142: bookstore.GetBookPriceStatisticsRequest
+	(*GetBookPriceStatisticsResponse)(nil), // 143: bookstore.GetBookPriceStatisticsResponse
 }
 var file_proto_bookstore_proto_depIdxs = []int32{
-	0,  // 0: bookstore.RegisterResponse.user:type_name -> bookstore.User
-	0,  // 1: bookstore.LoginResponse.user:type_name -> bookstore.User
-	0,  // 2: bookstore.GetProfileResponse.user:type_name -> bookstore.User
-	7,  // 3: bookstore.CreateCategoryResponse.category:type_name -> bookstore.Category
-	7,  // 4: bookstore.GetCategoriesResponse.categories:type_name -> bookstore.Category
-	7,  // 5: bookstore.GetCategoryResponse.category:type_name -> bookstore.Category
-	7,  // 6: bookstore.UpdateCategoryResponse.category:type_name -> bookstore.Category
-	7,  // 7: bookstore.Book.category:type_name -> bookstore.Category
-	18, // 8: bookstore.CreateBookResponse.book:type_name -> bookstore.Book
-	18, // 9: bookstore.GetBooksResponse.books:type_name -> bookstore.Book
-	18, // 10: bookstore.GetBookResponse.book:type_name -> bookstore.Book
-	18, // 11: bookstore.UpdateBookResponse.book:type_name -> bookstore.Book
-	18, // 12: bookstore.GetBooksByCategoryResponse.books:type_name -> bookstore.Book
-	18, // 13: bookstore.OrderItem.book:type_name -> bookstore.Book
-	0,  // 14: bookstore.Order.user:type_name -> bookstore.User
-	31, // 15: bookstore.Order.items:type_name -> bookstore.OrderItem
-	34, // 16: bookstore.CreateOrderRequest.items:type_name -> bookstore.OrderItemRequest
-	32, // 17: bookstore.CreateOrderResponse.order:type_name -> bookstore.Order
-	32, // 18: bookstore.GetOrdersResponse.orders:type_name -> bookstore.Order
-	32, // 19: bookstore.GetOrderResponse.order:type_name -> bookstore.Order
-	32, // 20: bookstore.UpdateOrderStatusResponse.order:type_name -> bookstore.Order
-	44, // 21: bookstore.GetSalesReportResponse.report:type_name -> bookstore.SalesReportItem
-	18, // 22: bookstore.TopBookItem.book:type_name -> bookstore.Book
-	47, // 23: bookstore.GetTopBooksResponse.books:type_name -> bookstore.TopBookItem
-	1,  // 24: bookstore.UserService.Register:input_type -> bookstore.RegisterRequest
-	3,  // 25: bookstore.UserService.Login:input_type -> bookstore.LoginRequest
-	5,  // 26: bookstore.UserService.GetProfile:input_type -> bookstore.GetProfileRequest
-	8,  // 27: bookstore.CategoryService.CreateCategory:input_type -> bookstore.CreateCategoryRequest
-	10, // 28: bookstore.CategoryService.GetCategories:input_type -> bookstore.GetCategoriesRequest
-	12, // 29: bookstore.CategoryService.GetCategory:input_type -> bookstore.GetCategoryRequest
-	14, // 30: bookstore.CategoryService.UpdateCategory:input_type -> bookstore.UpdateCategoryRequest
-	16, // 31: bookstore.CategoryService.DeleteCategory:input_type -> bookstore.DeleteCategoryRequest
-	19, // 32: bookstore.BookService.CreateBook:input_type -> bookstore.CreateBookRequest
-	21, // 33: bookstore.BookService.GetBooks:input_type -> bookstore.GetBooksRequest
-	23, // 34: bookstore.BookService.GetBook:input_type -> bookstore.GetBookRequest
-	25, // 35: bookstore.BookService.UpdateBook:input_type -> bookstore.UpdateBookRequest
-	27, // 36: bookstore.BookService.DeleteBook:input_type -> bookstore.DeleteBookRequest
-	29, // 37: bookstore.BookService.GetBooksByCategory:input_type -> bookstore.GetBooksByCategoryRequest
-	33, // 38: bookstore.OrderService.CreateOrder:input_type -> bookstore.CreateOrderRequest
-	36, // 39: bookstore.OrderService.GetOrders:input_type -> bookstore.GetOrdersRequest
-	38, // 40: bookstore.OrderService.GetOrder:input_type -> bookstore.GetOrderRequest
-	40, // 41: bookstore.OrderService.UpdateOrderStatus:input_type -> bookstore.UpdateOrderStatusRequest
-	42, // 42: bookstore.OrderService.ProcessPayment:input_type -> bookstore.ProcessPaymentRequest
-	45, // 43: bookstore.ReportService.GetSalesReport:input_type -> bookstore.GetSalesReportRequest
-	48, // 44: bookstore.ReportService.GetTopBooks:input_type -> bookstore.GetTopBooksRequest
-	50, // 45: bookstore.ReportService.GetBookPriceStatistics:input_type -> bookstore.GetBookPriceStatisticsRequest
-	2,  // 46: bookstore.UserService.Register:output_type -> bookstore.RegisterResponse
-	4,  // 47: bookstore.UserService.Login:output_type -> bookstore.LoginResponse
-	6,  // 48: bookstore.UserService.GetProfile:output_type -> bookstore.GetProfileResponse
-	9,  // 49: bookstore.CategoryService.CreateCategory:output_type -> bookstore.CreateCategoryResponse
-	11, // 50: bookstore.CategoryService.GetCategories:output_type -> bookstore.GetCategoriesResponse
-	13, // 51: bookstore.CategoryService.GetCategory:output_type -> bookstore.GetCategoryResponse
-	15, // 52: bookstore.CategoryService.UpdateCategory:output_type -> bookstore.UpdateCategoryResponse
-	17, // 53: bookstore.CategoryService.DeleteCategory:output_type -> bookstore.DeleteCategoryResponse
-	20, // 54: bookstore.BookService.CreateBook:output_type -> bookstore.CreateBookResponse
-	22, // 55: bookstore.BookService.GetBooks:output_type -> bookstore.GetBooksResponse
-	24, // 56: bookstore.BookService.GetBook:output_type -> bookstore.GetBookResponse
-	26, // 57: bookstore.BookService.UpdateBook:output_type -> bookstore.UpdateBookResponse
-	28, // 58: bookstore.BookService.DeleteBook:output_type -> bookstore.DeleteBookResponse
-	30, // 59: bookstore.BookService.GetBooksByCategory:output_type -> bookstore.GetBooksByCategoryResponse
-	35, // 60: bookstore.OrderService.CreateOrder:output_type -> bookstore.CreateOrderResponse
-	37, // 61: bookstore.OrderService.GetOrders:output_type -> bookstore.GetOrdersResponse
-	39, // 62: bookstore.OrderService.GetOrder:output_type -> bookstore.GetOrderResponse
-	41, // 63: bookstore.OrderService.UpdateOrderStatus:output_type -> bookstore.UpdateOrderStatusResponse
-	43, // 64: bookstore.OrderService.ProcessPayment:output_type -> bookstore.ProcessPaymentResponse
-	46, // 65: bookstore.ReportService.GetSalesReport:output_type -> bookstore.GetSalesReportResponse
-	49, // 66: bookstore.ReportService.GetTopBooks:output_type -> bookstore.GetTopBooksResponse
-	51, // 67: bookstore.ReportService.GetBookPriceStatistics:output_type -> bookstore.GetBookPriceStatisticsResponse
-	46, // [46:68] is the sub-list for method output_type
-	24, // [24:46] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	0,   // 0: bookstore.RegisterResponse.user:type_name -> bookstore.User
+	0,   // 1: bookstore.LoginResponse.user:type_name -> bookstore.User
+	0,   // 2: bookstore.GetProfileResponse.user:type_name -> bookstore.User
+	0,   // 3: bookstore.UpdateProfileResponse.user:type_name -> bookstore.User
+	0,   // 4: bookstore.VerifyMFAResponse.user:type_name -> bookstore.User
+	0,   // 5: bookstore.VerifyEmailResponse.user:type_name -> bookstore.User
+	34,  // 6: bookstore.ListMySessionsResponse.sessions:type_name -> bookstore.Session
+	0,   // 7: bookstore.ListUsersResponse.users:type_name -> bookstore.User
+	0,   // 8: bookstore.GetUserResponse.user:type_name -> bookstore.User
+	0,   // 9: bookstore.SetUserRoleResponse.user:type_name -> bookstore.User
+	0,   // 10: bookstore.DisableUserResponse.user:type_name -> bookstore.User
+	0,   // 11: bookstore.EnableUserResponse.user:type_name -> bookstore.User
+	58,  // 12: bookstore.ListRolesResponse.roles:type_name -> bookstore.Role
+	57,  // 13: bookstore.ListPermissionsResponse.permissions:type_name -> bookstore.Permission
+	58,  // 14: bookstore.UpsertRoleResponse.role:type_name -> bookstore.Role
+	0,   // 15: bookstore.AnonymizeUserResponse.user:type_name -> bookstore.User
+	0,   // 16: bookstore.ImpersonateUserResponse.user:type_name -> bookstore.User
+	70,  // 17: bookstore.CreateAPIKeyResponse.api_key:type_name -> bookstore.APIKey
+	70,  // 18: bookstore.ListAPIKeysResponse.api_keys:type_name -> bookstore.APIKey
+	77,  // 19: bookstore.ListAuditEventsResponse.events:type_name -> bookstore.AuditEvent
+	80,  // 20: bookstore.CreateAddressResponse.address:type_name -> bookstore.Address
+	80,  // 21: bookstore.ListAddressesResponse.addresses:type_name -> bookstore.Address
+	80,  // 22: bookstore.GetAddressResponse.address:type_name -> bookstore.Address
+	80,  // 23: bookstore.UpdateAddressResponse.address:type_name -> bookstore.Address
+	91,  // 24: bookstore.CreateCategoryResponse.category:type_name -> bookstore.Category
+	91,  // 25: bookstore.GetCategoriesResponse.categories:type_name -> bookstore.Category
+	91,  // 26: bookstore.GetCategoryResponse.category:type_name -> bookstore.Category
+	91,  // 27: bookstore.UpdateCategoryResponse.category:type_name -> bookstore.Category
+	91,  // 28: bookstore.Book.category:type_name -> bookstore.Category
+	102, // 29: bookstore.CreateBookResponse.book:type_name -> bookstore.Book
+	102, // 30: bookstore.GetBooksResponse.books:type_name -> bookstore.Book
+	102, // 31: bookstore.GetBookResponse.book:type_name -> bookstore.Book
+	102, // 32: bookstore.GetBookByISBNResponse.book:type_name -> bookstore.Book
+	102, // 33: bookstore.UpdateBookResponse.book:type_name -> bookstore.Book
+	102, // 34: bookstore.UploadBookCoverResponse.book:type_name -> bookstore.Book
+	102, // 35: bookstore.GetBooksByCategoryResponse.books:type_name -> bookstore.Book
+	120, // 36: bookstore.SuggestBooksResponse.suggestions:type_name -> bookstore.BookSuggestion
+	102, // 37: bookstore.OrderItem.book:type_name -> bookstore.Book
+	0,   // 38: bookstore.Order.user:type_name -> bookstore.User
+	122, // 39: bookstore.Order.items:type_name -> bookstore.OrderItem
+	124, // 40: bookstore.Order.shipping_address:type_name -> bookstore.ShippingAddress
+	126, // 41: bookstore.CreateOrderRequest.items:type_name -> bookstore.OrderItemRequest
+	123, // 42: bookstore.CreateOrderResponse.order:type_name -> bookstore.Order
+	123, // 43: bookstore.GetOrdersResponse.orders:type_name -> bookstore.Order
+	123, // 44: bookstore.GetOrderResponse.order:type_name -> bookstore.Order
+	123, // 45: bookstore.UpdateOrderStatusResponse.order:type_name -> bookstore.Order
+	136, // 46: bookstore.GetSalesReportResponse.report:type_name -> bookstore.SalesReportItem
+	102, // 47: bookstore.TopBookItem.book:type_name -> bookstore.Book
+	139, // 48: bookstore.GetTopBooksResponse.books:type_name -> bookstore.TopBookItem
+	1,   // 49: bookstore.UserService.Register:input_type -> bookstore.RegisterRequest
+	3,   // 50: bookstore.UserService.Login:input_type -> bookstore.LoginRequest
+	5,   // 51: bookstore.UserService.GetProfile:input_type -> bookstore.GetProfileRequest
+	7,   // 52: bookstore.UserService.UpdateProfile:input_type -> bookstore.UpdateProfileRequest
+	9,   // 53: bookstore.UserService.ChangePassword:input_type -> bookstore.ChangePasswordRequest
+	11,  // 54: bookstore.UserService.RefreshToken:input_type -> bookstore.RefreshTokenRequest
+	13,  // 55: bookstore.UserService.Logout:input_type -> bookstore.LogoutRequest
+	15,  // 56: bookstore.UserService.LogoutAllDevices:input_type -> bookstore.LogoutAllDevicesRequest
+	17,  // 57: bookstore.UserService.EnrollMFA:input_type -> bookstore.EnrollMFARequest
+	19,  // 58: bookstore.UserService.ConfirmMFA:input_type -> bookstore.ConfirmMFARequest
+	21,  // 59: bookstore.UserService.VerifyMFA:input_type -> bookstore.VerifyMFARequest
+	23,  // 60: bookstore.UserService.RequestPasswordReset:input_type -> bookstore.RequestPasswordResetRequest
+	25,  // 61: bookstore.UserService.ConfirmPasswordReset:input_type -> bookstore.ConfirmPasswordResetRequest
+	27,  // 62: bookstore.UserService.VerifyEmail:input_type -> bookstore.VerifyEmailRequest
+	29,  // 63: bookstore.UserService.ResendVerification:input_type -> bookstore.ResendVerificationRequest
+	39,  // 64: bookstore.UserService.ExportMyData:input_type -> bookstore.ExportMyDataRequest
+	41,  // 65: bookstore.UserService.DeleteMyAccount:input_type -> bookstore.DeleteMyAccountRequest
+	31,  // 66: bookstore.UserService.BeginOIDCLogin:input_type -> bookstore.BeginOIDCLoginRequest
+	33,  // 67: bookstore.UserService.CompleteOIDCLogin:input_type -> bookstore.CompleteOIDCLoginRequest
+	35,  // 68: bookstore.UserService.ListMySessions:input_type -> bookstore.ListMySessionsRequest
+	37,  // 69: bookstore.UserService.RevokeSession:input_type -> bookstore.RevokeSessionRequest
+	43,  // 70: bookstore.AdminUserService.ListUsers:input_type -> bookstore.ListUsersRequest
+	45,  // 71: bookstore.AdminUserService.GetUser:input_type -> bookstore.GetUserRequest
+	47,  // 72: bookstore.AdminUserService.SetUserRole:input_type -> bookstore.SetUserRoleRequest
+	49,  // 73: bookstore.AdminUserService.DisableUser:input_type -> bookstore.DisableUserRequest
+	51,  // 74: bookstore.AdminUserService.EnableUser:input_type -> bookstore.EnableUserRequest
+	53,  // 75: bookstore.AdminUserService.DeleteUser:input_type -> bookstore.DeleteUserRequest
+	55,  // 76: bookstore.AdminUserService.UnlockAccount:input_type -> bookstore.UnlockAccountRequest
+	59,  // 77: bookstore.AdminUserService.ListRoles:input_type -> bookstore.ListRolesRequest
+	61,  // 78: bookstore.AdminUserService.ListPermissions:input_type -> bookstore.ListPermissionsRequest
+	63,  // 79: bookstore.AdminUserService.UpsertRole:input_type -> bookstore.UpsertRoleRequest
+	65,  // 80: bookstore.AdminUserService.ExportUserData:input_type -> bookstore.ExportUserDataRequest
+	66,  // 81: bookstore.AdminUserService.AnonymizeUser:input_type -> bookstore.AnonymizeUserRequest
+	68,  // 82: bookstore.AdminUserService.ImpersonateUser:input_type -> bookstore.ImpersonateUserRequest
+	71,  // 83: bookstore.APIKeyService.CreateAPIKey:input_type -> bookstore.CreateAPIKeyRequest
+	73,  // 84: bookstore.APIKeyService.ListAPIKeys:input_type -> bookstore.ListAPIKeysRequest
+	75,  // 85: bookstore.APIKeyService.RevokeAPIKey:input_type -> bookstore.RevokeAPIKeyRequest
+	78,  // 86: bookstore.AuditService.ListAuditEvents:input_type -> bookstore.ListAuditEventsRequest
+	81,  // 87: bookstore.AddressService.CreateAddress:input_type -> bookstore.CreateAddressRequest
+	83,  // 88: bookstore.AddressService.ListAddresses:input_type -> bookstore.ListAddressesRequest
+	85,  // 89: bookstore.AddressService.GetAddress:input_type -> bookstore.GetAddressRequest
+	87,  // 90: bookstore.AddressService.UpdateAddress:input_type -> bookstore.UpdateAddressRequest
+	89,  // 91: bookstore.AddressService.DeleteAddress:input_type -> bookstore.DeleteAddressRequest
+	92,  // 92: bookstore.CategoryService.CreateCategory:input_type -> bookstore.CreateCategoryRequest
+	94,  // 93: bookstore.CategoryService.GetCategories:input_type -> bookstore.GetCategoriesRequest
+	96,  // 94: bookstore.CategoryService.GetCategory:input_type -> bookstore.GetCategoryRequest
+	98,  // 95: bookstore.CategoryService.UpdateCategory:input_type -> bookstore.UpdateCategoryRequest
+	100, // 96: bookstore.CategoryService.DeleteCategory:input_type -> bookstore.DeleteCategoryRequest
+	103, // 97: bookstore.BookService.CreateBook:input_type -> bookstore.CreateBookRequest
+	105, // 98: bookstore.BookService.GetBooks:input_type -> bookstore.GetBooksRequest
+	107, // 99: bookstore.BookService.GetBook:input_type -> bookstore.GetBookRequest
+	109, // 100: bookstore.BookService.GetBookByISBN:input_type -> bookstore.GetBookByISBNRequest
+	111, // 101: bookstore.BookService.UpdateBook:input_type -> bookstore.UpdateBookRequest
+	113, // 102: bookstore.BookService.UploadBookCover:input_type -> bookstore.UploadBookCoverRequest
+	115, // 103: bookstore.BookService.DeleteBook:input_type -> bookstore.DeleteBookRequest
+	117, // 104: bookstore.BookService.GetBooksByCategory:input_type -> bookstore.GetBooksByCategoryRequest
+	119, // 105: bookstore.BookService.SuggestBooks:input_type -> bookstore.SuggestBooksRequest
+	125, // 106: bookstore.OrderService.CreateOrder:input_type -> bookstore.CreateOrderRequest
+	128, // 107: bookstore.OrderService.GetOrders:input_type -> bookstore.GetOrdersRequest
+	130, // 108: bookstore.OrderService.GetOrder:input_type -> bookstore.GetOrderRequest
+	132, // 109: bookstore.OrderService.UpdateOrderStatus:input_type -> bookstore.UpdateOrderStatusRequest
+	134, // 110: bookstore.OrderService.ProcessPayment:input_type -> bookstore.ProcessPaymentRequest
+	137, // 111: bookstore.ReportService.GetSalesReport:input_type -> bookstore.GetSalesReportRequest
+	140, // 112: bookstore.ReportService.GetTopBooks:input_type -> bookstore.GetTopBooksRequest
+	142, // 113: bookstore.ReportService.GetBookPriceStatistics:input_type -> bookstore.GetBookPriceStatisticsRequest
+	2,   // 114: bookstore.UserService.Register:output_type -> bookstore.RegisterResponse
+	4,   // 115: bookstore.UserService.Login:output_type -> bookstore.LoginResponse
+	6,   // 116: bookstore.UserService.GetProfile:output_type -> bookstore.GetProfileResponse
+	8,   // 117: bookstore.UserService.UpdateProfile:output_type -> bookstore.UpdateProfileResponse
+	10,  // 118: bookstore.UserService.ChangePassword:output_type -> bookstore.ChangePasswordResponse
+	12,  // 119: bookstore.UserService.RefreshToken:output_type -> bookstore.RefreshTokenResponse
+	14,  // 120: bookstore.UserService.Logout:output_type -> bookstore.LogoutResponse
+	16,  // 121: bookstore.UserService.LogoutAllDevices:output_type -> bookstore.LogoutAllDevicesResponse
+	18,  // 122: bookstore.UserService.EnrollMFA:output_type -> bookstore.EnrollMFAResponse
+	20,  // 123: bookstore.UserService.ConfirmMFA:output_type -> bookstore.ConfirmMFAResponse
+	22,  // 124: bookstore.UserService.VerifyMFA:output_type -> bookstore.VerifyMFAResponse
+	24,  // 125: bookstore.UserService.RequestPasswordReset:output_type -> bookstore.RequestPasswordResetResponse
+	26,  // 126: bookstore.UserService.ConfirmPasswordReset:output_type -> bookstore.ConfirmPasswordResetResponse
+	28,  // 127: bookstore.UserService.VerifyEmail:output_type -> bookstore.VerifyEmailResponse
+	30,  // 128: bookstore.UserService.ResendVerification:output_type -> bookstore.ResendVerificationResponse
+	40,  // 129: bookstore.UserService.ExportMyData:output_type -> bookstore.DataExportChunk
+	42,  // 130: bookstore.UserService.DeleteMyAccount:output_type -> bookstore.DeleteMyAccountResponse
+	32,  // 131: bookstore.UserService.BeginOIDCLogin:output_type -> bookstore.BeginOIDCLoginResponse
+	4,   // 132: bookstore.UserService.CompleteOIDCLogin:output_type -> bookstore.LoginResponse
+	36,  // 133: bookstore.UserService.ListMySessions:output_type -> bookstore.ListMySessionsResponse
+	38,  // 134: bookstore.UserService.RevokeSession:output_type -> bookstore.RevokeSessionResponse
+	44,  // 135: bookstore.AdminUserService.ListUsers:output_type -> bookstore.ListUsersResponse
+	46,  // 136: bookstore.AdminUserService.GetUser:output_type -> bookstore.GetUserResponse
+	48,  // 137: bookstore.AdminUserService.SetUserRole:output_type -> bookstore.SetUserRoleResponse
+	50,  // 138: bookstore.AdminUserService.DisableUser:output_type -> bookstore.DisableUserResponse
+	52,  // 139: bookstore.AdminUserService.EnableUser:output_type -> bookstore.EnableUserResponse
+	54,  // 140: bookstore.AdminUserService.DeleteUser:output_type -> bookstore.DeleteUserResponse
+	56,  // 141: bookstore.AdminUserService.UnlockAccount:output_type -> bookstore.UnlockAccountResponse
+	60,  // 142: bookstore.AdminUserService.ListRoles:output_type -> bookstore.ListRolesResponse
+	62,  // 143: bookstore.AdminUserService.ListPermissions:output_type -> bookstore.ListPermissionsResponse
+	64,  // 144: bookstore.AdminUserService.UpsertRole:output_type -> bookstore.UpsertRoleResponse
+	40,  // 145: bookstore.AdminUserService.ExportUserData:output_type -> bookstore.DataExportChunk
+	67,  // 146: bookstore.AdminUserService.AnonymizeUser:output_type -> bookstore.AnonymizeUserResponse
+	69,  // 147: bookstore.AdminUserService.ImpersonateUser:output_type -> bookstore.ImpersonateUserResponse
+	72,  // 148: bookstore.APIKeyService.CreateAPIKey:output_type -> bookstore.CreateAPIKeyResponse
+	74,  // 149: bookstore.APIKeyService.ListAPIKeys:output_type -> bookstore.ListAPIKeysResponse
+	76,  // 150: bookstore.APIKeyService.RevokeAPIKey:output_type -> bookstore.RevokeAPIKeyResponse
+	79,  // 151: bookstore.AuditService.ListAuditEvents:output_type -> bookstore.ListAuditEventsResponse
+	82,  // 152: bookstore.AddressService.CreateAddress:output_type -> bookstore.CreateAddressResponse
+	84,  // 153: bookstore.AddressService.ListAddresses:output_type -> bookstore.ListAddressesResponse
+	86,  // 154: bookstore.AddressService.GetAddress:output_type -> bookstore.GetAddressResponse
+	88,  // 155: bookstore.AddressService.UpdateAddress:output_type -> bookstore.UpdateAddressResponse
+	90,  // 156: bookstore.AddressService.DeleteAddress:output_type -> bookstore.DeleteAddressResponse
+	93,  // 157: bookstore.CategoryService.CreateCategory:output_type -> bookstore.CreateCategoryResponse
+	95,  // 158: bookstore.CategoryService.GetCategories:output_type -> bookstore.GetCategoriesResponse
+	97,  // 159: bookstore.CategoryService.GetCategory:output_type -> bookstore.GetCategoryResponse
+	99,  // 160: bookstore.CategoryService.UpdateCategory:output_type -> bookstore.UpdateCategoryResponse
+	101, // 161: bookstore.CategoryService.DeleteCategory:output_type -> bookstore.DeleteCategoryResponse
+	104, // 162: bookstore.BookService.CreateBook:output_type -> bookstore.CreateBookResponse
+	106, // 163: bookstore.BookService.GetBooks:output_type -> bookstore.GetBooksResponse
+	108, // 164: bookstore.BookService.GetBook:output_type -> bookstore.GetBookResponse
+	110, // 165: bookstore.BookService.GetBookByISBN:output_type -> bookstore.GetBookByISBNResponse
+	112, // 166: bookstore.BookService.UpdateBook:output_type -> bookstore.UpdateBookResponse
+	114, // 167: bookstore.BookService.UploadBookCover:output_type -> bookstore.UploadBookCoverResponse
+	116, // 168: bookstore.BookService.DeleteBook:output_type -> bookstore.DeleteBookResponse
+	118, // 169: bookstore.BookService.GetBooksByCategory:output_type -> bookstore.GetBooksByCategoryResponse
+	121, // 170: bookstore.BookService.SuggestBooks:output_type -> bookstore.SuggestBooksResponse
+	127, // 171: bookstore.OrderService.CreateOrder:output_type -> bookstore.CreateOrderResponse
+	129, // 172: bookstore.OrderService.GetOrders:output_type -> bookstore.GetOrdersResponse
+	131, // 173: bookstore.OrderService.GetOrder:output_type -> bookstore.GetOrderResponse
+	133, // 174: bookstore.OrderService.UpdateOrderStatus:output_type -> bookstore.UpdateOrderStatusResponse
+	135, // 175: bookstore.OrderService.ProcessPayment:output_type -> bookstore.ProcessPaymentResponse
+	138, // 176: bookstore.ReportService.GetSalesReport:output_type -> bookstore.GetSalesReportResponse
+	141, // 177: bookstore.ReportService.GetTopBooks:output_type -> bookstore.GetTopBooksResponse
+	143, // 178: bookstore.ReportService.GetBookPriceStatistics:output_type -> bookstore.GetBookPriceStatisticsResponse
+	114, // [114:179] is the sub-list for method output_type
+	49,  // [49:114] is the sub-list for method input_type
+	49,  // [49:49] is the sub-list for extension type_name
+	49,  // [49:49] is the sub-list for extension extendee
+	0,   // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_proto_bookstore_proto_init() }
@@ -3880,15 +10440,19 @@ func file_proto_bookstore_proto_init() {
 	if File_proto_bookstore_proto != nil {
 		return
 	}
+	file_proto_bookstore_proto_msgTypes[113].OneofWrappers = []any{
+		(*UploadBookCoverRequest_BookId)(nil),
+		(*UploadBookCoverRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_bookstore_proto_rawDesc), len(file_proto_bookstore_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   52,
+			NumMessages:   144,
 			NumExtensions: 0,
-			NumServices:   5,
+			NumServices:   9,
 		},
 		GoTypes:           file_proto_bookstore_proto_goTypes,
 		DependencyIndexes: file_proto_bookstore_proto_depIdxs,